@@ -0,0 +1,21 @@
+package main
+
+import (
+	"github.com/gorilla/mux"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/net/router"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/proxy"
+)
+
+func main() {
+	catalog.StartLambda(func(function *catalog.Lambda) (pkglambda.Handler, error) {
+		// The same routes as the HTTP server, validated and scoped alike.
+		r := mux.NewRouter()
+		if err := router.Register(r, function.Factory, function.Logger, function.Tracer); err != nil {
+			return nil, err
+		}
+		return proxy.NewAPIGatewayProxyHandler(r), nil
+	})
+}
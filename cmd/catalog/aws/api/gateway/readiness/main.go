@@ -0,0 +1,17 @@
+package main
+
+import (
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+func main() {
+	catalog.StartLambda(func(function *catalog.Lambda) (pkglambda.Handler, error) {
+		healthChecker, err := pkgapplication.Create[pkgapplication.HealthChecker](function.Factory, "HealthChecker")
+		if err != nil {
+			return nil, err
+		}
+		return pkglambda.NewReadinessHandler(healthChecker), nil
+	})
+}
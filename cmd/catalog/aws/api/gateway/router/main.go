@@ -0,0 +1,60 @@
+package main
+
+import (
+	"net/http"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	awsadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/aws/adapter"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+func main() {
+	catalog.StartLambda(func(function *catalog.Lambda) (pkglambda.Handler, error) {
+		router, err := registerLambdaHandlers(function.Factory)
+		if err != nil {
+			return nil, err
+		}
+		return function.Validator.WrapLambda(router.Handle), nil
+	})
+}
+
+func registerLambdaHandlers(factory pkgapplication.Factory) (*pkglambda.Router, error) {
+	addProductUseCase, err := pkgapplication.Create[application.AddProductUseCase](factory, "AddProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+	deleteProductUseCase, err := pkgapplication.Create[application.DeleteProductUseCase](factory, "DeleteProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+	getAllProductsUseCase, err := pkgapplication.Create[application.GetAllProductsUseCase](factory, "GetAllProductsUseCase")
+	if err != nil {
+		return nil, err
+	}
+	getProductUseCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+	updateProductUseCase, err := pkgapplication.Create[application.UpdateProductUseCase](factory, "UpdateProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+
+	addProductHandler := awsadapter.NewLambdaAddProductAdapter(addProductUseCase)
+	deleteProductHandler := awsadapter.NewLambdaDeleteProductAdapter(deleteProductUseCase)
+	getAllProductsHandler := awsadapter.NewLambdaGetAllProductsAdapter(getAllProductsUseCase)
+	getProductHandler := awsadapter.NewLambdaGetProductUseCaseAdapter(getProductUseCase)
+	updateProductHandler := awsadapter.NewLambdaUpdateProductUseCaseAdapter(updateProductUseCase)
+
+	router := pkglambda.NewRouter()
+	router.HandleFunc(http.MethodPost, "/products", addProductHandler.Handle)
+	router.HandleFunc(http.MethodGet, "/products", getAllProductsHandler.Handle)
+	router.HandleFunc(http.MethodGet, "/products/{id}", getProductHandler.Handle)
+	router.HandleFunc(http.MethodPut, "/products/{id}", updateProductHandler.Handle)
+	router.HandleFunc(http.MethodPatch, "/products/{id}", updateProductHandler.Handle)
+	router.HandleFunc(http.MethodDelete, "/products/{id}", deleteProductHandler.Handle)
+
+	return router, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	awsadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/aws/adapter"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/openapi"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/emulator"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/emf"
+)
+
+func main() {
+	configPath := flag.String("config", "serverless.yml", "path to the serverless config")
+	stage := flag.String("stage", "local", "stage reported in the request context")
+
+	defaults := config.Defaults()
+	defaults.Server.Addr = ":3000"
+	defaults.Repository = config.RepositoryConfig{Backend: config.BackendMemory, DSN: "file::memory:?cache=shared", Migrate: true}
+	cfg, err := config.Load(flag.CommandLine, os.Args[1:], config.WithDefaults(defaults))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	serverless, err := emulator.LoadFile(*configPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	logger, err := catalog.NewLogger(cfg.Log)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The emulated functions emit their metrics to stdout in the same format
+	// as the deployed ones.
+	metrics := emf.NewRegistry(os.Stdout, catalog.MetricsNamespace)
+	tracer := catalog.NewTracer(cfg.Tracing, logger)
+	factory, err := catalog.NewFactory(cfg, logger, metrics, tracer)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	handlers, err := registerLambdaHandlers(factory)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Run the handlers behind the same middleware as the deployed functions.
+	for name, handler := range handlers {
+		handlers[name] = catalog.LambdaMiddleware(cfg.Server, factory, logger, metrics, tracer)(handler)
+	}
+
+	apiGateway, err := emulator.NewEmulator(serverless, handlers, emulator.WithStage(*stage))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Emulating API Gateway for %s on %s with %s", *configPath, cfg.Server.Addr, cfg)
+	if err := catalog.NewServer(cfg.Server, apiGateway, logger, factory, tracer).Run(context.Background()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func registerLambdaHandlers(factory pkgapplication.Factory) (map[string]pkglambda.Handler, error) {
+	addProductUseCase, err := pkgapplication.Create[application.AddProductUseCase](factory, "AddProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+	deleteProductUseCase, err := pkgapplication.Create[application.DeleteProductUseCase](factory, "DeleteProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+	getAllProductsUseCase, err := pkgapplication.Create[application.GetAllProductsUseCase](factory, "GetAllProductsUseCase")
+	if err != nil {
+		return nil, err
+	}
+	getProductUseCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+	updateProductUseCase, err := pkgapplication.Create[application.UpdateProductUseCase](factory, "UpdateProductUseCase")
+	if err != nil {
+		return nil, err
+	}
+
+	healthChecker, err := pkgapplication.Create[pkgapplication.HealthChecker](factory, "HealthChecker")
+	if err != nil {
+		return nil, err
+	}
+
+	addProductHandler := awsadapter.NewLambdaAddProductAdapter(addProductUseCase)
+	deleteProductHandler := awsadapter.NewLambdaDeleteProductAdapter(deleteProductUseCase)
+	getAllProductsHandler := awsadapter.NewLambdaGetAllProductsAdapter(getAllProductsUseCase)
+	getProductHandler := awsadapter.NewLambdaGetProductUseCaseAdapter(getProductUseCase)
+	updateProductHandler := awsadapter.NewLambdaUpdateProductUseCaseAdapter(updateProductUseCase)
+
+	router := pkglambda.NewRouter()
+	router.HandleFunc(http.MethodPost, "/products", addProductHandler.Handle)
+	router.HandleFunc(http.MethodGet, "/products", getAllProductsHandler.Handle)
+	router.HandleFunc(http.MethodGet, "/products/{id}", getProductHandler.Handle)
+	router.HandleFunc(http.MethodPut, "/products/{id}", updateProductHandler.Handle)
+	router.HandleFunc(http.MethodPatch, "/products/{id}", updateProductHandler.Handle)
+	router.HandleFunc(http.MethodDelete, "/products/{id}", deleteProductHandler.Handle)
+
+	validator, err := openapi.NewValidator()
+	if err != nil {
+		return nil, err
+	}
+
+	// Keys match the function names in serverless.yml and serverless.router.yml.
+	return map[string]pkglambda.Handler{
+		"addProduct":     validator.WrapLambda(addProductHandler.Handle),
+		"deleteProduct":  validator.WrapLambda(deleteProductHandler.Handle),
+		"getAllProducts": validator.WrapLambda(getAllProductsHandler.Handle),
+		"getProduct":     validator.WrapLambda(getProductHandler.Handle),
+		"updateProduct":  validator.WrapLambda(updateProductHandler.Handle),
+		"catalogRouter":  validator.WrapLambda(router.Handle),
+		"readiness":      pkglambda.NewReadinessHandler(healthChecker),
+	}, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	dbadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/adapter"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/migrations"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/db/migrate"
+)
+
+const migrateUsage = `usage: %s migrate [flags] <command>
+
+commands:
+  up            apply pending migrations (all unless -steps is set)
+  down          roll back the latest migration (or -steps of them)
+  status        list migrations and whether they are applied
+  unlock        release the SQLite migration lock left by a crashed run
+  create NAME   write empty up/down files for a new migration
+
+flags:
+`
+
+// runMigrate implements the migrate subcommand against the database selected
+// by the usual configuration flags, environment and file.
+func runMigrate(args []string, out io.Writer) error {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), migrateUsage, os.Args[0])
+		fs.PrintDefaults()
+	}
+	steps := fs.Int("steps", 0, "number of migrations up applies or down rolls back")
+	dir := fs.String("dir", migrations.Dir, "directory create writes new migrations to")
+
+	cfg, err := config.Load(fs, args)
+	if err != nil {
+		return err
+	}
+
+	command := fs.Arg(0)
+	if command == "create" {
+		if fs.NArg() != 2 {
+			fs.Usage()
+			return fmt.Errorf("create expects a migration name")
+		}
+		paths, err := migrations.Create(*dir, fs.Arg(1))
+		for _, path := range paths {
+			fmt.Fprintf(out, "created %s\n", path)
+		}
+		return err
+	}
+	if command != "up" && command != "down" && command != "status" && command != "unlock" {
+		fs.Usage()
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+
+	if cfg.Repository.Backend != config.BackendSQLite && cfg.Repository.Backend != config.BackendPostgres {
+		return fmt.Errorf("the %s backend has no schema to migrate", cfg.Repository.Backend)
+	}
+	dbConn, err := dbadapter.OpenDatabase(cfg.Repository.Backend, cfg.Repository.DSN)
+	if err != nil {
+		return err
+	}
+	defer dbadapter.CloseDatabase(dbConn)
+
+	migrator, err := migrations.NewMigrator(dbConn, cfg.Repository.Backend)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	switch command {
+	case "up":
+		applied, err := migrator.Up(ctx, *steps)
+		printMigrations(out, "applied", applied)
+		return err
+	case "down":
+		reverted, err := migrator.Down(ctx, *steps)
+		printMigrations(out, "reverted", reverted)
+		return err
+	case "unlock":
+		if err := migrator.Unlock(ctx); err != nil {
+			return err
+		}
+		fmt.Fprintln(out, "unlocked")
+		return nil
+	default:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printStatuses(out, statuses)
+		return nil
+	}
+}
+
+func printMigrations(out io.Writer, verb string, migrations []migrate.Migration) {
+	if len(migrations) == 0 {
+		fmt.Fprintf(out, "nothing %s\n", verb)
+	}
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "%s %04d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
+
+func printStatuses(out io.Writer, statuses []migrate.Status) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.Format(time.RFC3339)
+		}
+		if status.Missing {
+			state = "applied, missing from source"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	w.Flush()
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"time"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
@@ -23,7 +24,7 @@ type AddProductOutput struct {
 }
 
 type AddProductUseCase interface {
-	Execute(input AddProductInput) (*AddProductOutput, error)
+	Execute(ctx context.Context, input AddProductInput) (*AddProductOutput, error)
 }
 
 type addProductUseCase struct {
@@ -36,13 +37,13 @@ func NewAddProductUseCase(ProductAdder domain.ProductAdder) AddProductUseCase {
 	}
 }
 
-func (u *addProductUseCase) Execute(input AddProductInput) (*AddProductOutput, error) {
+func (u *addProductUseCase) Execute(ctx context.Context, input AddProductInput) (*AddProductOutput, error) {
 	id := domain.ProductID(input.ID)
 	name := input.Name
 	description := input.Description
 	price := input.Price
 
-	product, err := u.productAdder.AddProduct(id, name, description, price)
+	product, err := u.productAdder.AddProduct(ctx, id, name, description, price)
 	if err != nil {
 		return nil, err
 	}
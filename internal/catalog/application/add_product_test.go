@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -68,12 +69,12 @@ func TestAddProductUseCase_Execute(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.mockOutput != nil || tt.mockError != nil {
 				mockProductAdder.EXPECT().
-					AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(tt.mockOutput, tt.mockError)
 			}
 
 			useCase := NewAddProductUseCase(mockProductAdder)
-			output, err := useCase.Execute(tt.input)
+			output, err := useCase.Execute(context.Background(), tt.input)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
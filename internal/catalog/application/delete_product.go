@@ -1,13 +1,17 @@
 package application
 
-import "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+import (
+	"context"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
 
 type DeleteProductInput struct {
 	ID string `json:"id"`
 }
 
 type DeleteProductUseCase interface {
-	Execute(input DeleteProductInput) error
+	Execute(ctx context.Context, input DeleteProductInput) error
 }
 
 type deleteProductUseCase struct {
@@ -20,10 +24,10 @@ func NewDeleteProductUseCase(productDeleter domain.ProductDeleter) *deleteProduc
 	}
 }
 
-func (u *deleteProductUseCase) Execute(input DeleteProductInput) error {
+func (u *deleteProductUseCase) Execute(ctx context.Context, input DeleteProductInput) error {
 	id := domain.ProductID(input.ID)
 
-	err := u.productDeleter.DeleteProduct(id)
+	err := u.productDeleter.DeleteProduct(ctx, id)
 	if err != nil {
 		return err
 	}
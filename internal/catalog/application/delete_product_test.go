@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -27,7 +28,7 @@ func TestDeleteProductUseCase_Execute(t *testing.T) {
 			name:  "Successful deletion of product",
 			input: DeleteProductInput{ID: "1"},
 			mockBehavior: func(m *mocks.MockProductDeleter, id domain.ProductID) {
-				m.EXPECT().DeleteProduct(id).Return(nil)
+				m.EXPECT().DeleteProduct(gomock.Any(), id).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -35,7 +36,7 @@ func TestDeleteProductUseCase_Execute(t *testing.T) {
 			name:  "Product not found",
 			input: DeleteProductInput{ID: "999"},
 			mockBehavior: func(m *mocks.MockProductDeleter, id domain.ProductID) {
-				m.EXPECT().DeleteProduct(id).Return(errors.New("product not found"))
+				m.EXPECT().DeleteProduct(gomock.Any(), id).Return(errors.New("product not found"))
 			},
 			expectedError: errors.New("product not found"),
 		},
@@ -43,7 +44,7 @@ func TestDeleteProductUseCase_Execute(t *testing.T) {
 			name:  "Database error",
 			input: DeleteProductInput{ID: "2"},
 			mockBehavior: func(m *mocks.MockProductDeleter, id domain.ProductID) {
-				m.EXPECT().DeleteProduct(id).Return(errors.New("database error"))
+				m.EXPECT().DeleteProduct(gomock.Any(), id).Return(errors.New("database error"))
 			},
 			expectedError: errors.New("database error"),
 		},
@@ -54,7 +55,7 @@ func TestDeleteProductUseCase_Execute(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(mockService, domain.ProductID(tc.input.ID))
 
-			err := useCase.Execute(tc.input)
+			err := useCase.Execute(context.Background(), tc.input)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"time"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
@@ -16,7 +17,7 @@ type GetAllProductsOutput struct {
 }
 
 type GetAllProductsUseCase interface {
-	Execute() ([]*GetAllProductsOutput, error)
+	Execute(ctx context.Context) ([]*GetAllProductsOutput, error)
 }
 
 type getAllProductsUseCase struct {
@@ -29,9 +30,9 @@ func NewGetAllProductsUseCase(productFinder domain.AllProductFinder) GetAllProdu
 	}
 }
 
-func (u *getAllProductsUseCase) Execute() ([]*GetAllProductsOutput, error) {
+func (u *getAllProductsUseCase) Execute(ctx context.Context) ([]*GetAllProductsOutput, error) {
 	productsOutput := []*GetAllProductsOutput{}
-	products, err := u.productFinder.GetAllProducts()
+	products, err := u.productFinder.GetAllProducts(ctx)
 	if err != nil {
 		return productsOutput, err
 	}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -28,7 +29,7 @@ func TestGetAllProductsUseCase_Execute(t *testing.T) {
 			mockBehavior: func(m *mocks.MockAllProductFinder) {
 				createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
 				updatedAt := time.Date(2023, 5, 2, 11, 0, 0, 0, time.UTC)
-				m.EXPECT().GetAllProducts().Return([]*domain.Product{
+				m.EXPECT().GetAllProducts(gomock.Any()).Return([]*domain.Product{
 					{
 						ID:          domain.ProductID("1"),
 						Name:        "Product 1",
@@ -70,7 +71,7 @@ func TestGetAllProductsUseCase_Execute(t *testing.T) {
 		{
 			name: "Error retrieving products",
 			mockBehavior: func(m *mocks.MockAllProductFinder) {
-				m.EXPECT().GetAllProducts().Return(nil, domain.ErrRepositoryProduct)
+				m.EXPECT().GetAllProducts(gomock.Any()).Return(nil, domain.ErrRepositoryProduct)
 			},
 			expectedProducts: []*GetAllProductsOutput{},
 			expectedError:    domain.ErrRepositoryProduct,
@@ -82,7 +83,7 @@ func TestGetAllProductsUseCase_Execute(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(mockFinder)
 
-			products, err := useCase.Execute()
+			products, err := useCase.Execute(context.Background())
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
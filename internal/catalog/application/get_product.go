@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"time"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
@@ -20,7 +21,7 @@ type GetProductOutput struct {
 }
 
 type GetProductUseCase interface {
-	Execute(input GetProductInput) (*GetProductOutput, error)
+	Execute(ctx context.Context, input GetProductInput) (*GetProductOutput, error)
 }
 
 type getProductsUseCase struct {
@@ -33,10 +34,10 @@ func NewGetProductUseCase(productFinder domain.ProductFinder) GetProductUseCase
 	}
 }
 
-func (u *getProductsUseCase) Execute(input GetProductInput) (*GetProductOutput, error) {
+func (u *getProductsUseCase) Execute(ctx context.Context, input GetProductInput) (*GetProductOutput, error) {
 	id := domain.ProductID(input.ID)
 
-	product, err := u.productFinder.GetProduct(id)
+	product, err := u.productFinder.GetProduct(ctx, id)
 	if err != nil {
 		return nil, err
 	}
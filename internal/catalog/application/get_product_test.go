@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -31,7 +32,7 @@ func TestGetProductsUseCase_Execute(t *testing.T) {
 			mockBehavior: func(m *mocks.MockProductFinder, id domain.ProductID) {
 				createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
 				updatedAt := time.Date(2023, 5, 2, 11, 0, 0, 0, time.UTC)
-				m.EXPECT().GetProduct(id).Return(&domain.Product{
+				m.EXPECT().GetProduct(gomock.Any(), id).Return(&domain.Product{
 					ID:          id,
 					Name:        "Test Product",
 					Description: "Test Description",
@@ -54,7 +55,7 @@ func TestGetProductsUseCase_Execute(t *testing.T) {
 			name:  "Product not found",
 			input: GetProductInput{ID: "999"},
 			mockBehavior: func(m *mocks.MockProductFinder, id domain.ProductID) {
-				m.EXPECT().GetProduct(id).Return(nil, domain.ErrNotFoundProduct)
+				m.EXPECT().GetProduct(gomock.Any(), id).Return(nil, domain.ErrNotFoundProduct)
 			},
 			expectedOutput: nil,
 			expectedError:  domain.ErrNotFoundProduct,
@@ -63,7 +64,7 @@ func TestGetProductsUseCase_Execute(t *testing.T) {
 			name:  "Error when retrieving product",
 			input: GetProductInput{ID: "1"},
 			mockBehavior: func(m *mocks.MockProductFinder, id domain.ProductID) {
-				m.EXPECT().GetProduct(id).Return(nil, errors.New("repository error"))
+				m.EXPECT().GetProduct(gomock.Any(), id).Return(nil, errors.New("repository error"))
 			},
 			expectedOutput: nil,
 			expectedError:  errors.New("repository error"),
@@ -75,7 +76,7 @@ func TestGetProductsUseCase_Execute(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(mockFinder, domain.ProductID(tc.input.ID))
 
-			output, err := useCase.Execute(tc.input)
+			output, err := useCase.Execute(context.Background(), tc.input)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+var outcomes = []struct {
+	err     error
+	outcome string
+}{
+	{domain.ErrInvalidProductID, "invalid_id"},
+	{domain.ErrInvalidProductName, "invalid_name"},
+	{domain.ErrInvalidProductDescription, "invalid_description"},
+	{domain.ErrInvalidProductPrice, "invalid_price"},
+	{domain.ErrAlreadyExistsProduct, "already_exists"},
+	{domain.ErrNotFoundProduct, "not_found"},
+	{domain.ErrRepositoryProduct, "repository_error"},
+	{domain.ErrRepositoryUnavailable, "repository_unavailable"},
+}
+
+// Outcome labels the result of a use case by the domain error it returned,
+// OutcomeSuccess without error and OutcomeError for any other error.
+func Outcome(err error) string {
+	if err == nil {
+		return OutcomeSuccess
+	}
+	for _, o := range outcomes {
+		if errors.Is(err, o.err) {
+			return o.outcome
+		}
+	}
+	return OutcomeError
+}
+
+type useCaseMetrics struct {
+	name       string
+	executions pkgapplication.Counter
+	duration   pkgapplication.Histogram
+}
+
+func newUseCaseMetrics(metrics pkgapplication.Metrics, name string) useCaseMetrics {
+	return useCaseMetrics{
+		name:       name,
+		executions: metrics.Counter("catalog_use_case_executions_total", "Use case executions by outcome.", "use_case", "outcome"),
+		duration:   metrics.Histogram("catalog_use_case_duration_seconds", "Use case execution time.", nil, "use_case"),
+	}
+}
+
+func (m useCaseMetrics) observe(start time.Time, err error) {
+	m.executions.Inc(m.name, Outcome(err))
+	m.duration.Observe(time.Since(start).Seconds(), m.name)
+}
+
+// InstrumentAddProductUseCase records the executions of useCase in metrics.
+func InstrumentAddProductUseCase(useCase AddProductUseCase, metrics pkgapplication.Metrics) AddProductUseCase {
+	return &instrumentedAddProductUseCase{next: useCase, metrics: newUseCaseMetrics(metrics, "AddProduct")}
+}
+
+type instrumentedAddProductUseCase struct {
+	next    AddProductUseCase
+	metrics useCaseMetrics
+}
+
+func (u *instrumentedAddProductUseCase) Execute(ctx context.Context, input AddProductInput) (*AddProductOutput, error) {
+	start := time.Now()
+	output, err := u.next.Execute(ctx, input)
+	u.metrics.observe(start, err)
+	return output, err
+}
+
+func InstrumentDeleteProductUseCase(useCase DeleteProductUseCase, metrics pkgapplication.Metrics) DeleteProductUseCase {
+	return &instrumentedDeleteProductUseCase{next: useCase, metrics: newUseCaseMetrics(metrics, "DeleteProduct")}
+}
+
+type instrumentedDeleteProductUseCase struct {
+	next    DeleteProductUseCase
+	metrics useCaseMetrics
+}
+
+func (u *instrumentedDeleteProductUseCase) Execute(ctx context.Context, input DeleteProductInput) error {
+	start := time.Now()
+	err := u.next.Execute(ctx, input)
+	u.metrics.observe(start, err)
+	return err
+}
+
+func InstrumentGetAllProductsUseCase(useCase GetAllProductsUseCase, metrics pkgapplication.Metrics) GetAllProductsUseCase {
+	return &instrumentedGetAllProductsUseCase{next: useCase, metrics: newUseCaseMetrics(metrics, "GetAllProducts")}
+}
+
+type instrumentedGetAllProductsUseCase struct {
+	next    GetAllProductsUseCase
+	metrics useCaseMetrics
+}
+
+func (u *instrumentedGetAllProductsUseCase) Execute(ctx context.Context) ([]*GetAllProductsOutput, error) {
+	start := time.Now()
+	output, err := u.next.Execute(ctx)
+	u.metrics.observe(start, err)
+	return output, err
+}
+
+func InstrumentGetProductUseCase(useCase GetProductUseCase, metrics pkgapplication.Metrics) GetProductUseCase {
+	return &instrumentedGetProductUseCase{next: useCase, metrics: newUseCaseMetrics(metrics, "GetProduct")}
+}
+
+type instrumentedGetProductUseCase struct {
+	next    GetProductUseCase
+	metrics useCaseMetrics
+}
+
+func (u *instrumentedGetProductUseCase) Execute(ctx context.Context, input GetProductInput) (*GetProductOutput, error) {
+	start := time.Now()
+	output, err := u.next.Execute(ctx, input)
+	u.metrics.observe(start, err)
+	return output, err
+}
+
+func InstrumentUpdateProductUseCase(useCase UpdateProductUseCase, metrics pkgapplication.Metrics) UpdateProductUseCase {
+	return &instrumentedUpdateProductUseCase{next: useCase, metrics: newUseCaseMetrics(metrics, "UpdateProduct")}
+}
+
+type instrumentedUpdateProductUseCase struct {
+	next    UpdateProductUseCase
+	metrics useCaseMetrics
+}
+
+func (u *instrumentedUpdateProductUseCase) Execute(ctx context.Context, input UpdateProductInput) (*UpdateProductOutput, error) {
+	start := time.Now()
+	output, err := u.next.Execute(ctx, input)
+	u.metrics.observe(start, err)
+	return output, err
+}
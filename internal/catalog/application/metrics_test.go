@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{err: nil, expected: OutcomeSuccess},
+		{err: domain.ErrNotFoundProduct, expected: "not_found"},
+		{err: fmt.Errorf("wrapped: %w", domain.ErrAlreadyExistsProduct), expected: "already_exists"},
+		{err: domain.ErrInvalidProductPrice, expected: "invalid_price"},
+		{err: domain.ErrRepositoryProduct, expected: "repository_error"},
+		{err: domain.ErrRepositoryUnavailable, expected: "repository_unavailable"},
+		{err: errors.New("boom"), expected: OutcomeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Outcome(tt.err))
+		})
+	}
+}
+
+func TestInstrumentedUseCases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry := prometheus.NewRegistry()
+
+	adder := mocks.NewMockProductAdder(ctrl)
+	adder.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, domain.ErrAlreadyExistsProduct)
+	_, err := InstrumentAddProductUseCase(NewAddProductUseCase(adder), registry).Execute(context.Background(), AddProductInput{ID: "1"})
+	assert.ErrorIs(t, err, domain.ErrAlreadyExistsProduct)
+
+	deleter := mocks.NewMockProductDeleter(ctrl)
+	deleter.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Return(nil)
+	assert.NoError(t, InstrumentDeleteProductUseCase(NewDeleteProductUseCase(deleter), registry).Execute(context.Background(), DeleteProductInput{ID: "1"}))
+
+	allFinder := mocks.NewMockAllProductFinder(ctrl)
+	allFinder.EXPECT().GetAllProducts(gomock.Any()).Return([]*domain.Product{}, nil)
+	_, err = InstrumentGetAllProductsUseCase(NewGetAllProductsUseCase(allFinder), registry).Execute(context.Background())
+	assert.NoError(t, err)
+
+	finder := mocks.NewMockProductFinder(ctrl)
+	finder.EXPECT().GetProduct(gomock.Any(), gomock.Any()).Return(nil, domain.ErrNotFoundProduct).Times(2)
+	instrumented := InstrumentGetProductUseCase(NewGetProductUseCase(finder), registry)
+	instrumented.Execute(context.Background(), GetProductInput{ID: "1"})
+	_, err = instrumented.Execute(context.Background(), GetProductInput{ID: "1"})
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+
+	updater := mocks.NewMockProductUpdater(ctrl)
+	updater.EXPECT().UpdateProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.Product{ID: "1"}, nil)
+	output, err := InstrumentUpdateProductUseCase(NewUpdateProductUseCase(updater), registry).Execute(context.Background(), UpdateProductInput{ID: "1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", output.ID)
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	for _, line := range []string{
+		`catalog_use_case_executions_total{use_case="AddProduct",outcome="already_exists"} 1`,
+		`catalog_use_case_executions_total{use_case="DeleteProduct",outcome="success"} 1`,
+		`catalog_use_case_executions_total{use_case="GetAllProducts",outcome="success"} 1`,
+		`catalog_use_case_executions_total{use_case="GetProduct",outcome="not_found"} 2`,
+		`catalog_use_case_executions_total{use_case="UpdateProduct",outcome="success"} 1`,
+		`catalog_use_case_duration_seconds_count{use_case="GetProduct"} 2`,
+	} {
+		assert.Contains(t, out.String(), line+"\n")
+	}
+}
@@ -1,47 +1,46 @@
 package application
 
 import (
-	"fmt"
-
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
 )
 
 func CreateAddProductUseCase(dependencies map[string]interface{}) (interface{}, error) {
-	service, ok := dependencies["ProductAdder"].(domain.ProductAdder)
-	if !ok || service == nil {
-		return nil, fmt.Errorf("missing or invalid ProductAdder dependency")
+	service, err := pkgapplication.Dependency[domain.ProductAdder](dependencies, "ProductAdder")
+	if err != nil {
+		return nil, err
 	}
 	return NewAddProductUseCase(service), nil
 }
 
 func CreateDeleteProductUseCase(dependencies map[string]interface{}) (interface{}, error) {
-	service, ok := dependencies["ProductDeleter"].(domain.ProductDeleter)
-	if !ok || service == nil {
-		return nil, fmt.Errorf("missing or invalid ProductDeleter dependency")
+	service, err := pkgapplication.Dependency[domain.ProductDeleter](dependencies, "ProductDeleter")
+	if err != nil {
+		return nil, err
 	}
 	return NewDeleteProductUseCase(service), nil
 }
 
 func CreateGetAllProductsUseCase(dependencies map[string]interface{}) (interface{}, error) {
-	finder, ok := dependencies["AllProductFinder"].(domain.AllProductFinder)
-	if !ok || finder == nil {
-		return nil, fmt.Errorf("missing or invalid AllProductFinder dependency")
+	finder, err := pkgapplication.Dependency[domain.AllProductFinder](dependencies, "AllProductFinder")
+	if err != nil {
+		return nil, err
 	}
 	return NewGetAllProductsUseCase(finder), nil
 }
 
 func CreateGetProductUseCase(dependencies map[string]interface{}) (interface{}, error) {
-	finder, ok := dependencies["ProductFinder"].(domain.ProductFinder)
-	if !ok || finder == nil {
-		return nil, fmt.Errorf("missing or invalid ProductFinder dependency")
+	finder, err := pkgapplication.Dependency[domain.ProductFinder](dependencies, "ProductFinder")
+	if err != nil {
+		return nil, err
 	}
 	return NewGetProductUseCase(finder), nil
 }
 
 func CreateUpdateProductUseCase(dependencies map[string]interface{}) (interface{}, error) {
-	service, ok := dependencies["ProductUpdater"].(domain.ProductUpdater)
-	if !ok || service == nil {
-		return nil, fmt.Errorf("missing or invalid ProductUpdater dependency")
+	service, err := pkgapplication.Dependency[domain.ProductUpdater](dependencies, "ProductUpdater")
+	if err != nil {
+		return nil, err
 	}
 	return NewUpdateProductUseCase(service), nil
 }
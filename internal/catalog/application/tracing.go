@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// endSpan labels span with the outcome of the use case, as the metrics do,
+// and ends it.
+func endSpan(span pkgapplication.Span, err error) {
+	span.SetAttributes("catalog.outcome", Outcome(err))
+	span.RecordError(err)
+	span.End()
+}
+
+// TraceAddProductUseCase wraps every execution of useCase in a span.
+func TraceAddProductUseCase(useCase AddProductUseCase, tracer pkgapplication.Tracer) AddProductUseCase {
+	return &tracedAddProductUseCase{next: useCase, tracer: tracer}
+}
+
+type tracedAddProductUseCase struct {
+	next   AddProductUseCase
+	tracer pkgapplication.Tracer
+}
+
+func (u *tracedAddProductUseCase) Execute(ctx context.Context, input AddProductInput) (*AddProductOutput, error) {
+	ctx, span := u.tracer.Start(ctx, "AddProductUseCase.Execute", "product.id", input.ID)
+	output, err := u.next.Execute(ctx, input)
+	endSpan(span, err)
+	return output, err
+}
+
+func TraceDeleteProductUseCase(useCase DeleteProductUseCase, tracer pkgapplication.Tracer) DeleteProductUseCase {
+	return &tracedDeleteProductUseCase{next: useCase, tracer: tracer}
+}
+
+type tracedDeleteProductUseCase struct {
+	next   DeleteProductUseCase
+	tracer pkgapplication.Tracer
+}
+
+func (u *tracedDeleteProductUseCase) Execute(ctx context.Context, input DeleteProductInput) error {
+	ctx, span := u.tracer.Start(ctx, "DeleteProductUseCase.Execute", "product.id", input.ID)
+	err := u.next.Execute(ctx, input)
+	endSpan(span, err)
+	return err
+}
+
+func TraceGetAllProductsUseCase(useCase GetAllProductsUseCase, tracer pkgapplication.Tracer) GetAllProductsUseCase {
+	return &tracedGetAllProductsUseCase{next: useCase, tracer: tracer}
+}
+
+type tracedGetAllProductsUseCase struct {
+	next   GetAllProductsUseCase
+	tracer pkgapplication.Tracer
+}
+
+func (u *tracedGetAllProductsUseCase) Execute(ctx context.Context) ([]*GetAllProductsOutput, error) {
+	ctx, span := u.tracer.Start(ctx, "GetAllProductsUseCase.Execute")
+	output, err := u.next.Execute(ctx)
+	span.SetAttributes("catalog.products", len(output))
+	endSpan(span, err)
+	return output, err
+}
+
+func TraceGetProductUseCase(useCase GetProductUseCase, tracer pkgapplication.Tracer) GetProductUseCase {
+	return &tracedGetProductUseCase{next: useCase, tracer: tracer}
+}
+
+type tracedGetProductUseCase struct {
+	next   GetProductUseCase
+	tracer pkgapplication.Tracer
+}
+
+func (u *tracedGetProductUseCase) Execute(ctx context.Context, input GetProductInput) (*GetProductOutput, error) {
+	ctx, span := u.tracer.Start(ctx, "GetProductUseCase.Execute", "product.id", input.ID)
+	output, err := u.next.Execute(ctx, input)
+	endSpan(span, err)
+	return output, err
+}
+
+func TraceUpdateProductUseCase(useCase UpdateProductUseCase, tracer pkgapplication.Tracer) UpdateProductUseCase {
+	return &tracedUpdateProductUseCase{next: useCase, tracer: tracer}
+}
+
+type tracedUpdateProductUseCase struct {
+	next   UpdateProductUseCase
+	tracer pkgapplication.Tracer
+}
+
+func (u *tracedUpdateProductUseCase) Execute(ctx context.Context, input UpdateProductInput) (*UpdateProductOutput, error) {
+	ctx, span := u.tracer.Start(ctx, "UpdateProductUseCase.Execute", "product.id", input.ID)
+	output, err := u.next.Execute(ctx, input)
+	endSpan(span, err)
+	return output, err
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+func TestTracedUseCases(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, tracing.WithSyncExport())
+	ctx, root := tracer.Start(context.Background(), "GET /products/{id}")
+
+	finder := mocks.NewMockProductFinder(ctrl)
+	finder.EXPECT().GetProduct(gomock.Any(), domain.ProductID("1")).DoAndReturn(func(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+		assert.NotEqual(t, tracing.Traceparent(ctx), tracing.Traceparent(context.Background()), "the use case span reaches the domain service")
+		return nil, domain.ErrNotFoundProduct
+	})
+	_, err := TraceGetProductUseCase(NewGetProductUseCase(finder), tracer).Execute(ctx, GetProductInput{ID: "1"})
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+
+	allFinder := mocks.NewMockAllProductFinder(ctrl)
+	allFinder.EXPECT().GetAllProducts(gomock.Any()).Return([]*domain.Product{{ID: "1"}, {ID: "2"}}, nil)
+	_, err = TraceGetAllProductsUseCase(NewGetAllProductsUseCase(allFinder), tracer).Execute(ctx)
+	assert.NoError(t, err)
+
+	adder := mocks.NewMockProductAdder(ctrl)
+	adder.EXPECT().AddProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.Product{ID: "1"}, nil)
+	_, err = TraceAddProductUseCase(NewAddProductUseCase(adder), tracer).Execute(ctx, AddProductInput{ID: "1"})
+	assert.NoError(t, err)
+
+	deleter := mocks.NewMockProductDeleter(ctrl)
+	deleter.EXPECT().DeleteProduct(gomock.Any(), gomock.Any()).Return(domain.ErrRepositoryProduct)
+	err = TraceDeleteProductUseCase(NewDeleteProductUseCase(deleter), tracer).Execute(ctx, DeleteProductInput{ID: "1"})
+	assert.ErrorIs(t, err, domain.ErrRepositoryProduct)
+
+	updater := mocks.NewMockProductUpdater(ctrl)
+	updater.EXPECT().UpdateProduct(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(&domain.Product{ID: "1"}, nil)
+	_, err = TraceUpdateProductUseCase(NewUpdateProductUseCase(updater), tracer).Execute(ctx, UpdateProductInput{ID: "1"})
+	assert.NoError(t, err)
+
+	root.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 6)
+	tests := []struct {
+		name    string
+		outcome string
+		status  tracing.StatusCode
+	}{
+		{name: "GetProductUseCase.Execute", outcome: "not_found", status: tracing.StatusError},
+		{name: "GetAllProductsUseCase.Execute", outcome: OutcomeSuccess},
+		{name: "AddProductUseCase.Execute", outcome: OutcomeSuccess},
+		{name: "DeleteProductUseCase.Execute", outcome: "repository_error", status: tracing.StatusError},
+		{name: "UpdateProductUseCase.Execute", outcome: OutcomeSuccess},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, spans[i].Name)
+			assert.Equal(t, tt.outcome, spans[i].Attribute("catalog.outcome"))
+			assert.Equal(t, tt.status, spans[i].Status)
+			assert.Equal(t, spans[5].SpanID, spans[i].ParentSpanID)
+		})
+	}
+	assert.Equal(t, "1", spans[0].Attribute("product.id"))
+	assert.Equal(t, int64(2), spans[1].Attribute("catalog.products"))
+}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"time"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
@@ -23,7 +24,7 @@ type UpdateProductOutput struct {
 }
 
 type UpdateProductUseCase interface {
-	Execute(input UpdateProductInput) (*UpdateProductOutput, error)
+	Execute(ctx context.Context, input UpdateProductInput) (*UpdateProductOutput, error)
 }
 
 type updateProductUseCase struct {
@@ -36,10 +37,10 @@ func NewUpdateProductUseCase(productUpdater domain.ProductUpdater) UpdateProduct
 	}
 }
 
-func (u *updateProductUseCase) Execute(input UpdateProductInput) (*UpdateProductOutput, error) {
+func (u *updateProductUseCase) Execute(ctx context.Context, input UpdateProductInput) (*UpdateProductOutput, error) {
 	id := domain.ProductID(input.ID)
 
-	product, err := u.productUpdater.UpdateProduct(id, input.Name, input.Description, input.Price)
+	product, err := u.productUpdater.UpdateProduct(ctx, id, input.Name, input.Description, input.Price)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package application
 
 import (
+	"context"
 	"errors"
 	"testing"
 	"time"
@@ -36,7 +37,7 @@ func TestUpdateProductUseCase_Execute(t *testing.T) {
 			mockBehavior: func(m *mocks.MockProductUpdater, id domain.ProductID, name, description string, price float64) {
 				createdAt := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
 				updatedAt := time.Now()
-				m.EXPECT().UpdateProduct(id, name, description, price).Return(&domain.Product{
+				m.EXPECT().UpdateProduct(gomock.Any(), id, name, description, price).Return(&domain.Product{
 					ID:          id,
 					Name:        name,
 					Description: description,
@@ -64,7 +65,7 @@ func TestUpdateProductUseCase_Execute(t *testing.T) {
 				Price:       9.99,
 			},
 			mockBehavior: func(m *mocks.MockProductUpdater, id domain.ProductID, name, description string, price float64) {
-				m.EXPECT().UpdateProduct(id, name, description, price).Return(nil, errors.New("product not found"))
+				m.EXPECT().UpdateProduct(gomock.Any(), id, name, description, price).Return(nil, errors.New("product not found"))
 			},
 			expectedOutput: nil,
 			expectedError:  errors.New("product not found"),
@@ -76,7 +77,7 @@ func TestUpdateProductUseCase_Execute(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(mockUpdater, domain.ProductID(tc.input.ID), tc.input.Name, tc.input.Description, tc.input.Price)
 
-			output, err := useCase.Execute(tc.input)
+			output, err := useCase.Execute(context.Background(), tc.input)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -0,0 +1,129 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"gorm.io/gorm"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	dbadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/adapter"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/migrations"
+	memoryadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/memory/adapter"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/server"
+)
+
+// NewFactory registers cfg, metrics and tracer and composes the persistence
+// selected by cfg.Repository with the catalog services, the way every
+// entrypoint wires them.
+func NewFactory(cfg *config.Config, logger pkgapplication.Logger, metrics pkgapplication.Metrics, tracer pkgapplication.Tracer) (pkgapplication.Factory, error) {
+	serviceLocator := pkgapplication.NewSimpleServiceLocator(pkgapplication.WithLocatorLogger(logger))
+	serviceLocator.Register("config", cfg)
+	serviceLocator.Register("metrics", metrics)
+	serviceLocator.Register("tracer", tracer)
+
+	persistence, err := PersistenceModules(serviceLocator, cfg.Repository)
+	if err != nil {
+		return nil, err
+	}
+	return pkgapplication.Compose(serviceLocator, append(persistence, ServiceModules(cfg.Cache)...)...)
+}
+
+// PersistenceModules returns the modules providing the product repositories
+// of the configured backend. The memory backend's store is registered on
+// serviceLocator as "productStore".
+func PersistenceModules(serviceLocator pkgapplication.ServiceLocator, repository config.RepositoryConfig) ([]pkgapplication.Module, error) {
+	switch repository.Backend {
+	case config.BackendSQLite, config.BackendPostgres:
+		return []pkgapplication.Module{DatabaseModule(), GormPersistenceModule()}, nil
+	case config.BackendDynamoDB:
+		return []pkgapplication.Module{DynamoClientModule(), DynamoPersistenceModule()}, nil
+	case config.BackendMemory:
+		store, err := newProductStore(repository.SnapshotFile)
+		if err != nil {
+			return nil, err
+		}
+		serviceLocator.Register("productStore", store)
+
+		return []pkgapplication.Module{MemoryPersistenceModule()}, nil
+	default:
+		return nil, fmt.Errorf("unknown repository backend %q", repository.Backend)
+	}
+}
+
+// ServiceModules returns the use cases and the decorators of their
+// repositories, the same for every backend.
+func ServiceModules(cache config.CacheConfig) []pkgapplication.Module {
+	modules := []pkgapplication.Module{ChaosModule(), CatalogServicesModule(), MetricsModule(), TracingModule(), ResilienceModule(), HealthModule(), pkgapplication.RequestScopeModule()}
+	if cache.Enabled {
+		modules = append(modules, CacheModule())
+	}
+	return modules
+}
+
+func newProductStore(snapshotFile string) (*memoryadapter.ProductStore, error) {
+	if snapshotFile == "" {
+		return memoryadapter.NewProductStore()
+	}
+	return memoryadapter.NewProductStore(memoryadapter.WithSnapshotFile(snapshotFile))
+}
+
+// DatabaseModule provides the *gorm.DB of the configured SQLite or
+// PostgreSQL database as "dbConn", closed with the factory.
+func DatabaseModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "database",
+		Recipes: map[string]pkgapplication.Recipe{
+			"dbConn": {
+				Dependencies: []string{"config"},
+				Factory: func(dependencies map[string]interface{}) (interface{}, error) {
+					cfg, err := pkgapplication.Dependency[*config.Config](dependencies, "config")
+					if err != nil {
+						return nil, err
+					}
+					return openDatabase(cfg.Repository)
+				},
+				Close: dbadapter.CloseDatabase,
+			},
+		},
+	}
+}
+
+// openDatabase connects to the configured database and refuses to return it
+// while migrations are pending, unless the configuration asks to apply them.
+func openDatabase(repository config.RepositoryConfig) (*gorm.DB, error) {
+	dbConn, err := dbadapter.OpenDatabase(repository.Backend, repository.DSN)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := migrations.Ensure(context.Background(), dbConn, repository.Backend, repository.Migrate); err != nil {
+		return nil, errors.Join(err, dbadapter.CloseDatabase(dbConn))
+	}
+	return dbConn, nil
+}
+
+// NewServer applies the configured timeouts and size limits to handler and
+// closes the factory, then the tracer, once in-flight requests have drained.
+func NewServer(cfg config.ServerConfig, handler http.Handler, logger pkgapplication.Logger, factory pkgapplication.Factory, tracer pkgapplication.Tracer) *server.Server {
+	opts := []server.ServerOption{
+		server.WithReadTimeout(cfg.ReadTimeout),
+		server.WithReadHeaderTimeout(cfg.ReadHeaderTimeout),
+		server.WithWriteTimeout(cfg.WriteTimeout),
+		server.WithIdleTimeout(cfg.IdleTimeout),
+		server.WithMaxHeaderBytes(int(cfg.MaxHeaderBytes)),
+		server.WithMaxBodyBytes(cfg.MaxBodyBytes),
+		server.WithShutdownTimeout(cfg.ShutdownTimeout),
+		server.WithLogger(logger),
+	}
+	// Closers run in reverse order, so the tracer exports the spans still
+	// queued after the factory has closed.
+	if closer, ok := tracer.(io.Closer); ok {
+		opts = append(opts, server.WithCloser(closer))
+	}
+	return server.NewServer(cfg.Addr, handler, append(opts, server.WithCloser(factory))...)
+}
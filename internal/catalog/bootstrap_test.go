@@ -0,0 +1,79 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/db/migrate"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+)
+
+func TestNewFactory(t *testing.T) {
+	tests := []struct {
+		name        string
+		repository  config.RepositoryConfig
+		healthCheck string
+		wantErr     string
+	}{
+		{
+			name:        "SQLite",
+			repository:  config.RepositoryConfig{Backend: config.BackendSQLite, DSN: "file::memory:", Migrate: true},
+			healthCheck: "sqlite",
+		},
+		{
+			name:        "DynamoDB",
+			repository:  config.RepositoryConfig{Backend: config.BackendDynamoDB},
+			healthCheck: "dynamodb",
+		},
+		{
+			name:        "Memory",
+			repository:  config.RepositoryConfig{Backend: config.BackendMemory},
+			healthCheck: "memory",
+		},
+		{
+			name:       "Unknown backend",
+			repository: config.RepositoryConfig{Backend: "mongodb"},
+			wantErr:    `unknown repository backend "mongodb"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Defaults()
+			cfg.Repository = tt.repository
+			cfg.AWS = config.AWSConfig{Region: "us-east-1", ProductsTable: "ProductsTable"}
+
+			factory, err := NewFactory(&cfg, pkgapplication.NopLogger(), prometheus.NewRegistry(), pkgapplication.NopTracer())
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			defer factory.Close()
+
+			_, err = pkgapplication.Create[application.AddProductUseCase](factory, "AddProductUseCase")
+			assert.NoError(t, err)
+			check, err := pkgapplication.Create[pkgapplication.HealthCheck](factory, "RepositoryHealthCheck")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.healthCheck, check.Name())
+		})
+	}
+}
+
+func TestDatabaseModule_PendingMigrations(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Repository = config.RepositoryConfig{Backend: config.BackendSQLite, DSN: "file::memory:"}
+	locator := pkgapplication.NewSimpleServiceLocator()
+	locator.Register("config", &cfg)
+
+	factory, err := pkgapplication.Compose(locator, DatabaseModule())
+	assert.NoError(t, err)
+
+	_, err = pkgapplication.Create[*gorm.DB](factory, "dbConn")
+	assert.ErrorIs(t, err, migrate.ErrSchemaOutdated)
+}
@@ -9,3 +9,4 @@ var ErrInvalidProductPrice = errors.New("invalid product price")
 var ErrAlreadyExistsProduct = errors.New("product already exists")
 var ErrNotFoundProduct = errors.New("product not found")
 var ErrRepositoryProduct = errors.New("error in repository")
+var ErrRepositoryUnavailable = errors.New("repository unavailable")
@@ -1,17 +1,19 @@
 package domain
 
+import "context"
+
 type ProductSaveRepository interface {
-	Save(product *Product) error
+	Save(ctx context.Context, product *Product) error
 }
 
 type ProductFindRepository interface {
-	Find(id ProductID) (*Product, error)
+	Find(ctx context.Context, id ProductID) (*Product, error)
 }
 
 type ProductFindAllRepository interface {
-	FindAll() ([]*Product, error)
+	FindAll(ctx context.Context) ([]*Product, error)
 }
 
 type ProductDeleteRepository interface {
-	Delete(id ProductID) error
+	Delete(ctx context.Context, id ProductID) error
 }
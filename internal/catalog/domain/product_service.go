@@ -1,7 +1,9 @@
 package domain
 
+import "context"
+
 type ProductAdder interface {
-	AddProduct(id ProductID, name, description string, price float64) (*Product, error)
+	AddProduct(ctx context.Context, id ProductID, name, description string, price float64) (*Product, error)
 }
 
 type productAdder struct {
@@ -16,8 +18,8 @@ func NewProductAdder(findRepository ProductFindRepository, repository ProductSav
 	}
 }
 
-func (s *productAdder) AddProduct(id ProductID, name, description string, price float64) (*Product, error) {
-	productExists, err := s.findRepository.Find(id)
+func (s *productAdder) AddProduct(ctx context.Context, id ProductID, name, description string, price float64) (*Product, error) {
+	productExists, err := s.findRepository.Find(ctx, id)
 	if err != nil {
 		if err != ErrNotFoundProduct {
 			return nil, err
@@ -33,7 +35,7 @@ func (s *productAdder) AddProduct(id ProductID, name, description string, price
 		return nil, err
 	}
 
-	err = s.saveRepository.Save(product)
+	err = s.saveRepository.Save(ctx, product)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +44,7 @@ func (s *productAdder) AddProduct(id ProductID, name, description string, price
 }
 
 type AllProductFinder interface {
-	GetAllProducts() ([]*Product, error)
+	GetAllProducts(ctx context.Context) ([]*Product, error)
 }
 
 type allProductFinder struct {
@@ -55,8 +57,8 @@ func NewAllProductFinder(findAllRepository ProductFindAllRepository) AllProductF
 	}
 }
 
-func (s *allProductFinder) GetAllProducts() ([]*Product, error) {
-	records, err := s.findAllRepository.FindAll()
+func (s *allProductFinder) GetAllProducts(ctx context.Context) ([]*Product, error) {
+	records, err := s.findAllRepository.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -64,7 +66,7 @@ func (s *allProductFinder) GetAllProducts() ([]*Product, error) {
 }
 
 type ProductFinder interface {
-	GetProduct(id ProductID) (*Product, error)
+	GetProduct(ctx context.Context, id ProductID) (*Product, error)
 }
 
 type productFinder struct {
@@ -77,15 +79,15 @@ func NewProductFinder(findRepository ProductFindRepository) ProductFinder {
 	}
 }
 
-func (s *productFinder) GetProduct(id ProductID) (*Product, error) {
+func (s *productFinder) GetProduct(ctx context.Context, id ProductID) (*Product, error) {
 	if id == "" {
 		return nil, ErrInvalidProductID
 	}
-	return s.findRepository.Find(id)
+	return s.findRepository.Find(ctx, id)
 }
 
 type ProductUpdater interface {
-	UpdateProduct(id ProductID, name, description string, price float64) (*Product, error)
+	UpdateProduct(ctx context.Context, id ProductID, name, description string, price float64) (*Product, error)
 }
 
 type productUpdater struct {
@@ -100,12 +102,12 @@ func NewProductUpdater(findRepository ProductFindRepository, saveRepository Prod
 	}
 }
 
-func (s *productUpdater) UpdateProduct(id ProductID, name, description string, price float64) (*Product, error) {
+func (s *productUpdater) UpdateProduct(ctx context.Context, id ProductID, name, description string, price float64) (*Product, error) {
 	if id == "" {
 		return nil, ErrInvalidProductID
 	}
 
-	product, err := s.findRepository.Find(id)
+	product, err := s.findRepository.Find(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -125,7 +127,7 @@ func (s *productUpdater) UpdateProduct(id ProductID, name, description string, p
 		return nil, err
 	}
 
-	err = s.saveRepository.Save(product)
+	err = s.saveRepository.Save(ctx, product)
 	if err != nil {
 		return nil, err
 	}
@@ -134,7 +136,7 @@ func (s *productUpdater) UpdateProduct(id ProductID, name, description string, p
 }
 
 type ProductDeleter interface {
-	DeleteProduct(id ProductID) error
+	DeleteProduct(ctx context.Context, id ProductID) error
 }
 
 type productDeleter struct {
@@ -149,12 +151,12 @@ func NewProductDeleter(findRepository ProductFindRepository, deleteRepository Pr
 	}
 }
 
-func (s *productDeleter) DeleteProduct(id ProductID) error {
+func (s *productDeleter) DeleteProduct(ctx context.Context, id ProductID) error {
 	if id == "" {
 		return ErrInvalidProductID
 	}
 
-	product, err := s.findRepository.Find(id)
+	product, err := s.findRepository.Find(ctx, id)
 	if err != nil {
 		return err
 	}
@@ -162,7 +164,7 @@ func (s *productDeleter) DeleteProduct(id ProductID) error {
 		return ErrNotFoundProduct
 	}
 
-	err = s.deleteRepository.Delete(product.ID)
+	err = s.deleteRepository.Delete(ctx, product.ID)
 	if err != nil {
 		return err
 	}
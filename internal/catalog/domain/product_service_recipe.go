@@ -1,62 +1,64 @@
 package domain
 
-import "fmt"
+import (
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
 
 func CreateProductAdder(dependencies map[string]interface{}) (interface{}, error) {
-	findRepository, ok := dependencies["ProductFindRepository"].(ProductFindRepository)
-	if !ok || findRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductFindRepository dependency")
+	findRepository, err := pkgapplication.Dependency[ProductFindRepository](dependencies, "ProductFindRepository")
+	if err != nil {
+		return nil, err
 	}
 
-	saveRepository, ok := dependencies["ProductSaveRepository"].(ProductSaveRepository)
-	if !ok || saveRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductSaveRepository dependency")
+	saveRepository, err := pkgapplication.Dependency[ProductSaveRepository](dependencies, "ProductSaveRepository")
+	if err != nil {
+		return nil, err
 	}
 
 	return NewProductAdder(findRepository, saveRepository), nil
 }
 
 func CreateProductDeleter(dependencies map[string]interface{}) (interface{}, error) {
-	findRepository, ok := dependencies["ProductFindRepository"].(ProductFindRepository)
-	if !ok || findRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductFindRepository dependency")
+	findRepository, err := pkgapplication.Dependency[ProductFindRepository](dependencies, "ProductFindRepository")
+	if err != nil {
+		return nil, err
 	}
 
-	deleteRepository, ok := dependencies["ProductDeleteRepository"].(ProductDeleteRepository)
-	if !ok || deleteRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductDeleteRepository dependency")
+	deleteRepository, err := pkgapplication.Dependency[ProductDeleteRepository](dependencies, "ProductDeleteRepository")
+	if err != nil {
+		return nil, err
 	}
 
 	return NewProductDeleter(findRepository, deleteRepository), nil
 }
 
 func CreateProductFinder(dependencies map[string]interface{}) (interface{}, error) {
-	findRepository, ok := dependencies["ProductFindRepository"].(ProductFindRepository)
-	if !ok || findRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductFindRepository dependency")
+	findRepository, err := pkgapplication.Dependency[ProductFindRepository](dependencies, "ProductFindRepository")
+	if err != nil {
+		return nil, err
 	}
 
 	return NewProductFinder(findRepository), nil
 }
 
 func CreateAllProductFinder(dependencies map[string]interface{}) (interface{}, error) {
-	findAllRepository, ok := dependencies["ProductFindAllRepository"].(ProductFindAllRepository)
-	if !ok || findAllRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductFindAllRepository dependency")
+	findAllRepository, err := pkgapplication.Dependency[ProductFindAllRepository](dependencies, "ProductFindAllRepository")
+	if err != nil {
+		return nil, err
 	}
 
 	return NewAllProductFinder(findAllRepository), nil
 }
 
 func CreateProductUpdater(dependencies map[string]interface{}) (interface{}, error) {
-	findRepository, ok := dependencies["ProductFindRepository"].(ProductFindRepository)
-	if !ok || findRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductFindRepository dependency")
+	findRepository, err := pkgapplication.Dependency[ProductFindRepository](dependencies, "ProductFindRepository")
+	if err != nil {
+		return nil, err
 	}
 
-	saveRepository, ok := dependencies["ProductSaveRepository"].(ProductSaveRepository)
-	if !ok || saveRepository == nil {
-		return nil, fmt.Errorf("missing or nil ProductSaveRepository dependency")
+	saveRepository, err := pkgapplication.Dependency[ProductSaveRepository](dependencies, "ProductSaveRepository")
+	if err != nil {
+		return nil, err
 	}
 
 	return NewProductUpdater(findRepository, saveRepository), nil
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -12,7 +13,7 @@ type MockProductSaveRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductSaveRepository) Save(product *Product) error {
+func (m *MockProductSaveRepository) Save(ctx context.Context, product *Product) error {
 	args := m.Called(product)
 	return args.Error(0)
 }
@@ -21,7 +22,7 @@ type MockProductFindRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductFindRepository) Find(id ProductID) (*Product, error) {
+func (m *MockProductFindRepository) Find(ctx context.Context, id ProductID) (*Product, error) {
 	args := m.Called(id)
 	if args.Get(0) != nil {
 		return args.Get(0).(*Product), args.Error(1)
@@ -33,7 +34,7 @@ type MockProductFindAllRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductFindAllRepository) FindAll() ([]*Product, error) {
+func (m *MockProductFindAllRepository) FindAll(ctx context.Context) ([]*Product, error) {
 	args := m.Called()
 	return args.Get(0).([]*Product), args.Error(1)
 }
@@ -42,7 +43,7 @@ type MockProductDeleteRepository struct {
 	mock.Mock
 }
 
-func (m *MockProductDeleteRepository) Delete(id ProductID) error {
+func (m *MockProductDeleteRepository) Delete(ctx context.Context, id ProductID) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
@@ -57,7 +58,7 @@ func TestAddProduct(t *testing.T) {
 
 		service := NewProductAdder(mockFindRepo, mockSaveRepo)
 
-		product, err := service.AddProduct("1", "Produto Teste", "Descrição Teste", 10.0)
+		product, err := service.AddProduct(context.Background(), "1", "Produto Teste", "Descrição Teste", 10.0)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, product)
@@ -73,7 +74,7 @@ func TestAddProduct(t *testing.T) {
 
 		service := NewProductAdder(mockFindRepo, mockSaveRepo)
 
-		_, err := service.AddProduct("1", "Produto Teste", "Descrição Teste", 10.0)
+		_, err := service.AddProduct(context.Background(), "1", "Produto Teste", "Descrição Teste", 10.0)
 
 		assert.NotNil(t, err)
 		mockFindRepo.AssertCalled(t, "Find", mock.Anything)
@@ -88,7 +89,7 @@ func TestAddProduct(t *testing.T) {
 
 		service := NewProductAdder(mockFindRepo, mockSaveRepo)
 
-		_, err := service.AddProduct("1", "Produto Teste", "Descrição Teste", 10.0)
+		_, err := service.AddProduct(context.Background(), "1", "Produto Teste", "Descrição Teste", 10.0)
 
 		assert.NotNil(t, err)
 		mockFindRepo.AssertCalled(t, "Find", mock.Anything)
@@ -103,7 +104,7 @@ func TestAddProduct(t *testing.T) {
 
 		service := NewProductAdder(mockFindRepo, mockSaveRepo)
 
-		_, err := service.AddProduct("", "", "Descrição Teste", -10.0)
+		_, err := service.AddProduct(context.Background(), "", "", "Descrição Teste", -10.0)
 
 		assert.NotNil(t, err)
 		mockFindRepo.AssertCalled(t, "Find", mock.Anything)
@@ -119,7 +120,7 @@ func TestAddProduct(t *testing.T) {
 
 		service := NewProductAdder(mockFindRepo, mockSaveRepo)
 
-		_, err := service.AddProduct("1", "Produto Teste", "Descrição Teste", 10.0)
+		_, err := service.AddProduct(context.Background(), "1", "Produto Teste", "Descrição Teste", 10.0)
 
 		assert.NotNil(t, err)
 		mockFindRepo.AssertCalled(t, "Find", mock.Anything)
@@ -141,7 +142,7 @@ func TestGetProduct(t *testing.T) {
 
 		mockFindRepo.On("Find", ProductID("1")).Return(expectedProduct, nil)
 
-		product, err := service.GetProduct(ProductID("1"))
+		product, err := service.GetProduct(context.Background(), ProductID("1"))
 
 		assert.Nil(t, err)
 		assert.Equal(t, expectedProduct, product)
@@ -154,7 +155,7 @@ func TestGetProduct(t *testing.T) {
 
 		mockFindRepo.On("Find", ProductID("1")).Return(nil, ErrNotFoundProduct)
 
-		product, err := service.GetProduct(ProductID("1"))
+		product, err := service.GetProduct(context.Background(), ProductID("1"))
 
 		assert.Nil(t, product)
 		assert.Equal(t, ErrNotFoundProduct, err)
@@ -167,7 +168,7 @@ func TestGetProduct(t *testing.T) {
 
 		mockFindRepo.On("Find", ProductID("1")).Return(nil, ErrRepositoryProduct)
 
-		product, err := service.GetProduct(ProductID("1"))
+		product, err := service.GetProduct(context.Background(), ProductID("1"))
 
 		assert.Nil(t, product)
 		assert.Equal(t, ErrRepositoryProduct, err)
@@ -178,7 +179,7 @@ func TestGetProduct(t *testing.T) {
 		mockFindRepo := new(MockProductFindRepository)
 		service := NewProductFinder(mockFindRepo)
 
-		product, err := service.GetProduct("")
+		product, err := service.GetProduct(context.Background(), "")
 
 		assert.Nil(t, product)
 		assert.Equal(t, ErrInvalidProductID, err)
@@ -232,7 +233,7 @@ func TestProductService_GetAllProducts(t *testing.T) {
 
 			service := NewAllProductFinder(mockFindRepo)
 
-			result, err := service.GetAllProducts()
+			result, err := service.GetAllProducts(context.Background())
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -266,7 +267,7 @@ func TestUpdateProduct(t *testing.T) {
 		mockFindRepo.On("Find", ProductID("1")).Return(existingProduct, nil)
 		mockSaveRepo.On("Save", mock.Anything).Return(nil)
 
-		result, err := service.UpdateProduct(ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
+		result, err := service.UpdateProduct(context.Background(), ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
 
 		assert.Nil(t, err)
 		assert.NotNil(t, result)
@@ -291,7 +292,7 @@ func TestUpdateProduct(t *testing.T) {
 
 		mockFindRepo.On("Find", ProductID("1")).Return(nil, nil)
 
-		result, err := service.UpdateProduct(ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
+		result, err := service.UpdateProduct(context.Background(), ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
 
 		assert.Nil(t, result)
 		assert.Equal(t, ErrNotFoundProduct, err)
@@ -306,7 +307,7 @@ func TestUpdateProduct(t *testing.T) {
 
 		mockFindRepo.On("Find", ProductID("1")).Return(nil, ErrRepositoryProduct)
 
-		result, err := service.UpdateProduct(ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
+		result, err := service.UpdateProduct(context.Background(), ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
 
 		assert.Nil(t, result)
 		assert.Equal(t, ErrRepositoryProduct, err)
@@ -329,7 +330,7 @@ func TestUpdateProduct(t *testing.T) {
 		mockFindRepo.On("Find", ProductID("1")).Return(existingProduct, nil)
 		mockSaveRepo.On("Save", mock.Anything).Return(ErrRepositoryProduct)
 
-		result, err := service.UpdateProduct(ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
+		result, err := service.UpdateProduct(context.Background(), ProductID("1"), "Produto Atualizado", "Descrição Atualizada", 15.0)
 
 		assert.Nil(t, result)
 		assert.Equal(t, ErrRepositoryProduct, err)
@@ -394,7 +395,7 @@ func TestUpdateProduct(t *testing.T) {
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(t *testing.T) {
-				result, err := service.UpdateProduct(tc.id, tc.newName, tc.newDesc, tc.newPrice)
+				result, err := service.UpdateProduct(context.Background(), tc.id, tc.newName, tc.newDesc, tc.newPrice)
 
 				assert.Nil(t, result)
 				assert.NotNil(t, err)
@@ -465,7 +466,7 @@ func TestProductService_DeleteProduct(t *testing.T) {
 
 			service := NewProductDeleter(mockFindRepo, mockDeleteRepo)
 
-			err := service.DeleteProduct(tc.productID)
+			err := service.DeleteProduct(context.Background(), tc.productID)
 
 			if tc.expectedError != nil {
 				assert.Error(t, err)
@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"context"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+func endSpan(span pkgapplication.Span, err error) {
+	span.RecordError(err)
+	span.End()
+}
+
+// TraceProductAdder wraps every call to adder in a span.
+func TraceProductAdder(adder ProductAdder, tracer pkgapplication.Tracer) ProductAdder {
+	return &tracedProductAdder{next: adder, tracer: tracer}
+}
+
+type tracedProductAdder struct {
+	next   ProductAdder
+	tracer pkgapplication.Tracer
+}
+
+func (s *tracedProductAdder) AddProduct(ctx context.Context, id ProductID, name, description string, price float64) (*Product, error) {
+	ctx, span := s.tracer.Start(ctx, "ProductAdder.AddProduct", "product.id", string(id))
+	product, err := s.next.AddProduct(ctx, id, name, description, price)
+	endSpan(span, err)
+	return product, err
+}
+
+func TraceAllProductFinder(finder AllProductFinder, tracer pkgapplication.Tracer) AllProductFinder {
+	return &tracedAllProductFinder{next: finder, tracer: tracer}
+}
+
+type tracedAllProductFinder struct {
+	next   AllProductFinder
+	tracer pkgapplication.Tracer
+}
+
+func (s *tracedAllProductFinder) GetAllProducts(ctx context.Context) ([]*Product, error) {
+	ctx, span := s.tracer.Start(ctx, "AllProductFinder.GetAllProducts")
+	products, err := s.next.GetAllProducts(ctx)
+	endSpan(span, err)
+	return products, err
+}
+
+func TraceProductFinder(finder ProductFinder, tracer pkgapplication.Tracer) ProductFinder {
+	return &tracedProductFinder{next: finder, tracer: tracer}
+}
+
+type tracedProductFinder struct {
+	next   ProductFinder
+	tracer pkgapplication.Tracer
+}
+
+func (s *tracedProductFinder) GetProduct(ctx context.Context, id ProductID) (*Product, error) {
+	ctx, span := s.tracer.Start(ctx, "ProductFinder.GetProduct", "product.id", string(id))
+	product, err := s.next.GetProduct(ctx, id)
+	endSpan(span, err)
+	return product, err
+}
+
+func TraceProductUpdater(updater ProductUpdater, tracer pkgapplication.Tracer) ProductUpdater {
+	return &tracedProductUpdater{next: updater, tracer: tracer}
+}
+
+type tracedProductUpdater struct {
+	next   ProductUpdater
+	tracer pkgapplication.Tracer
+}
+
+func (s *tracedProductUpdater) UpdateProduct(ctx context.Context, id ProductID, name, description string, price float64) (*Product, error) {
+	ctx, span := s.tracer.Start(ctx, "ProductUpdater.UpdateProduct", "product.id", string(id))
+	product, err := s.next.UpdateProduct(ctx, id, name, description, price)
+	endSpan(span, err)
+	return product, err
+}
+
+func TraceProductDeleter(deleter ProductDeleter, tracer pkgapplication.Tracer) ProductDeleter {
+	return &tracedProductDeleter{next: deleter, tracer: tracer}
+}
+
+type tracedProductDeleter struct {
+	next   ProductDeleter
+	tracer pkgapplication.Tracer
+}
+
+func (s *tracedProductDeleter) DeleteProduct(ctx context.Context, id ProductID) error {
+	ctx, span := s.tracer.Start(ctx, "ProductDeleter.DeleteProduct", "product.id", string(id))
+	err := s.next.DeleteProduct(ctx, id)
+	endSpan(span, err)
+	return err
+}
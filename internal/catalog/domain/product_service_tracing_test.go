@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+)
+
+func TestTracedProductServices(t *testing.T) {
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, tracing.WithSyncExport())
+	ctx, root := tracer.Start(context.Background(), "root")
+
+	mockFindRepo := new(MockProductFindRepository)
+	mockSaveRepo := new(MockProductSaveRepository)
+	mockFindAllRepo := new(MockProductFindAllRepository)
+	mockDeleteRepo := new(MockProductDeleteRepository)
+	existing := &Product{ID: "1", Name: "Name", Description: "Description", Price: 10}
+	mockFindRepo.On("Find", ProductID("1")).Return(existing, nil)
+	mockFindRepo.On("Find", ProductID("2")).Return(nil, ErrNotFoundProduct)
+	mockSaveRepo.On("Save", mock.Anything).Return(nil)
+	mockFindAllRepo.On("FindAll").Return([]*Product{existing}, nil)
+	mockDeleteRepo.On("Delete", ProductID("1")).Return(nil)
+
+	_, err := TraceProductAdder(NewProductAdder(mockFindRepo, mockSaveRepo), tracer).AddProduct(ctx, "1", "Name", "Description", 10)
+	assert.ErrorIs(t, err, ErrAlreadyExistsProduct)
+	_, err = TraceAllProductFinder(NewAllProductFinder(mockFindAllRepo), tracer).GetAllProducts(ctx)
+	assert.NoError(t, err)
+	_, err = TraceProductFinder(NewProductFinder(mockFindRepo), tracer).GetProduct(ctx, "2")
+	assert.ErrorIs(t, err, ErrNotFoundProduct)
+	_, err = TraceProductUpdater(NewProductUpdater(mockFindRepo, mockSaveRepo), tracer).UpdateProduct(ctx, "1", "New name", "New description", 20)
+	assert.NoError(t, err)
+	assert.NoError(t, TraceProductDeleter(NewProductDeleter(mockFindRepo, mockDeleteRepo), tracer).DeleteProduct(ctx, "1"))
+
+	root.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 6)
+	tests := []struct {
+		name  string
+		error string
+	}{
+		{name: "ProductAdder.AddProduct", error: ErrAlreadyExistsProduct.Error()},
+		{name: "AllProductFinder.GetAllProducts"},
+		{name: "ProductFinder.GetProduct", error: ErrNotFoundProduct.Error()},
+		{name: "ProductUpdater.UpdateProduct"},
+		{name: "ProductDeleter.DeleteProduct"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, spans[i].Name)
+			assert.Equal(t, tt.error, spans[i].StatusMessage)
+			assert.Equal(t, spans[5].SpanID, spans[i].ParentSpanID)
+		})
+	}
+	assert.Equal(t, "2", spans[2].Attribute("product.id"))
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChaosRule is one fault injected into the repository calls it matches.
+type ChaosRule struct {
+	// Operation is save, find, find_all or delete; empty matches them all.
+	Operation string
+	// ProductID restricts the rule to the calls for one product.
+	ProductID string
+	// Rate is the share of the matching calls hit by the fault, 1 unless
+	// set.
+	Rate    float64
+	Latency time.Duration
+	// Error is one of the ChaosError constants. A timeout blocks the call
+	// until its context is done.
+	Error string
+	// Limit truncates the listings to that many products.
+	Limit int
+}
+
+// ParseRules parses every rule, reporting the first invalid one.
+func (c ChaosConfig) ParseRules() ([]ChaosRule, error) {
+	rules := make([]ChaosRule, 0, len(c.Rules))
+	for _, rule := range c.Rules {
+		parsed, err := ParseChaosRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, parsed)
+	}
+	return rules, nil
+}
+
+// ParseChaosRule parses space-separated key=value settings, with the keys
+// operation, id, rate, latency, error and limit.
+func ParseChaosRule(rule string) (ChaosRule, error) {
+	parsed := ChaosRule{Rate: 1}
+	invalid := func(format string, args ...interface{}) (ChaosRule, error) {
+		return ChaosRule{}, fmt.Errorf("%w: chaos rule %q: %s", ErrInvalidConfig, rule, fmt.Sprintf(format, args...))
+	}
+
+	for _, setting := range strings.Fields(rule) {
+		key, value, ok := strings.Cut(setting, "=")
+		if !ok {
+			return invalid("%q is not a key=value setting", setting)
+		}
+
+		var err error
+		switch key {
+		case "operation":
+			switch value {
+			case "save", "find", "find_all", "delete":
+				parsed.Operation = value
+			default:
+				return invalid("unknown operation %q, expected save, find, find_all or delete", value)
+			}
+		case "id":
+			parsed.ProductID = value
+		case "rate":
+			parsed.Rate, err = strconv.ParseFloat(value, 64)
+			if err == nil && (parsed.Rate <= 0 || parsed.Rate > 1) {
+				return invalid("rate must be above 0 and at most 1")
+			}
+		case "latency":
+			parsed.Latency, err = time.ParseDuration(value)
+			if err == nil && parsed.Latency < 0 {
+				return invalid("latency must not be negative")
+			}
+		case "error":
+			switch value {
+			case ChaosErrorNotFound, ChaosErrorAlreadyExists, ChaosErrorRepository, ChaosErrorUnavailable, ChaosErrorTimeout:
+				parsed.Error = value
+			default:
+				return invalid("unknown error %q, expected not_found, already_exists, repository_error, unavailable or timeout", value)
+			}
+		case "limit":
+			parsed.Limit, err = strconv.Atoi(value)
+			if err == nil && parsed.Limit < 0 {
+				return invalid("limit must not be negative")
+			}
+		default:
+			return invalid("unknown key %q", key)
+		}
+		if err != nil {
+			return invalid("%s: %v", key, err)
+		}
+	}
+
+	if parsed.Latency == 0 && parsed.Error == "" && parsed.Limit == 0 {
+		return invalid("no fault, expected latency, error or limit")
+	}
+	if parsed.Limit > 0 && parsed.Operation != "" && parsed.Operation != "find_all" {
+		return invalid("limit only applies to find_all")
+	}
+	return parsed, nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseChaosRule(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    ChaosRule
+		wantErr string
+	}{
+		{rule: "error=repository_error", want: ChaosRule{Rate: 1, Error: ChaosErrorRepository}},
+		{rule: "operation=find id=42  error=not_found rate=0.25", want: ChaosRule{Operation: "find", ProductID: "42", Rate: 0.25, Error: ChaosErrorNotFound}},
+		{rule: "operation=find_all latency=150ms limit=2", want: ChaosRule{Operation: "find_all", Rate: 1, Latency: 150 * time.Millisecond, Limit: 2}},
+		{rule: "operation=find", wantErr: `invalid config: chaos rule "operation=find": no fault, expected latency, error or limit`},
+		{rule: "operation=scan error=timeout", wantErr: `invalid config: chaos rule "operation=scan error=timeout": unknown operation "scan", expected save, find, find_all or delete`},
+		{rule: "error=boom", wantErr: `invalid config: chaos rule "error=boom": unknown error "boom", expected not_found, already_exists, repository_error, unavailable or timeout`},
+		{rule: "error=timeout rate=2", wantErr: `invalid config: chaos rule "error=timeout rate=2": rate must be above 0 and at most 1`},
+		{rule: "latency=soon", wantErr: `invalid config: chaos rule "latency=soon": latency: time: invalid duration "soon"`},
+		{rule: "operation=find limit=1", wantErr: `invalid config: chaos rule "operation=find limit=1": limit only applies to find_all`},
+		{rule: "error", wantErr: `invalid config: chaos rule "error": "error" is not a key=value setting`},
+		{rule: "seed=1 error=timeout", wantErr: `invalid config: chaos rule "seed=1 error=timeout": unknown key "seed"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseChaosRule(tt.rule)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rule)
+		})
+	}
+}
+
+func TestLoadChaosRules(t *testing.T) {
+	env := map[string]string{"CATALOG_CHAOS_ENABLED": "true", "CATALOG_CHAOS_RULES": "operation=find error=not_found, operation=find_all limit=1"}
+	cfg, err := Load(newFlagSet(), nil, lookupEnv(env))
+	assert.NoError(t, err)
+
+	rules, err := cfg.Chaos.ParseRules()
+	assert.NoError(t, err)
+	assert.Equal(t, []ChaosRule{
+		{Operation: "find", Rate: 1, Error: ChaosErrorNotFound},
+		{Operation: "find_all", Rate: 1, Limit: 1},
+	}, rules)
+
+	env["CATALOG_CHAOS_RULES"] = "operation=find"
+	_, err = Load(newFlagSet(), nil, lookupEnv(env))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,120 @@
+package config
+
+import (
+	"flag"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type stringValue struct{ p *string }
+
+func stringField(value func(cfg *Config) *string) func(cfg *Config) flag.Value {
+	return func(cfg *Config) flag.Value { return stringValue{value(cfg)} }
+}
+
+func (v stringValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return *v.p
+}
+
+func (v stringValue) Set(value string) error {
+	*v.p = value
+	return nil
+}
+
+type boolValue struct{ p *bool }
+
+func boolField(value func(cfg *Config) *bool) func(cfg *Config) flag.Value {
+	return func(cfg *Config) flag.Value { return boolValue{value(cfg)} }
+}
+
+func (v boolValue) String() string {
+	if v.p == nil {
+		return "false"
+	}
+	return strconv.FormatBool(*v.p)
+}
+
+func (v boolValue) Set(value string) error {
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*v.p = parsed
+	return nil
+}
+
+// IsBoolFlag lets -migrate be passed without a value.
+func (v boolValue) IsBoolFlag() bool { return true }
+
+type durationValue struct{ p *time.Duration }
+
+func durationField(value func(cfg *Config) *time.Duration) func(cfg *Config) flag.Value {
+	return func(cfg *Config) flag.Value { return durationValue{value(cfg)} }
+}
+
+func (v durationValue) String() string {
+	if v.p == nil {
+		return "0s"
+	}
+	return v.p.String()
+}
+
+func (v durationValue) Set(value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+	*v.p = parsed
+	return nil
+}
+
+type int64Value struct{ p *int64 }
+
+func int64Field(value func(cfg *Config) *int64) func(cfg *Config) flag.Value {
+	return func(cfg *Config) flag.Value { return int64Value{value(cfg)} }
+}
+
+func (v int64Value) String() string {
+	if v.p == nil {
+		return "0"
+	}
+	return strconv.FormatInt(*v.p, 10)
+}
+
+func (v int64Value) Set(value string) error {
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*v.p = parsed
+	return nil
+}
+
+// stringsValue holds a comma-separated list.
+type stringsValue struct{ p *[]string }
+
+func stringsField(value func(cfg *Config) *[]string) func(cfg *Config) flag.Value {
+	return func(cfg *Config) flag.Value { return stringsValue{value(cfg)} }
+}
+
+func (v stringsValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return strings.Join(*v.p, ",")
+}
+
+func (v stringsValue) Set(value string) error {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	*v.p = values
+	return nil
+}
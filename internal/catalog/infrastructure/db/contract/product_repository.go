@@ -0,0 +1,254 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+type ProductRepositories struct {
+	Save    domain.ProductSaveRepository
+	Find    domain.ProductFindRepository
+	FindAll domain.ProductFindAllRepository
+	Delete  domain.ProductDeleteRepository
+}
+
+// ProductRepositoriesFactory must return repositories backed by a fresh, empty store on every call.
+type ProductRepositoriesFactory func(t *testing.T) ProductRepositories
+
+// Timestamps use microsecond precision, the finest every supported backend stores.
+var baseTime = time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+
+var ctx = context.Background()
+
+func RunProductRepositoryTests(t *testing.T, newRepositories ProductRepositoriesFactory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repos ProductRepositories)
+	}{
+		{name: "Save and find", run: testSaveAndFind},
+		{name: "Find unknown product", run: testFindUnknown},
+		{name: "Find empty ID", run: testFindEmptyID},
+		{name: "Save invalid product", run: testSaveInvalid},
+		{name: "Save existing product upserts", run: testSaveUpserts},
+		{name: "Find all on empty store", run: testFindAllEmpty},
+		{name: "Find all products", run: testFindAll},
+		{name: "Delete product", run: testDelete},
+		{name: "Delete unknown product", run: testDeleteUnknown},
+		{name: "Returned products are copies", run: testReturnedCopies},
+		{name: "Concurrent access", run: testConcurrentAccess},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepositories(t))
+		})
+	}
+}
+
+func newProduct(id string, offset time.Duration) *domain.Product {
+	return &domain.Product{
+		ID:          domain.ProductID(id),
+		Name:        "Product " + id,
+		Description: "Description of " + id,
+		Price:       19.99,
+		CreatedAt:   baseTime.Add(offset),
+		UpdatedAt:   baseTime.Add(offset),
+	}
+}
+
+func assertProduct(t *testing.T, expected, actual *domain.Product) {
+	t.Helper()
+	require.NotNil(t, actual)
+	assert.Equal(t, expected.ID, actual.ID)
+	assert.Equal(t, expected.Name, actual.Name)
+	assert.Equal(t, expected.Description, actual.Description)
+	assert.Equal(t, expected.Price, actual.Price)
+	assert.True(t, expected.CreatedAt.Equal(actual.CreatedAt), "created_at: expected %s, got %s", expected.CreatedAt, actual.CreatedAt)
+	assert.True(t, expected.UpdatedAt.Equal(actual.UpdatedAt), "updated_at: expected %s, got %s", expected.UpdatedAt, actual.UpdatedAt)
+}
+
+func productIDs(products []*domain.Product) []domain.ProductID {
+	ids := make([]domain.ProductID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	return ids
+}
+
+func testSaveAndFind(t *testing.T, repos ProductRepositories) {
+	product := newProduct("product-1", 0)
+	require.NoError(t, repos.Save.Save(ctx, product))
+
+	found, err := repos.Find.Find(ctx, product.ID)
+	require.NoError(t, err)
+	assertProduct(t, product, found)
+}
+
+func testFindUnknown(t *testing.T, repos ProductRepositories) {
+	require.NoError(t, repos.Save.Save(ctx, newProduct("product-1", 0)))
+
+	found, err := repos.Find.Find(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+	assert.Nil(t, found)
+}
+
+func testFindEmptyID(t *testing.T, repos ProductRepositories) {
+	found, err := repos.Find.Find(ctx, "")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+	assert.Nil(t, found)
+}
+
+func testSaveInvalid(t *testing.T, repos ProductRepositories) {
+	assert.ErrorIs(t, repos.Save.Save(ctx, nil), domain.ErrInvalidProductID)
+	assert.ErrorIs(t, repos.Save.Save(ctx, newProduct("", 0)), domain.ErrInvalidProductID)
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+}
+
+func testSaveUpserts(t *testing.T, repos ProductRepositories) {
+	original := newProduct("product-1", 0)
+	require.NoError(t, repos.Save.Save(ctx, original))
+
+	updated := newProduct("product-1", 0)
+	updated.Name = "Updated name"
+	updated.Description = "Updated description"
+	updated.Price = 29.99
+	updated.CreatedAt = baseTime.Add(time.Hour)
+	updated.UpdatedAt = baseTime.Add(time.Hour)
+	require.NoError(t, repos.Save.Save(ctx, updated))
+
+	found, err := repos.Find.Find(ctx, "product-1")
+	require.NoError(t, err)
+	want := *updated
+	want.CreatedAt = original.CreatedAt
+	assertProduct(t, &want, found)
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+}
+
+func testFindAllEmpty(t *testing.T, repos ProductRepositories) {
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.NotNil(t, products)
+	assert.Empty(t, products)
+}
+
+func testFindAll(t *testing.T, repos ProductRepositories) {
+	expected := map[domain.ProductID]*domain.Product{}
+	for i := 0; i < 5; i++ {
+		product := newProduct(fmt.Sprintf("product-%d", i), time.Duration(i)*time.Minute)
+		require.NoError(t, repos.Save.Save(ctx, product))
+		expected[product.ID] = product
+	}
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, products, len(expected))
+	for _, product := range products {
+		require.Contains(t, expected, product.ID)
+		assertProduct(t, expected[product.ID], product)
+	}
+}
+
+func testDelete(t *testing.T, repos ProductRepositories) {
+	require.NoError(t, repos.Save.Save(ctx, newProduct("product-1", 0)))
+	require.NoError(t, repos.Save.Save(ctx, newProduct("product-2", 0)))
+
+	require.NoError(t, repos.Delete.Delete(ctx, "product-1"))
+
+	_, err := repos.Find.Find(ctx, "product-1")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []domain.ProductID{"product-2"}, productIDs(products))
+}
+
+func testDeleteUnknown(t *testing.T, repos ProductRepositories) {
+	require.NoError(t, repos.Save.Save(ctx, newProduct("product-1", 0)))
+
+	assert.NoError(t, repos.Delete.Delete(ctx, "unknown"))
+	assert.NoError(t, repos.Delete.Delete(ctx, ""))
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, products, 1)
+}
+
+func testReturnedCopies(t *testing.T, repos ProductRepositories) {
+	product := newProduct("product-1", 0)
+	require.NoError(t, repos.Save.Save(ctx, product))
+	product.Name = "Changed after save"
+
+	found, err := repos.Find.Find(ctx, "product-1")
+	require.NoError(t, err)
+	found.Name = "Changed after find"
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+	assert.Equal(t, "Product product-1", products[0].Name)
+	products[0].Name = "Changed after find all"
+
+	found, err = repos.Find.Find(ctx, "product-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Product product-1", found.Name)
+}
+
+func testConcurrentAccess(t *testing.T, repos ProductRepositories) {
+	const workers = 20
+
+	var wg sync.WaitGroup
+	errs := make(chan error, workers*3)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			product := newProduct(fmt.Sprintf("product-%02d", i), time.Duration(i)*time.Second)
+			if err := repos.Save.Save(ctx, product); err != nil {
+				errs <- err
+				return
+			}
+			if _, err := repos.Find.Find(ctx, product.ID); err != nil {
+				errs <- err
+			}
+			if _, err := repos.FindAll.FindAll(ctx); err != nil {
+				errs <- err
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	products, err := repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, products, workers)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, repos.Delete.Delete(ctx, domain.ProductID(fmt.Sprintf("product-%02d", i))))
+		}(i)
+	}
+	wg.Wait()
+
+	products, err = repos.FindAll.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, products)
+}
@@ -0,0 +1,369 @@
+package decorator
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+const (
+	DefaultCacheCapacity    = 10000
+	DefaultCacheTTL         = 30 * time.Second
+	DefaultCacheNegativeTTL = 5 * time.Second
+
+	listingKey = "\x00all"
+)
+
+// ProductCache is an in-memory LRU of products, and of the product listing,
+// shared by the caching decorators of one set of repositories so that the
+// save and delete decorators invalidate what the find decorators cached.
+// Products that do not exist are cached too, for a shorter TTL, and
+// concurrent misses for the same key are collapsed into one repository
+// call.
+type ProductCache struct {
+	capacity    int
+	ttl         time.Duration
+	negativeTTL time.Duration
+	now         func() time.Time
+
+	mu      sync.Mutex
+	entries map[domain.ProductID]*list.Element
+	lru     *list.List
+	listing *listingEntry
+	// loads tracks the product loads in flight and listingGeneration the
+	// invalidations of the listing, so a load that started before a write
+	// cannot store what it read while loads of other products still can.
+	loads             map[domain.ProductID]*productLoad
+	listingGeneration uint64
+	group             singleflight.Group
+}
+
+// productLoad counts the invalidations of a product while it is loaded.
+type productLoad struct {
+	generation uint64
+	running    int
+}
+
+type productEntry struct {
+	id      domain.ProductID
+	product *domain.Product // nil when the product does not exist
+	expires time.Time
+}
+
+type listingEntry struct {
+	products []*domain.Product
+	expires  time.Time
+}
+
+type CacheOption func(*ProductCache)
+
+// WithCacheCapacity bounds the number of products kept, evicting the least
+// recently used past it.
+func WithCacheCapacity(capacity int) CacheOption {
+	return func(c *ProductCache) {
+		c.capacity = capacity
+	}
+}
+
+// WithCacheTTL sets how long products and the listing are served from the
+// cache.
+func WithCacheTTL(ttl time.Duration) CacheOption {
+	return func(c *ProductCache) {
+		c.ttl = ttl
+	}
+}
+
+// WithNegativeTTL sets how long a product that was not found is answered
+// as not found without asking the repository; 0 disables negative caching.
+func WithNegativeTTL(ttl time.Duration) CacheOption {
+	return func(c *ProductCache) {
+		c.negativeTTL = ttl
+	}
+}
+
+// WithCacheClock replaces time.Now, mostly for tests.
+func WithCacheClock(now func() time.Time) CacheOption {
+	return func(c *ProductCache) {
+		c.now = now
+	}
+}
+
+func NewProductCache(opts ...CacheOption) *ProductCache {
+	c := &ProductCache{
+		capacity:    DefaultCacheCapacity,
+		ttl:         DefaultCacheTTL,
+		negativeTTL: DefaultCacheNegativeTTL,
+		now:         time.Now,
+		entries:     make(map[domain.ProductID]*list.Element),
+		loads:       make(map[domain.ProductID]*productLoad),
+		lru:         list.New(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Len returns the number of products cached, found or not.
+func (c *ProductCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+// Invalidate drops id and the listing from the cache.
+func (c *ProductCache) Invalidate(id domain.ProductID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.entries[id]; exists {
+		c.lru.Remove(element)
+		delete(c.entries, id)
+	}
+	if load, loading := c.loads[id]; loading {
+		load.generation++
+	}
+	c.listing = nil
+	c.listingGeneration++
+	// Callers arriving from now on must not join a load that may have read
+	// the product before the write.
+	c.group.Forget(string(id))
+	c.group.Forget(listingKey)
+}
+
+func (c *ProductCache) find(ctx context.Context, id domain.ProductID, load func(ctx context.Context) (*domain.Product, error)) (*domain.Product, error) {
+	if product, found, hit := c.lookup(id); hit {
+		if !found {
+			return nil, domain.ErrNotFoundProduct
+		}
+		return product, nil
+	}
+
+	value, err := c.load(ctx, string(id), func(ctx context.Context) (interface{}, error) {
+		generation := c.beginLoad(id)
+		defer c.endLoad(id)
+
+		product, err := load(ctx)
+		switch {
+		case err == nil:
+			c.store(id, product, c.ttl, generation)
+		case errors.Is(err, domain.ErrNotFoundProduct) && c.negativeTTL > 0:
+			c.store(id, nil, c.negativeTTL, generation)
+		}
+		return product, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyProduct(value.(*domain.Product)), nil
+}
+
+func (c *ProductCache) findAll(ctx context.Context, load func(ctx context.Context) ([]*domain.Product, error)) ([]*domain.Product, error) {
+	c.mu.Lock()
+	if c.listing != nil && c.now().Before(c.listing.expires) {
+		products := copyProducts(c.listing.products)
+		c.mu.Unlock()
+		return products, nil
+	}
+	c.mu.Unlock()
+
+	value, err := c.load(ctx, listingKey, func(ctx context.Context) (interface{}, error) {
+		c.mu.Lock()
+		generation := c.listingGeneration
+		c.mu.Unlock()
+
+		products, err := load(ctx)
+		if err == nil {
+			c.mu.Lock()
+			if c.listingGeneration == generation {
+				c.listing = &listingEntry{products: copyProducts(products), expires: c.now().Add(c.ttl)}
+			}
+			c.mu.Unlock()
+		}
+		return products, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyProducts(value.([]*domain.Product)), nil
+}
+
+// load runs fn once for all the concurrent callers asking for key. The
+// shared call is not cancelled when the caller that started it gives up,
+// while every caller still returns as soon as its own ctx is done.
+func (c *ProductCache) load(ctx context.Context, key string, fn func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	shared := context.WithoutCancel(ctx)
+	result := c.group.DoChan(key, func() (interface{}, error) {
+		return fn(shared)
+	})
+	select {
+	case r := <-result:
+		return r.Val, r.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// beginLoad registers a load of id and returns the generation store must
+// still see for the load's result to be cached.
+func (c *ProductCache) beginLoad(id domain.ProductID) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	load, loading := c.loads[id]
+	if !loading {
+		load = &productLoad{}
+		c.loads[id] = load
+	}
+	load.running++
+	return load.generation
+}
+
+func (c *ProductCache) endLoad(id domain.ProductID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	load := c.loads[id]
+	load.running--
+	if load.running == 0 {
+		delete(c.loads, id)
+	}
+}
+
+// lookup returns the cached product for id, whether it exists, and whether
+// the cache had a live entry for it at all.
+func (c *ProductCache) lookup(id domain.ProductID) (*domain.Product, bool, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[id]
+	if !exists {
+		return nil, false, false
+	}
+	entry := element.Value.(*productEntry)
+	if !c.now().Before(entry.expires) {
+		c.lru.Remove(element)
+		delete(c.entries, id)
+		return nil, false, false
+	}
+	c.lru.MoveToFront(element)
+	if entry.product == nil {
+		return nil, false, true
+	}
+	return copyProduct(entry.product), true, true
+}
+
+func (c *ProductCache) store(id domain.ProductID, product *domain.Product, ttl time.Duration, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if load, loading := c.loads[id]; !loading || load.generation != generation || c.capacity <= 0 {
+		return
+	}
+	entry := &productEntry{id: id, product: copyProduct(product), expires: c.now().Add(ttl)}
+	if element, exists := c.entries[id]; exists {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[id] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*productEntry).id)
+	}
+}
+
+// copyProduct keeps callers, such as the updater that changes the product
+// it found, from modifying the cached one.
+func copyProduct(product *domain.Product) *domain.Product {
+	if product == nil {
+		return nil
+	}
+	copied := *product
+	return &copied
+}
+
+func copyProducts(products []*domain.Product) []*domain.Product {
+	if products == nil {
+		return nil
+	}
+	copied := make([]*domain.Product, len(products))
+	for i, product := range products {
+		copied[i] = copyProduct(product)
+	}
+	return copied
+}
+
+// CacheFindRepository serves repeated lookups of a product from cache.
+func CacheFindRepository(repository domain.ProductFindRepository, cache *ProductCache) domain.ProductFindRepository {
+	return &cachedFindRepository{next: repository, cache: cache}
+}
+
+type cachedFindRepository struct {
+	next  domain.ProductFindRepository
+	cache *ProductCache
+}
+
+func (r *cachedFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	return r.cache.find(ctx, id, func(ctx context.Context) (*domain.Product, error) {
+		return r.next.Find(ctx, id)
+	})
+}
+
+// CacheFindAllRepository serves repeated listings from cache.
+func CacheFindAllRepository(repository domain.ProductFindAllRepository, cache *ProductCache) domain.ProductFindAllRepository {
+	return &cachedFindAllRepository{next: repository, cache: cache}
+}
+
+type cachedFindAllRepository struct {
+	next  domain.ProductFindAllRepository
+	cache *ProductCache
+}
+
+func (r *cachedFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	return r.cache.findAll(ctx, r.next.FindAll)
+}
+
+// CacheSaveRepository invalidates the saved product and the listing in
+// cache. It invalidates after a failed save too, since the write may have
+// gone through.
+func CacheSaveRepository(repository domain.ProductSaveRepository, cache *ProductCache) domain.ProductSaveRepository {
+	return &cachedSaveRepository{next: repository, cache: cache}
+}
+
+type cachedSaveRepository struct {
+	next  domain.ProductSaveRepository
+	cache *ProductCache
+}
+
+func (r *cachedSaveRepository) Save(ctx context.Context, product *domain.Product) error {
+	err := r.next.Save(ctx, product)
+	if product != nil {
+		r.cache.Invalidate(product.ID)
+	}
+	return err
+}
+
+// CacheDeleteRepository invalidates the deleted product and the listing in
+// cache.
+func CacheDeleteRepository(repository domain.ProductDeleteRepository, cache *ProductCache) domain.ProductDeleteRepository {
+	return &cachedDeleteRepository{next: repository, cache: cache}
+}
+
+type cachedDeleteRepository struct {
+	next  domain.ProductDeleteRepository
+	cache *ProductCache
+}
+
+func (r *cachedDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	err := r.next.Delete(ctx, id)
+	r.cache.Invalidate(id)
+	return err
+}
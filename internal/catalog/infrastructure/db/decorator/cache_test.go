@@ -0,0 +1,235 @@
+package decorator
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestCacheFindRepository(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []CacheOption
+		advance   time.Duration
+		result    *domain.Product
+		err       error
+		wantCalls int
+	}{
+		{name: "serves hits from cache", result: &domain.Product{ID: "1", Name: "Product"}, wantCalls: 1},
+		{name: "reloads after the TTL", advance: DefaultCacheTTL, result: &domain.Product{ID: "1"}, wantCalls: 2},
+		{name: "caches not found", err: domain.ErrNotFoundProduct, wantCalls: 1},
+		{name: "expires not found sooner", advance: DefaultCacheNegativeTTL, err: domain.ErrNotFoundProduct, wantCalls: 2},
+		{name: "negative caching disabled", opts: []CacheOption{WithNegativeTTL(0)}, err: domain.ErrNotFoundProduct, wantCalls: 2},
+		{name: "does not cache errors", err: domain.ErrRepositoryProduct, wantCalls: 2},
+		{name: "capacity zero disables caching", opts: []CacheOption{WithCacheCapacity(0)}, result: &domain.Product{ID: "1"}, wantCalls: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			clock := &fakeClock{now: time.Unix(0, 0)}
+			cache := NewProductCache(append([]CacheOption{WithCacheClock(clock.Now)}, tt.opts...)...)
+			find := mocks.NewMockProductFindRepository(ctrl)
+			find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(tt.result, tt.err).Times(tt.wantCalls)
+			repository := CacheFindRepository(find, cache)
+
+			for i := 0; i < 2; i++ {
+				product, err := repository.Find(context.Background(), "1")
+				assert.ErrorIs(t, err, tt.err)
+				assert.Equal(t, tt.result, product)
+				clock.now = clock.now.Add(tt.advance)
+			}
+		})
+	}
+}
+
+func TestCacheFindRepository_ReturnsCopies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(&domain.Product{ID: "1", Name: "Original"}, nil)
+	repository := CacheFindRepository(find, NewProductCache())
+
+	product, err := repository.Find(context.Background(), "1")
+	require.NoError(t, err)
+	product.Name = "Changed"
+
+	product, err = repository.Find(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Original", product.Name)
+}
+
+func TestCacheFindRepository_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+		return &domain.Product{ID: id}, nil
+	}).Times(4)
+	cache := NewProductCache(WithCacheCapacity(2))
+	repository := CacheFindRepository(find, cache)
+
+	// 1 is used after 2, so loading 3 evicts 2.
+	for _, id := range []domain.ProductID{"1", "2", "1", "3", "1", "2"} {
+		_, err := repository.Find(context.Background(), id)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, cache.Len())
+}
+
+func TestCacheFindRepository_CollapsesConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).DoAndReturn(func(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+		<-release
+		return &domain.Product{ID: id}, nil
+	}).Times(1)
+	repository := CacheFindRepository(find, NewProductCache())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			product, err := repository.Find(context.Background(), "1")
+			assert.NoError(t, err)
+			assert.Equal(t, domain.ProductID("1"), product.ID)
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+}
+
+func TestCacheFindRepository_CallerCancellation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	release := make(chan struct{})
+	loaded := make(chan struct{})
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).DoAndReturn(func(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+		<-release
+		defer close(loaded)
+		return &domain.Product{ID: id}, ctx.Err()
+	}).Times(1)
+	cache := NewProductCache()
+	repository := CacheFindRepository(find, cache)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := repository.Find(ctx, "1")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The load the cancelled caller started still completes and fills the
+	// cache for the next one.
+	close(release)
+	<-loaded
+	product, err := repository.Find(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ProductID("1"), product.ID)
+}
+
+func TestCacheRepositories_InvalidateOnWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	product := &domain.Product{ID: "1"}
+	cache := NewProductCache()
+
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(product, nil).Times(3)
+	findAll := mocks.NewMockProductFindAllRepository(ctrl)
+	findAll.EXPECT().FindAll(gomock.Any()).Return([]*domain.Product{product}, nil).Times(3)
+	save := mocks.NewMockProductSaveRepository(ctrl)
+	save.EXPECT().Save(gomock.Any(), product).Return(nil)
+	remove := mocks.NewMockProductDeleteRepository(ctrl)
+	remove.EXPECT().Delete(gomock.Any(), domain.ProductID("1")).Return(domain.ErrRepositoryProduct)
+
+	findRepository := CacheFindRepository(find, cache)
+	findAllRepository := CacheFindAllRepository(findAll, cache)
+	read := func() {
+		for i := 0; i < 2; i++ {
+			_, err := findRepository.Find(ctx, "1")
+			require.NoError(t, err)
+			products, err := findAllRepository.FindAll(ctx)
+			require.NoError(t, err)
+			assert.Len(t, products, 1)
+		}
+	}
+
+	read()
+	require.NoError(t, CacheSaveRepository(save, cache).Save(ctx, product))
+	read()
+	// A failed delete may still have removed the product.
+	assert.ErrorIs(t, CacheDeleteRepository(remove, cache).Delete(ctx, "1"), domain.ErrRepositoryProduct)
+	read()
+}
+
+func TestCacheFindRepository_DoesNotStoreLoadsRacingAWrite(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := NewProductCache()
+	find := mocks.NewMockProductFindRepository(ctrl)
+	gomock.InOrder(
+		find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).DoAndReturn(func(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+			cache.Invalidate(id)
+			return &domain.Product{ID: id, Name: "Stale"}, nil
+		}),
+		find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(&domain.Product{ID: "1", Name: "Fresh"}, nil),
+	)
+	repository := CacheFindRepository(find, cache)
+
+	product, err := repository.Find(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Stale", product.Name)
+
+	product, err = repository.Find(context.Background(), "1")
+	require.NoError(t, err)
+	assert.Equal(t, "Fresh", product.Name)
+}
+
+func TestCacheFindRepository_StoresLoadsRacingWritesOfOtherProducts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cache := NewProductCache()
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).DoAndReturn(func(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+		cache.Invalidate("2")
+		return &domain.Product{ID: id, Name: "Product 1"}, nil
+	}).Times(1)
+	repository := CacheFindRepository(find, cache)
+
+	for i := 0; i < 2; i++ {
+		product, err := repository.Find(context.Background(), "1")
+		require.NoError(t, err)
+		assert.Equal(t, "Product 1", product.Name)
+	}
+	assert.Equal(t, 1, cache.Len())
+}
@@ -0,0 +1,186 @@
+package decorator
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+// ChaosRule is one fault injected into the repository calls it matches.
+type ChaosRule struct {
+	// Operation is save, find, find_all or delete; empty matches them all.
+	Operation string
+	// ProductID restricts the rule to the calls for one product.
+	ProductID domain.ProductID
+	// Rate is the share of the matching calls hit by the fault; 0 counts
+	// as 1.
+	Rate    float64
+	Latency time.Duration
+	// Hang blocks the call until its context is done, like a backend that
+	// stopped answering.
+	Hang bool
+	// Err is returned instead of calling the repository.
+	Err error
+	// Limit truncates the listings to that many products.
+	Limit int
+}
+
+// Chaos injects the faults of its rules into the repositories decorated
+// with it. Every matching rule applies, in order, until one fails the call.
+type Chaos struct {
+	rules  []ChaosRule
+	random func() float64
+}
+
+type ChaosOption func(*Chaos)
+
+// WithChaosRandom replaces the source of the rule rates, mostly for tests.
+func WithChaosRandom(random func() float64) ChaosOption {
+	return func(c *Chaos) {
+		c.random = random
+	}
+}
+
+func NewChaos(rules []ChaosRule, opts ...ChaosOption) *Chaos {
+	c := &Chaos{rules: rules, random: rand.Float64}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// inject applies the rules matching a call and returns the error that must
+// fail it, or the number of products a listing is truncated to.
+func (c *Chaos) inject(ctx context.Context, operation string, id domain.ProductID) (int, error) {
+	limit := 0
+	for _, rule := range c.rules {
+		if rule.Operation != "" && rule.Operation != operation {
+			continue
+		}
+		if rule.ProductID != "" && rule.ProductID != id {
+			continue
+		}
+		if rule.Rate > 0 && rule.Rate < 1 && c.random() >= rule.Rate {
+			continue
+		}
+
+		if rule.Latency > 0 {
+			if err := sleep(ctx, rule.Latency); err != nil {
+				return 0, err
+			}
+		}
+		if rule.Hang {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		if rule.Err != nil {
+			return 0, rule.Err
+		}
+		if rule.Limit > 0 && (limit == 0 || rule.Limit < limit) {
+			limit = rule.Limit
+		}
+	}
+	return limit, nil
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ChaosSaveRepository injects the faults of chaos into repository. Without
+// rules it returns repository as is.
+func ChaosSaveRepository(repository domain.ProductSaveRepository, chaos *Chaos) domain.ProductSaveRepository {
+	if len(chaos.rules) == 0 {
+		return repository
+	}
+	return &chaosSaveRepository{next: repository, chaos: chaos}
+}
+
+type chaosSaveRepository struct {
+	next  domain.ProductSaveRepository
+	chaos *Chaos
+}
+
+func (r *chaosSaveRepository) Save(ctx context.Context, product *domain.Product) error {
+	var id domain.ProductID
+	if product != nil {
+		id = product.ID
+	}
+	if _, err := r.chaos.inject(ctx, "save", id); err != nil {
+		return err
+	}
+	return r.next.Save(ctx, product)
+}
+
+func ChaosFindRepository(repository domain.ProductFindRepository, chaos *Chaos) domain.ProductFindRepository {
+	if len(chaos.rules) == 0 {
+		return repository
+	}
+	return &chaosFindRepository{next: repository, chaos: chaos}
+}
+
+type chaosFindRepository struct {
+	next  domain.ProductFindRepository
+	chaos *Chaos
+}
+
+func (r *chaosFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	if _, err := r.chaos.inject(ctx, "find", id); err != nil {
+		return nil, err
+	}
+	return r.next.Find(ctx, id)
+}
+
+// ChaosFindAllRepository injects the faults of chaos into repository,
+// returning partial listings for the rules with a limit.
+func ChaosFindAllRepository(repository domain.ProductFindAllRepository, chaos *Chaos) domain.ProductFindAllRepository {
+	if len(chaos.rules) == 0 {
+		return repository
+	}
+	return &chaosFindAllRepository{next: repository, chaos: chaos}
+}
+
+type chaosFindAllRepository struct {
+	next  domain.ProductFindAllRepository
+	chaos *Chaos
+}
+
+func (r *chaosFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	limit, err := r.chaos.inject(ctx, "find_all", "")
+	if err != nil {
+		return nil, err
+	}
+	products, err := r.next.FindAll(ctx)
+	if err == nil && limit > 0 && len(products) > limit {
+		products = products[:limit]
+	}
+	return products, err
+}
+
+func ChaosDeleteRepository(repository domain.ProductDeleteRepository, chaos *Chaos) domain.ProductDeleteRepository {
+	if len(chaos.rules) == 0 {
+		return repository
+	}
+	return &chaosDeleteRepository{next: repository, chaos: chaos}
+}
+
+type chaosDeleteRepository struct {
+	next  domain.ProductDeleteRepository
+	chaos *Chaos
+}
+
+func (r *chaosDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	if _, err := r.chaos.inject(ctx, "delete", id); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
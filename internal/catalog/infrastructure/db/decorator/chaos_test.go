@@ -0,0 +1,82 @@
+package decorator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+func TestChaosFindRepository(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     []ChaosRule
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "Matching error", rules: []ChaosRule{{Operation: "find", Err: domain.ErrNotFoundProduct}}, wantErr: domain.ErrNotFoundProduct},
+		{name: "Other operation", rules: []ChaosRule{{Operation: "save", Err: domain.ErrRepositoryProduct}}, wantCalls: 1},
+		{name: "Other product", rules: []ChaosRule{{ProductID: "2", Err: domain.ErrRepositoryProduct}}, wantCalls: 1},
+		{name: "Rate hit", rules: []ChaosRule{{Rate: 0.5, Err: domain.ErrRepositoryUnavailable}}, wantErr: domain.ErrRepositoryUnavailable},
+		{name: "Rate missed", rules: []ChaosRule{{Rate: 0.2, Err: domain.ErrRepositoryUnavailable}}, wantCalls: 1},
+		{name: "Latency then the repository", rules: []ChaosRule{{Latency: time.Millisecond}}, wantCalls: 1},
+		{name: "Latency then an error", rules: []ChaosRule{{Latency: time.Millisecond}, {Err: domain.ErrAlreadyExistsProduct}}, wantErr: domain.ErrAlreadyExistsProduct},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			find := mocks.NewMockProductFindRepository(ctrl)
+			find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(&domain.Product{ID: "1"}, nil).Times(tt.wantCalls)
+			chaos := NewChaos(tt.rules, WithChaosRandom(func() float64 { return 0.3 }))
+
+			_, err := ChaosFindRepository(find, chaos).Find(context.Background(), "1")
+
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestChaosFindAllRepository_PartialResults(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	findAll := mocks.NewMockProductFindAllRepository(ctrl)
+	findAll.EXPECT().FindAll(gomock.Any()).Return([]*domain.Product{{ID: "1"}, {ID: "2"}, {ID: "3"}}, nil)
+	chaos := NewChaos([]ChaosRule{{Operation: "find_all", Limit: 2}, {Limit: 5}})
+
+	products, err := ChaosFindAllRepository(findAll, chaos).FindAll(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Product{{ID: "1"}, {ID: "2"}}, products)
+}
+
+func TestChaosDeleteRepository_Hang(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	chaos := NewChaos([]ChaosRule{{Operation: "delete", Hang: true}})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	err := ChaosDeleteRepository(mocks.NewMockProductDeleteRepository(ctrl), chaos).Delete(ctx, "1")
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestChaosRepositories_WithoutRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	save := mocks.NewMockProductSaveRepository(ctrl)
+	chaos := NewChaos(nil)
+
+	assert.Same(t, save, ChaosSaveRepository(save, chaos))
+}
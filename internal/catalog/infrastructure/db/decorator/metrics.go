@@ -0,0 +1,105 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// repositoryMetrics records one operation of the repositories of a backend.
+// Not finding a product is an expected answer, so it gets its own outcome
+// instead of counting as an error.
+type repositoryMetrics struct {
+	backend    string
+	operations pkgapplication.Counter
+	duration   pkgapplication.Histogram
+}
+
+func newRepositoryMetrics(metrics pkgapplication.Metrics, backend string) repositoryMetrics {
+	return repositoryMetrics{
+		backend:    backend,
+		operations: metrics.Counter("catalog_repository_operations_total", "Repository calls by backend, operation and outcome.", "backend", "operation", "outcome"),
+		duration:   metrics.Histogram("catalog_repository_operation_duration_seconds", "Repository call latency.", nil, "backend", "operation"),
+	}
+}
+
+func (m repositoryMetrics) observe(operation string, start time.Time, err error) {
+	outcome := "success"
+	switch {
+	case errors.Is(err, domain.ErrNotFoundProduct):
+		outcome = "not_found"
+	case err != nil:
+		outcome = "error"
+	}
+	m.operations.Inc(m.backend, operation, outcome)
+	m.duration.Observe(time.Since(start).Seconds(), m.backend, operation)
+}
+
+// InstrumentSaveRepository records the calls to repository in metrics,
+// labelled with backend.
+func InstrumentSaveRepository(repository domain.ProductSaveRepository, metrics pkgapplication.Metrics, backend string) domain.ProductSaveRepository {
+	return &instrumentedSaveRepository{next: repository, metrics: newRepositoryMetrics(metrics, backend)}
+}
+
+type instrumentedSaveRepository struct {
+	next    domain.ProductSaveRepository
+	metrics repositoryMetrics
+}
+
+func (r *instrumentedSaveRepository) Save(ctx context.Context, product *domain.Product) error {
+	start := time.Now()
+	err := r.next.Save(ctx, product)
+	r.metrics.observe("save", start, err)
+	return err
+}
+
+func InstrumentFindRepository(repository domain.ProductFindRepository, metrics pkgapplication.Metrics, backend string) domain.ProductFindRepository {
+	return &instrumentedFindRepository{next: repository, metrics: newRepositoryMetrics(metrics, backend)}
+}
+
+type instrumentedFindRepository struct {
+	next    domain.ProductFindRepository
+	metrics repositoryMetrics
+}
+
+func (r *instrumentedFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	start := time.Now()
+	product, err := r.next.Find(ctx, id)
+	r.metrics.observe("find", start, err)
+	return product, err
+}
+
+func InstrumentFindAllRepository(repository domain.ProductFindAllRepository, metrics pkgapplication.Metrics, backend string) domain.ProductFindAllRepository {
+	return &instrumentedFindAllRepository{next: repository, metrics: newRepositoryMetrics(metrics, backend)}
+}
+
+type instrumentedFindAllRepository struct {
+	next    domain.ProductFindAllRepository
+	metrics repositoryMetrics
+}
+
+func (r *instrumentedFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	start := time.Now()
+	products, err := r.next.FindAll(ctx)
+	r.metrics.observe("find_all", start, err)
+	return products, err
+}
+
+func InstrumentDeleteRepository(repository domain.ProductDeleteRepository, metrics pkgapplication.Metrics, backend string) domain.ProductDeleteRepository {
+	return &instrumentedDeleteRepository{next: repository, metrics: newRepositoryMetrics(metrics, backend)}
+}
+
+type instrumentedDeleteRepository struct {
+	next    domain.ProductDeleteRepository
+	metrics repositoryMetrics
+}
+
+func (r *instrumentedDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	start := time.Now()
+	err := r.next.Delete(ctx, id)
+	r.metrics.observe("delete", start, err)
+	return err
+}
@@ -0,0 +1,59 @@
+package decorator
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+func TestInstrumentedRepositories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	registry := prometheus.NewRegistry()
+	product := &domain.Product{ID: "1"}
+
+	save := mocks.NewMockProductSaveRepository(ctrl)
+	save.EXPECT().Save(gomock.Any(), product).Return(domain.ErrRepositoryProduct)
+	assert.ErrorIs(t, InstrumentSaveRepository(save, registry, "memory").Save(context.Background(), product), domain.ErrRepositoryProduct)
+
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(product, nil)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("2")).Return(nil, domain.ErrNotFoundProduct)
+	instrumentedFind := InstrumentFindRepository(find, registry, "memory")
+	found, err := instrumentedFind.Find(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, product, found)
+	_, err = instrumentedFind.Find(context.Background(), "2")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+
+	findAll := mocks.NewMockProductFindAllRepository(ctrl)
+	findAll.EXPECT().FindAll(gomock.Any()).Return([]*domain.Product{product}, nil)
+	products, err := InstrumentFindAllRepository(findAll, registry, "memory").FindAll(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, products, 1)
+
+	remove := mocks.NewMockProductDeleteRepository(ctrl)
+	remove.EXPECT().Delete(gomock.Any(), domain.ProductID("1")).Return(nil)
+	assert.NoError(t, InstrumentDeleteRepository(remove, registry, "memory").Delete(context.Background(), "1"))
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	for _, line := range []string{
+		`catalog_repository_operations_total{backend="memory",operation="delete",outcome="success"} 1`,
+		`catalog_repository_operations_total{backend="memory",operation="find",outcome="not_found"} 1`,
+		`catalog_repository_operations_total{backend="memory",operation="find",outcome="success"} 1`,
+		`catalog_repository_operations_total{backend="memory",operation="find_all",outcome="success"} 1`,
+		`catalog_repository_operations_total{backend="memory",operation="save",outcome="error"} 1`,
+		`catalog_repository_operation_duration_seconds_count{backend="memory",operation="find"} 2`,
+	} {
+		assert.Contains(t, out.String(), line+"\n")
+	}
+}
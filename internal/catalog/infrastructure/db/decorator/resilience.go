@@ -0,0 +1,95 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/resilience"
+)
+
+// unavailable turns the errors that mean the backend cannot serve the call
+// right now, a transient error that outlasted the retries or an open
+// circuit, into domain.ErrRepositoryUnavailable.
+func unavailable(policy *resilience.Policy, err error) error {
+	if errors.Is(err, resilience.ErrCircuitOpen) || policy.IsTransient(err) {
+		return domain.ErrRepositoryUnavailable
+	}
+	return err
+}
+
+// ResilientSaveRepository runs every call to repository under policy. The
+// repositories of one backend share a policy, so they share its breaker.
+func ResilientSaveRepository(repository domain.ProductSaveRepository, policy *resilience.Policy) domain.ProductSaveRepository {
+	return &resilientSaveRepository{next: repository, policy: policy}
+}
+
+type resilientSaveRepository struct {
+	next   domain.ProductSaveRepository
+	policy *resilience.Policy
+}
+
+func (r *resilientSaveRepository) Save(ctx context.Context, product *domain.Product) error {
+	err := r.policy.Do(ctx, "save", func(ctx context.Context) error {
+		return r.next.Save(ctx, product)
+	})
+	return unavailable(r.policy, err)
+}
+
+func ResilientFindRepository(repository domain.ProductFindRepository, policy *resilience.Policy) domain.ProductFindRepository {
+	return &resilientFindRepository{next: repository, policy: policy}
+}
+
+type resilientFindRepository struct {
+	next   domain.ProductFindRepository
+	policy *resilience.Policy
+}
+
+func (r *resilientFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	var product *domain.Product
+	err := r.policy.Do(ctx, "find", func(ctx context.Context) (err error) {
+		product, err = r.next.Find(ctx, id)
+		return err
+	})
+	if err != nil {
+		return nil, unavailable(r.policy, err)
+	}
+	return product, nil
+}
+
+func ResilientFindAllRepository(repository domain.ProductFindAllRepository, policy *resilience.Policy) domain.ProductFindAllRepository {
+	return &resilientFindAllRepository{next: repository, policy: policy}
+}
+
+type resilientFindAllRepository struct {
+	next   domain.ProductFindAllRepository
+	policy *resilience.Policy
+}
+
+func (r *resilientFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	var products []*domain.Product
+	err := r.policy.Do(ctx, "find_all", func(ctx context.Context) (err error) {
+		products, err = r.next.FindAll(ctx)
+		return err
+	})
+	if err != nil {
+		return nil, unavailable(r.policy, err)
+	}
+	return products, nil
+}
+
+func ResilientDeleteRepository(repository domain.ProductDeleteRepository, policy *resilience.Policy) domain.ProductDeleteRepository {
+	return &resilientDeleteRepository{next: repository, policy: policy}
+}
+
+type resilientDeleteRepository struct {
+	next   domain.ProductDeleteRepository
+	policy *resilience.Policy
+}
+
+func (r *resilientDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	err := r.policy.Do(ctx, "delete", func(ctx context.Context) error {
+		return r.next.Delete(ctx, id)
+	})
+	return unavailable(r.policy, err)
+}
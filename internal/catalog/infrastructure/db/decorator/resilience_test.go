@@ -0,0 +1,102 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/resilience"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+var errThrottled = errors.New("throttled")
+
+func testPolicy(opts ...resilience.Option) *resilience.Policy {
+	return resilience.NewPolicy(append([]resilience.Option{
+		resilience.WithTransient(func(err error) bool { return errors.Is(err, errThrottled) }),
+		resilience.WithBackoff(0, 0),
+	}, opts...)...)
+}
+
+func TestResilientFindRepository(t *testing.T) {
+	tests := []struct {
+		name    string
+		results []error
+		wantErr error
+	}{
+		{name: "Retries transient errors", results: []error{errThrottled, nil}},
+		{name: "Unavailable after the last attempt", results: []error{errThrottled, errThrottled, errThrottled}, wantErr: domain.ErrRepositoryUnavailable},
+		{name: "Not found is not retried", results: []error{domain.ErrNotFoundProduct}, wantErr: domain.ErrNotFoundProduct},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			find := mocks.NewMockProductFindRepository(ctrl)
+			var calls []*gomock.Call
+			for _, err := range tt.results {
+				var product *domain.Product
+				if err == nil {
+					product = &domain.Product{ID: "1"}
+				}
+				calls = append(calls, find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(product, err))
+			}
+			gomock.InOrder(calls...)
+
+			product, err := ResilientFindRepository(find, testPolicy()).Find(context.Background(), "1")
+
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err, "the HTTP adapters match the error exactly")
+				assert.Nil(t, product)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, domain.ProductID("1"), product.ID)
+		})
+	}
+}
+
+func TestResilientRepositories_ShareTheBreaker(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	policy := testPolicy(resilience.WithMaxAttempts(1), resilience.WithBreaker(resilience.NewBreaker(2, time.Minute)))
+	ctx := context.Background()
+
+	save := mocks.NewMockProductSaveRepository(ctrl)
+	save.EXPECT().Save(gomock.Any(), gomock.Any()).Return(errThrottled)
+	findAll := mocks.NewMockProductFindAllRepository(ctrl)
+	findAll.EXPECT().FindAll(gomock.Any()).Return(nil, errThrottled)
+	remove := mocks.NewMockProductDeleteRepository(ctrl)
+	find := mocks.NewMockProductFindRepository(ctrl)
+
+	assert.Equal(t, domain.ErrRepositoryUnavailable, ResilientSaveRepository(save, policy).Save(ctx, &domain.Product{ID: "1"}))
+	_, err := ResilientFindAllRepository(findAll, policy).FindAll(ctx)
+	assert.Equal(t, domain.ErrRepositoryUnavailable, err)
+
+	// The circuit is open: the calls fail fast without reaching the mocks.
+	assert.Equal(t, domain.ErrRepositoryUnavailable, ResilientDeleteRepository(remove, policy).Delete(ctx, "1"))
+	_, err = ResilientFindRepository(find, policy).Find(ctx, "1")
+	assert.Equal(t, domain.ErrRepositoryUnavailable, err)
+}
+
+func TestResilientDeleteRepository_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	remove := mocks.NewMockProductDeleteRepository(ctrl)
+	remove.EXPECT().Delete(gomock.Any(), domain.ProductID("1")).DoAndReturn(func(ctx context.Context, id domain.ProductID) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}).Times(2)
+
+	policy := testPolicy(resilience.WithMaxAttempts(2), resilience.WithTimeout(time.Millisecond))
+	assert.Equal(t, domain.ErrRepositoryUnavailable, ResilientDeleteRepository(remove, policy).Delete(context.Background(), "1"))
+}
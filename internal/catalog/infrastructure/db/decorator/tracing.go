@@ -0,0 +1,100 @@
+package decorator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// startSpan starts the span of one repository call, with the backend as
+// the db.system, so a trace reaches the DynamoDB or SQL call.
+func startSpan(ctx context.Context, tracer pkgapplication.Tracer, name, backend, operation string, kv ...interface{}) (context.Context, pkgapplication.Span) {
+	return tracer.Start(ctx, name, append([]interface{}{"db.system", backend, "db.operation.name", operation}, kv...)...)
+}
+
+// endSpan ends span, recording err. Not finding a product is an expected
+// answer and is recorded as an attribute instead.
+func endSpan(span pkgapplication.Span, err error) {
+	if errors.Is(err, domain.ErrNotFoundProduct) {
+		span.SetAttributes("product.found", false)
+	} else {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// TraceSaveRepository wraps every call to repository in a span.
+func TraceSaveRepository(repository domain.ProductSaveRepository, tracer pkgapplication.Tracer, backend string) domain.ProductSaveRepository {
+	return &tracedSaveRepository{next: repository, tracer: tracer, backend: backend}
+}
+
+type tracedSaveRepository struct {
+	next    domain.ProductSaveRepository
+	tracer  pkgapplication.Tracer
+	backend string
+}
+
+func (r *tracedSaveRepository) Save(ctx context.Context, product *domain.Product) error {
+	var id string
+	if product != nil {
+		id = string(product.ID)
+	}
+	ctx, span := startSpan(ctx, r.tracer, "ProductSaveRepository.Save", r.backend, "save", "product.id", id)
+	err := r.next.Save(ctx, product)
+	endSpan(span, err)
+	return err
+}
+
+func TraceFindRepository(repository domain.ProductFindRepository, tracer pkgapplication.Tracer, backend string) domain.ProductFindRepository {
+	return &tracedFindRepository{next: repository, tracer: tracer, backend: backend}
+}
+
+type tracedFindRepository struct {
+	next    domain.ProductFindRepository
+	tracer  pkgapplication.Tracer
+	backend string
+}
+
+func (r *tracedFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ProductFindRepository.Find", r.backend, "find", "product.id", string(id))
+	product, err := r.next.Find(ctx, id)
+	endSpan(span, err)
+	return product, err
+}
+
+func TraceFindAllRepository(repository domain.ProductFindAllRepository, tracer pkgapplication.Tracer, backend string) domain.ProductFindAllRepository {
+	return &tracedFindAllRepository{next: repository, tracer: tracer, backend: backend}
+}
+
+type tracedFindAllRepository struct {
+	next    domain.ProductFindAllRepository
+	tracer  pkgapplication.Tracer
+	backend string
+}
+
+func (r *tracedFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	ctx, span := startSpan(ctx, r.tracer, "ProductFindAllRepository.FindAll", r.backend, "find_all")
+	products, err := r.next.FindAll(ctx)
+	span.SetAttributes("catalog.products", len(products))
+	endSpan(span, err)
+	return products, err
+}
+
+func TraceDeleteRepository(repository domain.ProductDeleteRepository, tracer pkgapplication.Tracer, backend string) domain.ProductDeleteRepository {
+	return &tracedDeleteRepository{next: repository, tracer: tracer, backend: backend}
+}
+
+type tracedDeleteRepository struct {
+	next    domain.ProductDeleteRepository
+	tracer  pkgapplication.Tracer
+	backend string
+}
+
+func (r *tracedDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	ctx, span := startSpan(ctx, r.tracer, "ProductDeleteRepository.Delete", r.backend, "delete", "product.id", string(id))
+	err := r.next.Delete(ctx, id)
+	endSpan(span, err)
+	return err
+}
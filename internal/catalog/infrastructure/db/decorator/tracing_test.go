@@ -0,0 +1,68 @@
+package decorator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+	"github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+)
+
+func TestTracedRepositories(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, tracing.WithSyncExport())
+	ctx, root := tracer.Start(context.Background(), "root")
+	product := &domain.Product{ID: "1"}
+
+	save := mocks.NewMockProductSaveRepository(ctrl)
+	save.EXPECT().Save(gomock.Any(), product).Return(domain.ErrRepositoryProduct)
+	assert.ErrorIs(t, TraceSaveRepository(save, tracer, "dynamodb").Save(ctx, product), domain.ErrRepositoryProduct)
+
+	find := mocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("2")).Return(nil, domain.ErrNotFoundProduct)
+	_, err := TraceFindRepository(find, tracer, "dynamodb").Find(ctx, "2")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+
+	findAll := mocks.NewMockProductFindAllRepository(ctrl)
+	findAll.EXPECT().FindAll(gomock.Any()).Return([]*domain.Product{product}, nil)
+	_, err = TraceFindAllRepository(findAll, tracer, "dynamodb").FindAll(ctx)
+	assert.NoError(t, err)
+
+	remove := mocks.NewMockProductDeleteRepository(ctrl)
+	remove.EXPECT().Delete(gomock.Any(), domain.ProductID("1")).Return(nil)
+	assert.NoError(t, TraceDeleteRepository(remove, tracer, "dynamodb").Delete(ctx, "1"))
+
+	root.End()
+
+	spans := exporter.Spans()
+	require.Len(t, spans, 5)
+	tests := []struct {
+		name      string
+		operation string
+		status    tracing.StatusCode
+	}{
+		{name: "ProductSaveRepository.Save", operation: "save", status: tracing.StatusError},
+		{name: "ProductFindRepository.Find", operation: "find"},
+		{name: "ProductFindAllRepository.FindAll", operation: "find_all"},
+		{name: "ProductDeleteRepository.Delete", operation: "delete"},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.name, spans[i].Name)
+			assert.Equal(t, "dynamodb", spans[i].Attribute("db.system"))
+			assert.Equal(t, tt.operation, spans[i].Attribute("db.operation.name"))
+			assert.Equal(t, tt.status, spans[i].Status)
+		})
+	}
+	assert.Equal(t, "1", spans[0].Attribute("product.id"))
+	assert.Equal(t, false, spans[1].Attribute("product.found"))
+	assert.Equal(t, int64(1), spans[2].Attribute("catalog.products"))
+}
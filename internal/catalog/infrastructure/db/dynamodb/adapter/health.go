@@ -0,0 +1,40 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// NewTableHealthCheck describes tableName and reports it down unless the
+// table is active. DescribeTable consumes no read capacity.
+func NewTableHealthCheck(db DynamoDBAPI, tableName string) pkgapplication.HealthCheck {
+	return pkgapplication.NewHealthCheck("dynamodb", func(ctx context.Context) error {
+		output, err := db.DescribeTable(ctx, &dynamodb.DescribeTableInput{TableName: aws.String(tableName)})
+		if err != nil {
+			return err
+		}
+		if output.Table == nil || output.Table.TableStatus != types.TableStatusActive {
+			status := types.TableStatus("unknown")
+			if output.Table != nil {
+				status = output.Table.TableStatus
+			}
+			return fmt.Errorf("table %s is %s", tableName, status)
+		}
+		return nil
+	})
+}
+
+func CreateTableHealthCheck(dependencies map[string]interface{}) (interface{}, error) {
+	db, tableName, err := dynamoDependencies(dependencies)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTableHealthCheck(db, tableName), nil
+}
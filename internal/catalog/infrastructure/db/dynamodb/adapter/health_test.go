@@ -0,0 +1,80 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/test/infrastructure/mocks"
+)
+
+func TestTableHealthCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		output      *dynamodb.DescribeTableOutput
+		err         error
+		expectedErr string
+	}{
+		{
+			name:   "Active table",
+			output: &dynamodb.DescribeTableOutput{Table: &types.TableDescription{TableStatus: types.TableStatusActive}},
+		},
+		{
+			name:        "Table being created",
+			output:      &dynamodb.DescribeTableOutput{Table: &types.TableDescription{TableStatus: types.TableStatusCreating}},
+			expectedErr: "table Products is CREATING",
+		},
+		{
+			name:        "No table description",
+			output:      &dynamodb.DescribeTableOutput{},
+			expectedErr: "table Products is unknown",
+		},
+		{
+			name:        "DescribeTable error",
+			err:         errors.New("ResourceNotFoundException"),
+			expectedErr: "ResourceNotFoundException",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockDB := mocks.NewMockDynamoDBAPI(ctrl)
+			mockDB.EXPECT().DescribeTable(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ context.Context, input *dynamodb.DescribeTableInput, _ ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+					assert.Equal(t, "Products", *input.TableName)
+					return tt.output, tt.err
+				})
+
+			check := NewTableHealthCheck(mockDB, "Products")
+			assert.Equal(t, "dynamodb", check.Name())
+
+			err := check.Check(context.Background())
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCreateTableHealthCheck(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
+
+	check, err := CreateTableHealthCheck(map[string]interface{}{"dynamoDBAPI": mockDB, "dynamoTableName": "Products"})
+	assert.NoError(t, err)
+	assert.Implements(t, (*pkgapplication.HealthCheck)(nil), check)
+
+	_, err = CreateTableHealthCheck(map[string]interface{}{"dynamoDBAPI": mockDB, "dynamoTableName": ""})
+	assert.Error(t, err)
+}
@@ -27,7 +27,7 @@ func (pe *DynamoDbProductEntity) ToDomain() (*domain.Product, error) {
 }
 
 func NewProductEntityFromDomain(product *domain.Product) (*DynamoDbProductEntity, error) {
-	if product == nil {
+	if product == nil || product.ID == "" {
 		return nil, domain.ErrInvalidProductID
 	}
 	return &DynamoDbProductEntity{
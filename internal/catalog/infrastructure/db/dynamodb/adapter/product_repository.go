@@ -3,6 +3,7 @@ package adapter
 import (
 	"context"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
@@ -19,7 +20,7 @@ func NewDynamoDbProductSaveRepository(db DynamoDBAPI, tableName string) domain.P
 	return &dynamoDbProductSaveRepository{DB: db, TableName: tableName}
 }
 
-func (r *dynamoDbProductSaveRepository) Save(product *domain.Product) error {
+func (r *dynamoDbProductSaveRepository) Save(ctx context.Context, product *domain.Product) error {
 	entity, err := NewProductEntityFromDomain(product)
 	if err != nil {
 		return err
@@ -30,9 +31,26 @@ func (r *dynamoDbProductSaveRepository) Save(product *domain.Product) error {
 		return err
 	}
 
-	_, err = r.DB.PutItem(context.TODO(), &dynamodb.PutItemInput{
-		TableName: &r.TableName,
-		Item:      item,
+	// Saving an existing product keeps its created_at, like the other
+	// backends, so it cannot be a PutItem replacing the whole item.
+	_, err = r.DB.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:        &r.TableName,
+		Key:              map[string]types.AttributeValue{"id": item["id"]},
+		UpdateExpression: aws.String("SET #name = :name, #description = :description, #price = :price, #updated_at = :updated_at, #created_at = if_not_exists(#created_at, :created_at)"),
+		ExpressionAttributeNames: map[string]string{
+			"#name":        "name",
+			"#description": "description",
+			"#price":       "price",
+			"#updated_at":  "updated_at",
+			"#created_at":  "created_at",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name":        item["name"],
+			":description": item["description"],
+			":price":       item["price"],
+			":updated_at":  item["updated_at"],
+			":created_at":  item["created_at"],
+		},
 	})
 	return err
 }
@@ -46,8 +64,13 @@ func NewDynamoDbProductFindRepository(db DynamoDBAPI, tableName string) domain.P
 	return &dynamoDbProductFindRepository{DB: db, TableName: tableName}
 }
 
-func (r *dynamoDbProductFindRepository) Find(id domain.ProductID) (*domain.Product, error) {
-	result, err := r.DB.GetItem(context.TODO(), &dynamodb.GetItemInput{
+func (r *dynamoDbProductFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	// DynamoDB rejects empty key attributes, so an empty ID can never match.
+	if id == "" {
+		return nil, domain.ErrNotFoundProduct
+	}
+
+	result, err := r.DB.GetItem(ctx, &dynamodb.GetItemInput{
 		TableName: &r.TableName,
 		Key: map[string]types.AttributeValue{
 			"id": &types.AttributeValueMemberS{Value: string(id)},
@@ -80,17 +103,26 @@ func NewDynamoDbProductFindAllRepository(db DynamoDBAPI, tableName string) domai
 	return &dynamoDbProductFindAllRepository{DB: db, TableName: tableName}
 }
 
-func (r *dynamoDbProductFindAllRepository) FindAll() ([]*domain.Product, error) {
-	result, err := r.DB.Scan(context.TODO(), &dynamodb.ScanInput{
+func (r *dynamoDbProductFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	var items []map[string]types.AttributeValue
+	input := &dynamodb.ScanInput{
 		TableName: &r.TableName,
-	})
-
-	if err != nil {
-		return nil, err
+	}
+	// A single Scan stops at 1 MB, so keep paging until DynamoDB has no more items.
+	for {
+		result, err := r.DB.Scan(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, result.Items...)
+		if len(result.LastEvaluatedKey) == 0 {
+			break
+		}
+		input.ExclusiveStartKey = result.LastEvaluatedKey
 	}
 
 	var entities []*DynamoDbProductEntity
-	err = attributevalue.UnmarshalListOfMaps(result.Items, &entities)
+	err := attributevalue.UnmarshalListOfMaps(items, &entities)
 	if err != nil {
 		return nil, err
 	}
@@ -113,8 +145,12 @@ func NewDynamoDbProductDeleteRepository(db DynamoDBAPI, tableName string) domain
 	return &dynamoDbProductDeleteRepository{DB: db, TableName: tableName}
 }
 
-func (r *dynamoDbProductDeleteRepository) Delete(id domain.ProductID) error {
-	_, err := r.DB.DeleteItem(context.TODO(), &dynamodb.DeleteItemInput{
+func (r *dynamoDbProductDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	if id == "" {
+		return nil
+	}
+
+	_, err := r.DB.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName: &r.TableName,
 		Key: map[string]types.AttributeValue{
 			"id": &types.AttributeValueMemberS{Value: string(id)},
@@ -0,0 +1,35 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/contract"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/db/dynamodb/fake"
+)
+
+func TestDynamoDbProductRepository_Contract(t *testing.T) {
+	contract.RunProductRepositoryTests(t, func(t *testing.T) contract.ProductRepositories {
+		// A small page size makes FindAll page through Scan results.
+		client, err := fake.New(fake.WithMaxPageSize(3))
+		require.NoError(t, err)
+		tableName := "Products"
+		_, err = client.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+			TableName: aws.String(tableName),
+			KeySchema: []types.KeySchemaElement{{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash}},
+		})
+		require.NoError(t, err)
+
+		return contract.ProductRepositories{
+			Save:    NewDynamoDbProductSaveRepository(client, tableName),
+			Find:    NewDynamoDbProductFindRepository(client, tableName),
+			FindAll: NewDynamoDbProductFindAllRepository(client, tableName),
+			Delete:  NewDynamoDbProductDeleteRepository(client, tableName),
+		}
+	})
+}
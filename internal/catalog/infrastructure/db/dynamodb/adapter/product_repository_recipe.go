@@ -2,60 +2,59 @@ package adapter
 
 import (
 	"fmt"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
 )
 
 func CreateProductSaveRepository(dependencies map[string]interface{}) (interface{}, error) {
-	db, ok := dependencies["dynamoDBAPI"].(DynamoDBAPI)
-	if !ok || db == nil {
-		return nil, fmt.Errorf("missing or invalid dynamoDBAPI dependency")
-	}
-
-	tableName, ok := dependencies["dynamoTableName"].(string)
-	if !ok || tableName == "" {
-		return nil, fmt.Errorf("missing or invalid dynamoTableName dependency")
+	db, tableName, err := dynamoDependencies(dependencies)
+	if err != nil {
+		return nil, err
 	}
 
 	return NewDynamoDbProductSaveRepository(db, tableName), nil
 }
 
 func CreateProductFindRepository(dependencies map[string]interface{}) (interface{}, error) {
-	db, ok := dependencies["dynamoDBAPI"].(DynamoDBAPI)
-	if !ok || db == nil {
-		return nil, fmt.Errorf("missing or invalid dynamoDBAPI dependency")
-	}
-
-	tableName, ok := dependencies["dynamoTableName"].(string)
-	if !ok || tableName == "" {
-		return nil, fmt.Errorf("missing or invalid dynamoTableName dependency")
+	db, tableName, err := dynamoDependencies(dependencies)
+	if err != nil {
+		return nil, err
 	}
 
 	return NewDynamoDbProductFindRepository(db, tableName), nil
 }
 
 func CreateProductFindAllRepository(dependencies map[string]interface{}) (interface{}, error) {
-	db, ok := dependencies["dynamoDBAPI"].(DynamoDBAPI)
-	if !ok || db == nil {
-		return nil, fmt.Errorf("missing or invalid dynamoDBAPI dependency")
-	}
-
-	tableName, ok := dependencies["dynamoTableName"].(string)
-	if !ok || tableName == "" {
-		return nil, fmt.Errorf("missing or invalid dynamoTableName dependency")
+	db, tableName, err := dynamoDependencies(dependencies)
+	if err != nil {
+		return nil, err
 	}
 
 	return NewDynamoDbProductFindAllRepository(db, tableName), nil
 }
 
 func CreateProductDeleteRepository(dependencies map[string]interface{}) (interface{}, error) {
-	db, ok := dependencies["dynamoDBAPI"].(DynamoDBAPI)
-	if !ok || db == nil {
-		return nil, fmt.Errorf("missing or invalid dynamoDBAPI dependency")
+	db, tableName, err := dynamoDependencies(dependencies)
+	if err != nil {
+		return nil, err
 	}
 
-	tableName, ok := dependencies["dynamoTableName"].(string)
-	if !ok || tableName == "" {
-		return nil, fmt.Errorf("missing or invalid dynamoTableName dependency")
+	return NewDynamoDbProductDeleteRepository(db, tableName), nil
+}
+
+func dynamoDependencies(dependencies map[string]interface{}) (DynamoDBAPI, string, error) {
+	db, err := pkgapplication.Dependency[DynamoDBAPI](dependencies, "dynamoDBAPI")
+	if err != nil {
+		return nil, "", err
 	}
 
-	return NewDynamoDbProductDeleteRepository(db, tableName), nil
+	tableName, err := pkgapplication.Dependency[string](dependencies, "dynamoTableName")
+	if err != nil {
+		return nil, "", err
+	}
+	if tableName == "" {
+		return nil, "", fmt.Errorf("empty dynamoTableName dependency")
+	}
+
+	return db, tableName, nil
 }
@@ -1,23 +1,35 @@
 package adapter
 
 import (
+	"context"
 	"testing"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/db/dynamodb/fake"
 	"github.com/mateusmacedo/go-sls-marketplace/test/infrastructure/mocks"
 )
 
-func TestSaveProduct(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
+func newFakeDynamoDB(t *testing.T, opts ...fake.Option) *fake.Client {
+	client, err := fake.New(opts...)
+	assert.NoError(t, err)
 
-	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
-	repo := NewDynamoDbProductSaveRepository(mockDB, "ProductsTable")
+	_, err = client.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName: aws.String("ProductsTable"),
+		KeySchema: []types.KeySchemaElement{{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash}},
+	})
+	assert.NoError(t, err)
+	return client
+}
+
+func TestSaveProduct(t *testing.T) {
+	client := newFakeDynamoDB(t)
+	repo := NewDynamoDbProductSaveRepository(client, "ProductsTable")
 
 	product := &domain.Product{
 		ID:    "1",
@@ -25,10 +37,16 @@ func TestSaveProduct(t *testing.T) {
 		Price: 100,
 	}
 
-	mockDB.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(&dynamodb.PutItemOutput{}, nil)
+	err := repo.Save(context.Background(), product)
+	assert.NoError(t, err)
 
-	err := repo.Save(product)
+	result, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName: aws.String("ProductsTable"),
+		Key:       map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}},
+	})
 	assert.NoError(t, err)
+	assert.Equal(t, &types.AttributeValueMemberS{Value: "Product 1"}, result.Item["name"])
+	assert.Equal(t, &types.AttributeValueMemberN{Value: "100"}, result.Item["price"])
 }
 
 func TestSaveProductErrorWhenProductIsNil(t *testing.T) {
@@ -38,7 +56,7 @@ func TestSaveProductErrorWhenProductIsNil(t *testing.T) {
 	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
 	repo := NewDynamoDbProductSaveRepository(mockDB, "ProductsTable")
 
-	err := repo.Save(nil)
+	err := repo.Save(context.Background(), nil)
 	assert.Error(t, err)
 }
 
@@ -55,31 +73,28 @@ func TestSaveProductErrorWhenMarshalMapFails(t *testing.T) {
 		Price: 100,
 	}
 
-	mockDB.EXPECT().PutItem(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+	mockDB.EXPECT().UpdateItem(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
-	err := repo.Save(product)
+	err := repo.Save(context.Background(), product)
 	assert.Error(t, err)
 }
 
 func TestFindProduct(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
-	repo := NewDynamoDbProductFindRepository(mockDB, "ProductsTable")
+	client := newFakeDynamoDB(t)
+	repo := NewDynamoDbProductFindRepository(client, "ProductsTable")
 
 	productID := "1"
-	mockOutput := &dynamodb.GetItemOutput{
+	_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName: aws.String("ProductsTable"),
 		Item: map[string]types.AttributeValue{
 			"id":    &types.AttributeValueMemberS{Value: productID},
 			"name":  &types.AttributeValueMemberS{Value: "Product 1"},
 			"price": &types.AttributeValueMemberN{Value: "100"},
 		},
-	}
-
-	mockDB.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(mockOutput, nil)
+	})
+	assert.NoError(t, err)
 
-	product, err := repo.Find(domain.ProductID(productID))
+	product, err := repo.Find(context.Background(), domain.ProductID(productID))
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
 	assert.Equal(t, domain.ProductID(productID), product.ID)
@@ -98,54 +113,65 @@ func TestFindProductErrorWhenDynamoDBGetItemFails(t *testing.T) {
 
 	mockDB.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
-	product, err := repo.Find(domain.ProductID(productID))
+	product, err := repo.Find(context.Background(), domain.ProductID(productID))
 	assert.Error(t, err)
 	assert.Nil(t, product)
 }
 
 func TestFindProductErrorWhenProductNotFound(t *testing.T) {
-	ctrl := gomock.NewController(t)
-	defer ctrl.Finish()
-
-	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
-	repo := NewDynamoDbProductFindRepository(mockDB, "ProductsTable")
-
-	productID := "1"
-
-	mockOutput := &dynamodb.GetItemOutput{}
-
-	mockDB.EXPECT().GetItem(gomock.Any(), gomock.Any()).Return(mockOutput, nil)
+	repo := NewDynamoDbProductFindRepository(newFakeDynamoDB(t), "ProductsTable")
 
-	product, err := repo.Find(domain.ProductID(productID))
-	assert.Error(t, err)
+	product, err := repo.Find(context.Background(), domain.ProductID("1"))
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
 	assert.Nil(t, product)
 }
 
 func TestFindAllProducts(t *testing.T) {
+	client := newFakeDynamoDB(t, fake.WithMaxPageSize(2))
+	repo := NewDynamoDbProductFindAllRepository(client, "ProductsTable")
+
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+			TableName: aws.String("ProductsTable"),
+			Item: map[string]types.AttributeValue{
+				"id":    &types.AttributeValueMemberS{Value: id},
+				"name":  &types.AttributeValueMemberS{Value: "Product " + id},
+				"price": &types.AttributeValueMemberN{Value: "100"},
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	products, err := repo.FindAll(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, products, 5)
+}
+
+func TestFindAllProductsFollowsLastEvaluatedKey(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
 	repo := NewDynamoDbProductFindAllRepository(mockDB, "ProductsTable")
 
-	mockOutput := &dynamodb.ScanOutput{
-		Items: []map[string]types.AttributeValue{
-			{
-				"id":    &types.AttributeValueMemberS{Value: "1"},
-				"name":  &types.AttributeValueMemberS{Value: "Product 1"},
-				"price": &types.AttributeValueMemberN{Value: "100"},
-			},
-			{
-				"id":    &types.AttributeValueMemberS{Value: "2"},
-				"name":  &types.AttributeValueMemberS{Value: "Product 2"},
-				"price": &types.AttributeValueMemberN{Value: "200"},
-			},
-		},
-	}
-
-	mockDB.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(mockOutput, nil)
-
-	products, err := repo.FindAll()
+	lastKey := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: "1"}}
+	gomock.InOrder(
+		mockDB.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			assert.Nil(t, input.ExclusiveStartKey)
+			return &dynamodb.ScanOutput{
+				Items:            []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "1"}}},
+				LastEvaluatedKey: lastKey,
+			}, nil
+		}),
+		mockDB.EXPECT().Scan(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+			assert.Equal(t, lastKey, input.ExclusiveStartKey)
+			return &dynamodb.ScanOutput{
+				Items: []map[string]types.AttributeValue{{"id": &types.AttributeValueMemberS{Value: "2"}}},
+			}, nil
+		}),
+	)
+
+	products, err := repo.FindAll(context.Background())
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
 }
@@ -159,22 +185,37 @@ func TestFindAllProductsErrorWhenDynamoDBScanFails(t *testing.T) {
 
 	mockDB.EXPECT().Scan(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
-	products, err := repo.FindAll()
+	products, err := repo.FindAll(context.Background())
 	assert.Error(t, err)
 	assert.Nil(t, products)
 }
 
 func TestDeleteProduct(t *testing.T) {
+	client := newFakeDynamoDB(t)
+	repo := NewDynamoDbProductDeleteRepository(client, "ProductsTable")
+
+	productID := "1"
+	key := map[string]types.AttributeValue{"id": &types.AttributeValueMemberS{Value: productID}}
+	_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String("ProductsTable"), Item: key})
+	assert.NoError(t, err)
+
+	err = repo.Delete(context.Background(), domain.ProductID(productID))
+	assert.NoError(t, err)
+
+	result, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{TableName: aws.String("ProductsTable"), Key: key})
+	assert.NoError(t, err)
+	assert.Nil(t, result.Item)
+}
+
+func TestDeleteProductErrorWhenDynamoDBDeleteItemFails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockDB := mocks.NewMockDynamoDBAPI(ctrl)
 	repo := NewDynamoDbProductDeleteRepository(mockDB, "ProductsTable")
 
-	productID := "1"
-
-	mockDB.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(&dynamodb.DeleteItemOutput{}, nil)
+	mockDB.EXPECT().DeleteItem(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
 
-	err := repo.Delete(domain.ProductID(productID))
-	assert.NoError(t, err)
+	err := repo.Delete(context.Background(), domain.ProductID("1"))
+	assert.ErrorIs(t, err, assert.AnError)
 }
@@ -0,0 +1,36 @@
+package adapter
+
+import (
+	"errors"
+	"net"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// transientCodes are the DynamoDB errors that a later attempt may not get:
+// throttling and failures on the service side.
+var transientCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+	"InternalServerError":                    true,
+	"ServiceUnavailable":                     true,
+}
+
+// IsTransient reports whether err, returned by the DynamoDB client, is
+// worth retrying: throttling, a server fault or a request that could not be
+// sent or timed out on the network.
+func IsTransient(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return transientCodes[apiErr.ErrorCode()] || apiErr.ErrorFault() == smithy.FaultServer
+	}
+
+	var sendErr *smithyhttp.RequestSendError
+	if errors.As(err, &sendErr) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,36 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/smithy-go"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Throughput exceeded", err: &smithy.GenericAPIError{Code: "ProvisionedThroughputExceededException", Fault: smithy.FaultClient}, expected: true},
+		{name: "Wrapped throttling", err: fmt.Errorf("operation error DynamoDB: GetItem: %w", &smithy.GenericAPIError{Code: "ThrottlingException"}), expected: true},
+		{name: "Server fault", err: &smithy.GenericAPIError{Code: "InternalFailure", Fault: smithy.FaultServer}, expected: true},
+		{name: "Request not sent", err: &smithyhttp.RequestSendError{Err: errors.New("connection refused")}, expected: true},
+		{name: "Validation", err: &smithy.GenericAPIError{Code: "ValidationException", Fault: smithy.FaultClient}},
+		{name: "Missing table", err: &smithy.GenericAPIError{Code: "ResourceNotFoundException", Fault: smithy.FaultClient}},
+		{name: "Not found", err: domain.ErrNotFoundProduct},
+		{name: "Nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTransient(tt.err))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package adapter
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const (
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+var ErrUnsupportedDriver = errors.New("unsupported database driver")
+
+// Dialector returns the gorm dialector that opens dsn with driver.
+func Dialector(driver, dsn string) (gorm.Dialector, error) {
+	switch driver {
+	case DriverSQLite:
+		return sqlite.Open(dsn), nil
+	case DriverPostgres:
+		return postgres.Open(dsn), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDriver, driver)
+	}
+}
+
+// OpenDatabase connects to dsn with driver. The schema is managed by the
+// migrations package.
+func OpenDatabase(driver, dsn string, opts ...gorm.Option) (*gorm.DB, error) {
+	dialector, err := Dialector(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	return gorm.Open(dialector, opts...)
+}
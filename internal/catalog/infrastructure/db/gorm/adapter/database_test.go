@@ -0,0 +1,45 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestDialector(t *testing.T) {
+	tests := []struct {
+		driver  string
+		want    string
+		wantErr error
+	}{
+		{driver: DriverSQLite, want: "sqlite"},
+		{driver: DriverPostgres, want: "postgres"},
+		{driver: "mysql", wantErr: ErrUnsupportedDriver},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			dialector, err := Dialector(tt.driver, "dsn")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, dialector)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, dialector.Name())
+		})
+	}
+}
+
+func TestOpenDatabase(t *testing.T) {
+	db, err := OpenDatabase(DriverSQLite, "file::memory:", &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	assert.NoError(t, err)
+	defer CloseDatabase(db)
+
+	assert.NoError(t, db.Exec("SELECT 1").Error)
+
+	_, err = OpenDatabase("mysql", "dsn")
+	assert.ErrorIs(t, err, ErrUnsupportedDriver)
+}
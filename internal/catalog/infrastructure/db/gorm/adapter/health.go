@@ -0,0 +1,21 @@
+package adapter
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// NewDatabaseHealthCheck pings the connection pool behind db and reports
+// under the dialect name, such as sqlite or postgres.
+func NewDatabaseHealthCheck(db *gorm.DB) pkgapplication.HealthCheck {
+	return pkgapplication.NewHealthCheck(db.Dialector.Name(), func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	})
+}
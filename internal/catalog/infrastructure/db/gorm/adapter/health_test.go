@@ -0,0 +1,23 @@
+package adapter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestNewDatabaseHealthCheck(t *testing.T) {
+	db, err := OpenDatabase(DriverSQLite, "file::memory:", &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+
+	check := NewDatabaseHealthCheck(db)
+	assert.Equal(t, "sqlite", check.Name())
+	assert.NoError(t, check.Check(context.Background()))
+
+	require.NoError(t, CloseDatabase(db))
+	assert.Error(t, check.Check(context.Background()))
+}
@@ -7,12 +7,12 @@ import (
 )
 
 type GormProductEntity struct {
-	ID          string    `gorm:"primaryKey;type:text;default:(lower(hex(randomblob(16))))" json:"id"`
-	Name        string    `gorm:"type:varchar(100);not null" json:"name"`
-	Description string    `gorm:"type:text;not null" json:"description"`
+	ID          string    `gorm:"primaryKey;size:255" json:"id"`
+	Name        string    `gorm:"size:100;not null" json:"name"`
+	Description string    `gorm:"not null" json:"description"`
 	Price       float64   `gorm:"not null" json:"price"`
-	CreatedAt   time.Time `gorm:"type:timestamp;not null" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"type:timestamp;not null" json:"updated_at"`
+	CreatedAt   time.Time `gorm:"not null" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"not null" json:"updated_at"`
 }
 
 func (pe *GormProductEntity) ToDomain() (*domain.Product, error) {
@@ -27,7 +27,7 @@ func (pe *GormProductEntity) ToDomain() (*domain.Product, error) {
 }
 
 func NewProductEntityFromDomain(product *domain.Product) (*GormProductEntity, error) {
-	if product == nil {
+	if product == nil || product.ID == "" {
 		return nil, domain.ErrInvalidProductID
 	}
 	return &GormProductEntity{
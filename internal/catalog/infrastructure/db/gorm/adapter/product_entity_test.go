@@ -89,6 +89,12 @@ func TestNewProductEntityFromDomain(t *testing.T) {
 			want:    nil,
 			wantErr: domain.ErrInvalidProductID,
 		},
+		{
+			name:    "Empty Product ID",
+			product: &domain.Product{Name: "Test Product", Description: "This is a test product", Price: 9.99},
+			want:    nil,
+			wantErr: domain.ErrInvalidProductID,
+		},
 	}
 
 	for _, tt := range tests {
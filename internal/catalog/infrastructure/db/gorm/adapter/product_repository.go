@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"context"
 	"errors"
 
 	"gorm.io/gorm"
@@ -19,12 +20,12 @@ func NewGormProductSaveRepository(db *gorm.DB) domain.ProductSaveRepository {
 	}
 }
 
-func (repo *gormProductSaveRepository) Save(product *domain.Product) error {
+func (repo *gormProductSaveRepository) Save(ctx context.Context, product *domain.Product) error {
 	entity, err := NewProductEntityFromDomain(product)
 	if err != nil {
 		return err
 	}
-	result := repo.db.Clauses(clause.OnConflict{
+	result := repo.db.WithContext(ctx).Clauses(clause.OnConflict{
 		Columns:   []clause.Column{{Name: "id"}},
 		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "price", "updated_at"}),
 	}).Create(entity)
@@ -42,9 +43,9 @@ func NewGormProductFindRepository(db *gorm.DB) domain.ProductFindRepository {
 	}
 }
 
-func (repo *gormProductFindRepository) Find(id domain.ProductID) (*domain.Product, error) {
+func (repo *gormProductFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
 	var entity GormProductEntity
-	err := repo.db.First(&entity, "id = ?", id).Error
+	err := repo.db.WithContext(ctx).First(&entity, "id = ?", id).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, domain.ErrNotFoundProduct
@@ -64,9 +65,9 @@ func NewGormProductFindAllRepository(db *gorm.DB) domain.ProductFindAllRepositor
 	}
 }
 
-func (repo *gormProductFindAllRepository) FindAll() ([]*domain.Product, error) {
+func (repo *gormProductFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
 	var entities []GormProductEntity
-	err := repo.db.Find(&entities).Error
+	err := repo.db.WithContext(ctx).Find(&entities).Error
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +89,6 @@ func NewGormProductDeleteRepository(db *gorm.DB) domain.ProductDeleteRepository
 	}
 }
 
-func (repo *gormProductDeleteRepository) Delete(id domain.ProductID) error {
-	return repo.db.Delete(&GormProductEntity{}, "id = ?", id).Error
+func (repo *gormProductDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	return repo.db.WithContext(ctx).Delete(&GormProductEntity{}, "id = ?", id).Error
 }
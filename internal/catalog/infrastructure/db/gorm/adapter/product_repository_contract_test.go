@@ -0,0 +1,67 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/contract"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/migrations"
+)
+
+func TestGormProductRepository_Contract(t *testing.T) {
+	contract.RunProductRepositoryTests(t, func(t *testing.T) contract.ProductRepositories {
+		// A named shared-cache database keeps every pooled connection on the same in-memory store.
+		dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()))
+		db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+		require.NoError(t, err)
+
+		sqlDB, err := db.DB()
+		require.NoError(t, err)
+		sqlDB.SetMaxOpenConns(1)
+		t.Cleanup(func() { sqlDB.Close() })
+
+		require.NoError(t, migrations.Ensure(context.Background(), db, DriverSQLite, true))
+
+		return contract.ProductRepositories{
+			Save:    NewGormProductSaveRepository(db),
+			Find:    NewGormProductFindRepository(db),
+			FindAll: NewGormProductFindAllRepository(db),
+			Delete:  NewGormProductDeleteRepository(db),
+		}
+	})
+}
+
+// PostgresDSNEnv points the Postgres contract run at a disposable database,
+// e.g. the one started by `make up-postgres`.
+const PostgresDSNEnv = "CATALOG_TEST_POSTGRES_DSN"
+
+func TestGormProductRepository_PostgresContract(t *testing.T) {
+	dsn := os.Getenv(PostgresDSNEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", PostgresDSNEnv)
+	}
+
+	db, err := OpenDatabase(DriverPostgres, dsn, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	t.Cleanup(func() { CloseDatabase(db) })
+	require.NoError(t, migrations.Ensure(context.Background(), db, DriverPostgres, true))
+
+	contract.RunProductRepositoryTests(t, func(t *testing.T) contract.ProductRepositories {
+		require.NoError(t, db.Exec("TRUNCATE TABLE gorm_product_entities").Error)
+
+		return contract.ProductRepositories{
+			Save:    NewGormProductSaveRepository(db),
+			Find:    NewGormProductFindRepository(db),
+			FindAll: NewGormProductFindAllRepository(db),
+			Delete:  NewGormProductDeleteRepository(db),
+		}
+	})
+}
@@ -2,28 +2,57 @@ package adapter
 
 import (
 	"gorm.io/gorm"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
 )
 
 func CreateProductSaveRepository(dependencies map[string]interface{}) (interface{}, error) {
-	dbConn := dependencies["dbConn"].(*gorm.DB)
+	dbConn, err := pkgapplication.Dependency[*gorm.DB](dependencies, "dbConn")
+	if err != nil {
+		return nil, err
+	}
 
 	return NewGormProductSaveRepository(dbConn), nil
 }
 
 func CreateProductFindRepository(dependencies map[string]interface{}) (interface{}, error) {
-	dbConn := dependencies["dbConn"].(*gorm.DB)
+	dbConn, err := pkgapplication.Dependency[*gorm.DB](dependencies, "dbConn")
+	if err != nil {
+		return nil, err
+	}
 
 	return NewGormProductFindRepository(dbConn), nil
 }
 
 func CreateProductFindAllRepository(dependencies map[string]interface{}) (interface{}, error) {
-	dbConn := dependencies["dbConn"].(*gorm.DB)
+	dbConn, err := pkgapplication.Dependency[*gorm.DB](dependencies, "dbConn")
+	if err != nil {
+		return nil, err
+	}
 
 	return NewGormProductFindAllRepository(dbConn), nil
 }
 
 func CreateProductDeleteRepository(dependencies map[string]interface{}) (interface{}, error) {
-	dbConn := dependencies["dbConn"].(*gorm.DB)
+	dbConn, err := pkgapplication.Dependency[*gorm.DB](dependencies, "dbConn")
+	if err != nil {
+		return nil, err
+	}
 
 	return NewGormProductDeleteRepository(dbConn), nil
 }
+
+// CloseDatabase closes the connection pool behind a *gorm.DB built by a
+// recipe. It is meant to be used as the recipe's Close hook.
+func CloseDatabase(instance interface{}) error {
+	dbConn, err := pkgapplication.As[*gorm.DB]("dbConn", instance)
+	if err != nil {
+		return err
+	}
+
+	sqlDB, err := dbConn.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
+}
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
 )
 
 // Test case structure
@@ -63,3 +65,26 @@ func TestCreateProductRepositories(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateProductRepositoriesWithoutDBConn(t *testing.T) {
+	for _, createRepoFn := range []func(map[string]interface{}) (interface{}, error){
+		CreateProductSaveRepository,
+		CreateProductFindRepository,
+		CreateProductFindAllRepository,
+		CreateProductDeleteRepository,
+	} {
+		repo, err := createRepoFn(map[string]interface{}{})
+		assert.ErrorIs(t, err, pkgapplication.ErrDependencyNotFound)
+		assert.Nil(t, repo)
+	}
+}
+
+func TestCloseDatabase(t *testing.T) {
+	dbConn, mock := setupTestDB(t)
+	mock.ExpectClose()
+
+	assert.NoError(t, CloseDatabase(dbConn))
+	assert.NoError(t, mock.ExpectationsWereMet())
+
+	assert.ErrorIs(t, CloseDatabase("not a database"), pkgapplication.ErrDependencyTypeMismatch)
+}
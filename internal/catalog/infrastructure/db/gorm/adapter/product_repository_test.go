@@ -1,6 +1,7 @@
 package adapter
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -48,12 +49,12 @@ func TestGormProductRepository_Save(t *testing.T) {
 	}
 
 	mock.ExpectBegin()
-	mock.ExpectQuery(`INSERT INTO "product_entities" (.+) VALUES (.+) RETURNING "id"`).
+	mock.ExpectExec(`INSERT INTO "product_entities" (.+) VALUES (.+) ON CONFLICT \("id"\) DO UPDATE`).
 		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
-		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(product.ID))
+		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := repo.Save(product)
+	err := repo.Save(context.Background(), product)
 	assert.NoError(t, err)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -63,7 +64,7 @@ func TestGormProductRepository_Save_Error_WhenProductIsNil(t *testing.T) {
 	gormDB, _ := setupTestDB(t)
 	repo := NewGormProductSaveRepository(gormDB)
 
-	err := repo.Save(nil)
+	err := repo.Save(context.Background(), nil)
 	assert.ErrorIs(t, err, domain.ErrInvalidProductID)
 }
 
@@ -82,7 +83,7 @@ func TestGormProductRepository_Find(t *testing.T) {
 		WithArgs(string(productID), 1).
 		WillReturnRows(rows)
 
-	product, err := repo.Find(productID)
+	product, err := repo.Find(context.Background(), productID)
 	assert.NoError(t, err)
 	assert.NotNil(t, product)
 	if product != nil {
@@ -107,7 +108,7 @@ func TestGormProductRepository_Find_NotFound(t *testing.T) {
 		WithArgs(string(productID), 1).
 		WillReturnError(gorm.ErrRecordNotFound)
 
-	product, err := repo.Find(productID)
+	product, err := repo.Find(context.Background(), productID)
 	assert.Nil(t, product)
 	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
 
@@ -124,7 +125,7 @@ func TestGormProductRepository_Find_Error_WhenGormError(t *testing.T) {
 		WithArgs(string(productID), 1).
 		WillReturnError(errors.New("unexpected error"))
 
-	product, err := repo.Find(productID)
+	product, err := repo.Find(context.Background(), productID)
 	assert.Nil(t, product)
 	assert.Error(t, err)
 
@@ -142,7 +143,7 @@ func TestGormProductRepository_FindAll(t *testing.T) {
 	mock.ExpectQuery("SELECT \\* FROM \"product_entities\"").
 		WillReturnRows(rows)
 
-	products, err := repo.FindAll()
+	products, err := repo.FindAll(context.Background())
 	assert.NoError(t, err)
 	assert.Len(t, products, 2)
 
@@ -156,7 +157,7 @@ func TestGormProductRepository_FindAll_Error_WhenGormError(t *testing.T) {
 	mock.ExpectQuery("SELECT \\* FROM \"product_entities\"").
 		WillReturnError(errors.New("unexpected error"))
 
-	products, err := repo.FindAll()
+	products, err := repo.FindAll(context.Background())
 	assert.Nil(t, products)
 	assert.Error(t, err)
 
@@ -175,7 +176,7 @@ func TestGormProductRepository_Delete(t *testing.T) {
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	mock.ExpectCommit()
 
-	err := repo.Delete(productID)
+	err := repo.Delete(context.Background(), productID)
 	assert.NoError(t, err)
 
 	assert.NoError(t, mock.ExpectationsWereMet())
@@ -0,0 +1,48 @@
+package adapter
+
+import (
+	"database/sql/driver"
+	"errors"
+	"net"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+)
+
+// transientStates are the PostgreSQL SQLSTATE codes of failures a later
+// attempt may not get: lost connections, serialization failures,
+// deadlocks, too many connections and servers shutting down.
+var transientStates = map[string]bool{
+	"40001": true,
+	"40P01": true,
+	"53300": true,
+	"57P01": true,
+	"57P02": true,
+	"57P03": true,
+}
+
+// IsTransient reports whether err, returned by gorm on SQLite or
+// PostgreSQL, is worth retrying.
+func IsTransient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) {
+		return true
+	}
+
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) {
+		return sqliteErr.Code == sqlite3.ErrBusy || sqliteErr.Code == sqlite3.ErrLocked
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Class 08 is connection exceptions.
+		return transientStates[pgErr.Code] || (len(pgErr.Code) == 5 && pgErr.Code[:2] == "08")
+	}
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
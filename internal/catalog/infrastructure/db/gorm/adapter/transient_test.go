@@ -0,0 +1,39 @@
+package adapter
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "Bad connection", err: fmt.Errorf("query: %w", driver.ErrBadConn), expected: true},
+		{name: "SQLite busy", err: sqlite3.Error{Code: sqlite3.ErrBusy}, expected: true},
+		{name: "SQLite locked", err: sqlite3.Error{Code: sqlite3.ErrLocked}, expected: true},
+		{name: "SQLite constraint", err: sqlite3.Error{Code: sqlite3.ErrConstraint}},
+		{name: "Postgres serialization failure", err: &pgconn.PgError{Code: "40001"}, expected: true},
+		{name: "Postgres connection failure", err: &pgconn.PgError{Code: "08006"}, expected: true},
+		{name: "Postgres unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "Record not found", err: gorm.ErrRecordNotFound},
+		{name: "Not found", err: domain.ErrNotFoundProduct},
+		{name: "Nil", err: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, IsTransient(tt.err))
+		})
+	}
+}
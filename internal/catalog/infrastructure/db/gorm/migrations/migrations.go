@@ -0,0 +1,73 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/db/migrate"
+)
+
+//go:embed sqlite/*.sql postgres/*.sql
+var files embed.FS
+
+// Dir is the directory of this package relative to the repository root,
+// where new migrations are created.
+const Dir = "internal/catalog/infrastructure/db/gorm/migrations"
+
+// Dialects lists the subdirectories every migration is written for.
+var Dialects = []string{migrate.DialectSQLite, migrate.DialectPostgres}
+
+// Load returns the catalog migrations written for driver.
+func Load(driver string) ([]migrate.Migration, error) {
+	if !slices.Contains(Dialects, driver) {
+		return nil, fmt.Errorf("%w: %q", migrate.ErrUnsupportedDialect, driver)
+	}
+	return migrate.Load(files, driver)
+}
+
+// Create writes an empty migration named name for every dialect under dir.
+func Create(dir, name string) ([]string, error) {
+	dirs := make([]string, 0, len(Dialects))
+	for _, dialect := range Dialects {
+		dirs = append(dirs, filepath.Join(dir, dialect))
+	}
+	return migrate.Create(name, dirs...)
+}
+
+// NewMigrator returns a Migrator for the catalog schema behind dbConn.
+func NewMigrator(dbConn *gorm.DB, driver string, opts ...migrate.Option) (*migrate.Migrator, error) {
+	migrations, err := Load(driver)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := dbConn.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(sqlDB, driver, migrations, opts...)
+}
+
+// Ensure applies pending migrations when apply is set. Otherwise it returns
+// an error wrapping migrate.ErrSchemaOutdated when any migration is pending,
+// so a server never runs against a schema it does not expect.
+func Ensure(ctx context.Context, dbConn *gorm.DB, driver string, apply bool) error {
+	migrator, err := NewMigrator(dbConn, driver)
+	if err != nil {
+		return err
+	}
+
+	if apply {
+		_, err = migrator.Up(ctx, 0)
+		return err
+	}
+	if err := migrator.Check(ctx); err != nil {
+		return fmt.Errorf("%w; run the migrate up command or start with -migrate", err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package migrations
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/db/migrate"
+)
+
+func TestDialectsShareVersions(t *testing.T) {
+	var reference []migrate.Migration
+	for _, dialect := range Dialects {
+		migrations, err := Load(dialect)
+		require.NoError(t, err)
+		require.NotEmpty(t, migrations)
+
+		if reference == nil {
+			reference = migrations
+			continue
+		}
+		require.Len(t, migrations, len(reference), dialect)
+		for i := range migrations {
+			assert.Equal(t, reference[i].Version, migrations[i].Version, dialect)
+			assert.Equal(t, reference[i].Name, migrations[i].Name, dialect)
+		}
+	}
+
+	_, err := Load("mysql")
+	assert.ErrorIs(t, err, migrate.ErrUnsupportedDialect)
+}
+
+func TestEnsure(t *testing.T) {
+	ctx := context.Background()
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	defer sqlDB.Close()
+
+	err = Ensure(ctx, db, migrate.DialectSQLite, false)
+	assert.ErrorIs(t, err, migrate.ErrSchemaOutdated)
+	assert.ErrorContains(t, err, "0001_create_products")
+	assert.False(t, db.Migrator().HasTable("gorm_product_entities"))
+
+	assert.NoError(t, Ensure(ctx, db, migrate.DialectSQLite, true))
+	assert.True(t, db.Migrator().HasTable("gorm_product_entities"))
+	assert.NoError(t, Ensure(ctx, db, migrate.DialectSQLite, false))
+
+	migrator, err := NewMigrator(db, migrate.DialectSQLite)
+	require.NoError(t, err)
+	_, err = migrator.Down(ctx, 1)
+	assert.NoError(t, err)
+	assert.False(t, db.Migrator().HasTable("gorm_product_entities"))
+}
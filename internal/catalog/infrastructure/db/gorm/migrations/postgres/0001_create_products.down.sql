@@ -0,0 +1 @@
+DROP TABLE gorm_product_entities;
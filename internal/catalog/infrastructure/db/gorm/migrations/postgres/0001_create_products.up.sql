@@ -0,0 +1,10 @@
+-- IF NOT EXISTS adopts databases created by the former AutoMigrate boot.
+CREATE TABLE IF NOT EXISTS gorm_product_entities (
+    id varchar(255) NOT NULL,
+    name varchar(100) NOT NULL,
+    description text NOT NULL,
+    price double precision NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    PRIMARY KEY (id)
+);
@@ -0,0 +1,10 @@
+-- IF NOT EXISTS adopts databases created by the former AutoMigrate boot.
+CREATE TABLE IF NOT EXISTS gorm_product_entities (
+    id text NOT NULL,
+    name text NOT NULL,
+    description text NOT NULL,
+    price real NOT NULL,
+    created_at datetime NOT NULL,
+    updated_at datetime NOT NULL,
+    PRIMARY KEY (id)
+);
@@ -0,0 +1,21 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// NewStoreHealthCheck reports the store down when the directory of its
+// snapshot file has gone away, since later writes would fail.
+func NewStoreHealthCheck(store *ProductStore) pkgapplication.HealthCheck {
+	return pkgapplication.NewHealthCheck("memory", func(context.Context) error {
+		if store.snapshotFile == "" {
+			return nil
+		}
+		_, err := os.Stat(filepath.Dir(store.snapshotFile))
+		return err
+	})
+}
@@ -0,0 +1,29 @@
+package adapter
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStoreHealthCheck(t *testing.T) {
+	store, err := NewProductStore()
+	require.NoError(t, err)
+	check := NewStoreHealthCheck(store)
+	assert.Equal(t, "memory", check.Name())
+	assert.NoError(t, check.Check(context.Background()))
+
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	require.NoError(t, os.Mkdir(dir, 0o755))
+	store, err = NewProductStore(WithSnapshotFile(filepath.Join(dir, "products.json")))
+	require.NoError(t, err)
+	check = NewStoreHealthCheck(store)
+	assert.NoError(t, check.Check(context.Background()))
+
+	require.NoError(t, os.Remove(dir))
+	assert.Error(t, check.Check(context.Background()))
+}
@@ -0,0 +1,63 @@
+package adapter
+
+import (
+	"context"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+type memoryProductSaveRepository struct {
+	store *ProductStore
+}
+
+func NewMemoryProductSaveRepository(store *ProductStore) domain.ProductSaveRepository {
+	return &memoryProductSaveRepository{
+		store: store,
+	}
+}
+
+func (repo *memoryProductSaveRepository) Save(ctx context.Context, product *domain.Product) error {
+	return repo.store.Save(product)
+}
+
+type memoryProductFindRepository struct {
+	store *ProductStore
+}
+
+func NewMemoryProductFindRepository(store *ProductStore) domain.ProductFindRepository {
+	return &memoryProductFindRepository{
+		store: store,
+	}
+}
+
+func (repo *memoryProductFindRepository) Find(ctx context.Context, id domain.ProductID) (*domain.Product, error) {
+	return repo.store.Find(id)
+}
+
+type memoryProductFindAllRepository struct {
+	store *ProductStore
+}
+
+func NewMemoryProductFindAllRepository(store *ProductStore) domain.ProductFindAllRepository {
+	return &memoryProductFindAllRepository{
+		store: store,
+	}
+}
+
+func (repo *memoryProductFindAllRepository) FindAll(ctx context.Context) ([]*domain.Product, error) {
+	return repo.store.FindAll()
+}
+
+type memoryProductDeleteRepository struct {
+	store *ProductStore
+}
+
+func NewMemoryProductDeleteRepository(store *ProductStore) domain.ProductDeleteRepository {
+	return &memoryProductDeleteRepository{
+		store: store,
+	}
+}
+
+func (repo *memoryProductDeleteRepository) Delete(ctx context.Context, id domain.ProductID) error {
+	return repo.store.Delete(id)
+}
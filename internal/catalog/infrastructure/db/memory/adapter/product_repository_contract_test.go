@@ -0,0 +1,19 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/contract"
+)
+
+func TestMemoryProductRepository_Contract(t *testing.T) {
+	contract.RunProductRepositoryTests(t, func(t *testing.T) contract.ProductRepositories {
+		store := newTestStore(t)
+		return contract.ProductRepositories{
+			Save:    NewMemoryProductSaveRepository(store),
+			Find:    NewMemoryProductFindRepository(store),
+			FindAll: NewMemoryProductFindAllRepository(store),
+			Delete:  NewMemoryProductDeleteRepository(store),
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package adapter
+
+import (
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+func CreateProductSaveRepository(dependencies map[string]interface{}) (interface{}, error) {
+	store, err := pkgapplication.Dependency[*ProductStore](dependencies, "productStore")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMemoryProductSaveRepository(store), nil
+}
+
+func CreateProductFindRepository(dependencies map[string]interface{}) (interface{}, error) {
+	store, err := pkgapplication.Dependency[*ProductStore](dependencies, "productStore")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMemoryProductFindRepository(store), nil
+}
+
+func CreateProductFindAllRepository(dependencies map[string]interface{}) (interface{}, error) {
+	store, err := pkgapplication.Dependency[*ProductStore](dependencies, "productStore")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMemoryProductFindAllRepository(store), nil
+}
+
+func CreateProductDeleteRepository(dependencies map[string]interface{}) (interface{}, error) {
+	store, err := pkgapplication.Dependency[*ProductStore](dependencies, "productStore")
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMemoryProductDeleteRepository(store), nil
+}
@@ -0,0 +1,61 @@
+package adapter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateProductRepositories(t *testing.T) {
+	store, err := NewProductStore()
+	assert.NoError(t, err)
+
+	recipes := map[string]func(map[string]interface{}) (interface{}, error){
+		"CreateProductSaveRepository":    CreateProductSaveRepository,
+		"CreateProductFindRepository":    CreateProductFindRepository,
+		"CreateProductFindAllRepository": CreateProductFindAllRepository,
+		"CreateProductDeleteRepository":  CreateProductDeleteRepository,
+	}
+
+	tests := []struct {
+		name         string
+		dependencies map[string]interface{}
+		expectedErr  string
+	}{
+		{
+			name:         "Successful creation",
+			dependencies: map[string]interface{}{"productStore": store},
+		},
+		{
+			name:         "Missing productStore",
+			dependencies: map[string]interface{}{},
+			expectedErr:  "dependency not found: productStore",
+		},
+		{
+			name:         "Nil productStore",
+			dependencies: map[string]interface{}{"productStore": (*ProductStore)(nil)},
+			expectedErr:  "nil dependency: productStore",
+		},
+		{
+			name:         "Invalid productStore",
+			dependencies: map[string]interface{}{"productStore": "store"},
+			expectedErr:  "dependency type mismatch: productStore is string, expected *adapter.ProductStore",
+		},
+	}
+
+	for recipeName, recipe := range recipes {
+		for _, tt := range tests {
+			t.Run(recipeName+"/"+tt.name, func(t *testing.T) {
+				repo, err := recipe(tt.dependencies)
+
+				if tt.expectedErr != "" {
+					assert.EqualError(t, err, tt.expectedErr)
+					assert.Nil(t, repo)
+				} else {
+					assert.NoError(t, err)
+					assert.NotNil(t, repo)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package adapter
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+func newTestProduct(id string, createdAt time.Time) *domain.Product {
+	return &domain.Product{
+		ID:          domain.ProductID(id),
+		Name:        "Product " + id,
+		Description: "Description " + id,
+		Price:       9.99,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}
+}
+
+func newTestStore(t *testing.T, opts ...ProductStoreOption) *ProductStore {
+	store, err := NewProductStore(opts...)
+	assert.NoError(t, err)
+	return store
+}
+
+func TestMemoryProductRepository_SaveAndFind(t *testing.T) {
+	store := newTestStore(t)
+	saveRepo := NewMemoryProductSaveRepository(store)
+	findRepo := NewMemoryProductFindRepository(store)
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	product := newTestProduct("1", createdAt)
+	assert.NoError(t, saveRepo.Save(context.Background(), product))
+
+	// Mutating the caller's copy must not leak into the store.
+	product.Name = "Changed outside"
+	found, err := findRepo.Find(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Product 1", found.Name)
+
+	found.Name = "Changed after find"
+	found, err = findRepo.Find(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Product 1", found.Name)
+
+	updated := newTestProduct("1", createdAt.Add(time.Hour))
+	updated.Name = "Updated"
+	assert.NoError(t, saveRepo.Save(context.Background(), updated))
+
+	found, err = findRepo.Find(context.Background(), "1")
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated", found.Name)
+	assert.Equal(t, createdAt, found.CreatedAt)
+}
+
+func TestMemoryProductRepository_Save_Error_WhenProductIsNil(t *testing.T) {
+	repo := NewMemoryProductSaveRepository(newTestStore(t))
+
+	err := repo.Save(context.Background(), nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidProductID)
+}
+
+func TestMemoryProductRepository_Find_NotFound(t *testing.T) {
+	repo := NewMemoryProductFindRepository(newTestStore(t))
+
+	product, err := repo.Find(context.Background(), "missing")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+	assert.Nil(t, product)
+}
+
+func TestMemoryProductRepository_FindAll(t *testing.T) {
+	store := newTestStore(t)
+	saveRepo := NewMemoryProductSaveRepository(store)
+	findAllRepo := NewMemoryProductFindAllRepository(store)
+
+	products, err := findAllRepo.FindAll(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, products)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, saveRepo.Save(context.Background(), newTestProduct("c", base.Add(time.Minute))))
+	assert.NoError(t, saveRepo.Save(context.Background(), newTestProduct("b", base)))
+	assert.NoError(t, saveRepo.Save(context.Background(), newTestProduct("a", base)))
+
+	products, err = findAllRepo.FindAll(context.Background())
+	assert.NoError(t, err)
+	ids := make([]domain.ProductID, 0, len(products))
+	for _, product := range products {
+		ids = append(ids, product.ID)
+	}
+	assert.Equal(t, []domain.ProductID{"a", "b", "c"}, ids)
+}
+
+func TestMemoryProductRepository_Delete(t *testing.T) {
+	store := newTestStore(t)
+	assert.NoError(t, store.Save(newTestProduct("1", time.Now())))
+
+	repo := NewMemoryProductDeleteRepository(store)
+	assert.NoError(t, repo.Delete(context.Background(), "1"))
+	assert.NoError(t, repo.Delete(context.Background(), "1"))
+
+	_, err := store.Find("1")
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+}
+
+func TestProductStore_ConcurrentAccess(t *testing.T) {
+	store := newTestStore(t)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id := fmt.Sprintf("%02d", i)
+			assert.NoError(t, store.Save(newTestProduct(id, time.Now())))
+			_, err := store.Find(domain.ProductID(id))
+			assert.NoError(t, err)
+			_, err = store.FindAll()
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	products, err := store.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, products, 50)
+}
+
+func TestProductStore_SnapshotAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.json")
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := newTestStore(t)
+	assert.NoError(t, store.Save(newTestProduct("1", createdAt)))
+	assert.NoError(t, store.Save(newTestProduct("2", createdAt)))
+	assert.NoError(t, store.Snapshot(path))
+
+	loaded := newTestStore(t)
+	assert.NoError(t, loaded.Save(newTestProduct("stale", createdAt)))
+	assert.NoError(t, loaded.Load(path))
+
+	products, err := loaded.FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Product{newTestProduct("1", createdAt), newTestProduct("2", createdAt)}, products)
+
+	assert.Error(t, loaded.Load(filepath.Join(t.TempDir(), "missing.json")))
+}
+
+func TestProductStore_WithSnapshotFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "products.json")
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := newTestStore(t, WithSnapshotFile(path))
+	assert.NoError(t, store.Save(newTestProduct("1", createdAt)))
+	assert.NoError(t, store.Save(newTestProduct("2", createdAt)))
+	assert.NoError(t, store.Delete("1"))
+
+	reopened := newTestStore(t, WithSnapshotFile(path))
+	products, err := reopened.FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Product{newTestProduct("2", createdAt)}, products)
+}
+
+func TestProductStore_KeepsChangesOutWhenTheSnapshotFails(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+	assert.NoError(t, os.Mkdir(dir, 0o700))
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store := newTestStore(t, WithSnapshotFile(filepath.Join(dir, "products.json")))
+	original := newTestProduct("1", createdAt)
+	assert.NoError(t, store.Save(original))
+	assert.NoError(t, os.RemoveAll(dir))
+
+	assert.Error(t, store.Save(newTestProduct("2", createdAt)))
+	updated := newTestProduct("1", createdAt)
+	updated.Name = "Updated"
+	assert.Error(t, store.Save(updated))
+	assert.Error(t, store.Delete("1"))
+
+	products, err := store.FindAll()
+	assert.NoError(t, err)
+	assert.Equal(t, []*domain.Product{original}, products)
+}
+
+func TestNewProductStore_Errors(t *testing.T) {
+	_, err := NewProductStore(WithSnapshotFile(""))
+	assert.EqualError(t, err, "snapshot file must not be empty")
+
+	path := filepath.Join(t.TempDir(), "products.json")
+	assert.NoError(t, os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = NewProductStore(WithSnapshotFile(path))
+	assert.ErrorContains(t, err, "invalid product snapshot")
+}
@@ -0,0 +1,189 @@
+package adapter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+)
+
+type ProductStore struct {
+	mu           sync.RWMutex
+	products     map[domain.ProductID]domain.Product
+	snapshotFile string
+}
+
+type ProductStoreOption func(*ProductStore) error
+
+// WithSnapshotFile loads the store from path when it exists and writes it back after every change.
+func WithSnapshotFile(path string) ProductStoreOption {
+	return func(s *ProductStore) error {
+		if path == "" {
+			return fmt.Errorf("snapshot file must not be empty")
+		}
+		s.snapshotFile = path
+		return nil
+	}
+}
+
+func NewProductStore(opts ...ProductStoreOption) (*ProductStore, error) {
+	s := &ProductStore{products: make(map[domain.ProductID]domain.Product)}
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.snapshotFile != "" {
+		err := s.Load(s.snapshotFile)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (s *ProductStore) Save(product *domain.Product) error {
+	if product == nil || product.ID == "" {
+		return domain.ErrInvalidProductID
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, exists := s.products[product.ID]
+	stored := *product
+	if exists {
+		stored.CreatedAt = previous.CreatedAt
+	}
+	s.products[product.ID] = stored
+
+	if err := s.persist(); err != nil {
+		s.restore(product.ID, previous, exists)
+		return err
+	}
+	return nil
+}
+
+func (s *ProductStore) Find(id domain.ProductID) (*domain.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	product, ok := s.products[id]
+	if !ok {
+		return nil, domain.ErrNotFoundProduct
+	}
+	return &product, nil
+}
+
+func (s *ProductStore) FindAll() ([]*domain.Product, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.sorted(), nil
+}
+
+func (s *ProductStore) Delete(id domain.ProductID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, exists := s.products[id]
+	delete(s.products, id)
+
+	if err := s.persist(); err != nil {
+		s.restore(id, previous, exists)
+		return err
+	}
+	return nil
+}
+
+func (s *ProductStore) Snapshot(path string) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.write(path)
+}
+
+func (s *ProductStore) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var products []domain.Product
+	if err := json.Unmarshal(data, &products); err != nil {
+		return fmt.Errorf("invalid product snapshot %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.products = make(map[domain.ProductID]domain.Product, len(products))
+	for _, product := range products {
+		s.products[product.ID] = product
+	}
+	return nil
+}
+
+// Products are ordered by creation time, then by ID, so listings are stable across runs.
+func (s *ProductStore) sorted() []*domain.Product {
+	products := make([]*domain.Product, 0, len(s.products))
+	for _, product := range s.products {
+		product := product
+		products = append(products, &product)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		if !products[i].CreatedAt.Equal(products[j].CreatedAt) {
+			return products[i].CreatedAt.Before(products[j].CreatedAt)
+		}
+		return products[i].ID < products[j].ID
+	})
+	return products
+}
+
+// restore undoes a change whose snapshot could not be written, so the store
+// never holds a product the snapshot does not.
+func (s *ProductStore) restore(id domain.ProductID, previous domain.Product, existed bool) {
+	if existed {
+		s.products[id] = previous
+	} else {
+		delete(s.products, id)
+	}
+}
+
+func (s *ProductStore) persist() error {
+	if s.snapshotFile == "" {
+		return nil
+	}
+	return s.write(s.snapshotFile)
+}
+
+func (s *ProductStore) write(path string) error {
+	data, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a sibling file first so a crash never leaves a truncated snapshot behind.
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -4,15 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
 	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
 )
 
 type AddProductRequest struct {
@@ -33,73 +30,57 @@ type AddProductResponse struct {
 
 type LambdaAddProductAdapter struct {
 	service application.AddProductUseCase
-	db      *dynamodb.DynamoDB
-	table   string
 }
 
 func NewLambdaAddProductAdapter(service application.AddProductUseCase) *LambdaAddProductAdapter {
-	sess := session.Must(session.NewSession())
-	db := dynamodb.New(sess)
-
 	return &LambdaAddProductAdapter{
 		service: service,
-		db:      db,
-		table:   os.Getenv("PRODUCTS_TABLE"),
 	}
 }
 
 func (a *LambdaAddProductAdapter) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	if request.HTTPMethod != http.MethodPost {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusMethodNotAllowed,
-			Body:       `{"error": "` + httpadapter.ErrHttpMethodNotAllowed.Error() + `"}`,
-		}, nil
+	return pkglambda.NewAPIGatewayProxyHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaAddProductAdapter) HandleV2(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	return pkglambda.NewAPIGatewayV2HTTPHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaAddProductAdapter) HandleALB(ctx context.Context, request events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	return pkglambda.NewALBTargetGroupHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaAddProductAdapter) Serve(ctx context.Context, request pkglambda.Request) (pkglambda.Response, error) {
+	if request.Method != http.MethodPost {
+		return errorResponse(http.StatusMethodNotAllowed, httpadapter.ErrHttpMethodNotAllowed), nil
+	}
+
+	body, err := request.DecodedBody()
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, httpadapter.ErrHttpInvalidJSON), nil
 	}
 
 	var req AddProductRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err), nil
 	}
 
-	product, err := a.service.Execute(application.AddProductInput{
+	product, err := a.service.Execute(ctx, application.AddProductInput{
 		ID:          req.ID,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
 	})
 	if err != nil {
-		statusCode, ok := httperror.HttpError[err]
-		if !ok {
-			statusCode = http.StatusInternalServerError
-		}
-		return events.APIGatewayProxyResponse{
-			StatusCode: statusCode,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
+		return serviceErrorResponse(ctx, err), nil
 	}
 
-	res := AddProductResponse{
+	return jsonResponse(http.StatusCreated, AddProductResponse{
 		ID:          product.ID,
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
-	}
-
-	responseBody, err := json.Marshal(res) // TODO: Test error
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
-	}
-
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusCreated,
-		Body:       string(responseBody),
-	}, nil
+	}), nil
 }
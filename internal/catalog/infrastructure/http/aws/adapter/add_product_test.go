@@ -73,7 +73,7 @@ func TestLambdaAddProductAdapter_Handle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.mockServiceResult != nil || tt.mockServiceError != nil {
 				mockService.EXPECT().
-					Execute(gomock.Any()).
+					Execute(gomock.Any(), gomock.Any()).
 					Return(tt.mockServiceResult, tt.mockServiceError)
 			}
 
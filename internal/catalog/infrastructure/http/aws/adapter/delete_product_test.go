@@ -46,6 +46,14 @@ func TestLambdaDeleteProductAdapter_Handle(t *testing.T) {
 			expectedStatusCode: http.StatusInternalServerError,
 			expectedResponse:   `{"error": "error in repository"}`,
 		},
+		{
+			name:               "Repository unavailable",
+			httpMethod:         http.MethodDelete,
+			pathParameters:     map[string]string{"id": "1"},
+			mockServiceError:   domain.ErrRepositoryUnavailable,
+			expectedStatusCode: http.StatusServiceUnavailable,
+			expectedResponse:   `{"error": "repository unavailable"}`,
+		},
 		{
 			name:               "Service unknown error",
 			httpMethod:         http.MethodDelete,
@@ -73,11 +81,11 @@ func TestLambdaDeleteProductAdapter_Handle(t *testing.T) {
 			if tt.httpMethod == http.MethodDelete {
 				if tt.mockServiceError != nil {
 					mockService.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(tt.mockServiceError)
 				} else {
 					mockService.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(nil)
 				}
 			}
@@ -0,0 +1,130 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/test/application/mocks"
+)
+
+func loadEvent(t *testing.T, name string, event interface{}) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, event))
+}
+
+func TestLambdaAdapters_HandleV2(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	t.Run("Add product from base64 encoded body", func(t *testing.T) {
+		mockService := mocks.NewMockAddProductUseCase(mockCtrl)
+		mockService.EXPECT().
+			Execute(gomock.Any(), application.AddProductInput{ID: "1", Name: "Product", Description: "Description", Price: 10.0}).
+			Return(&application.AddProductOutput{ID: "1", Name: "Product", Description: "Description", Price: 10.0, CreatedAt: "2021-01-01T00:00:00Z", UpdatedAt: "2021-01-02T00:00:00Z"}, nil)
+
+		var event events.APIGatewayV2HTTPRequest
+		loadEvent(t, "apigw-v2-add-product.json", &event)
+
+		resp, err := NewLambdaAddProductAdapter(mockService).HandleV2(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.JSONEq(t, `{"id":"1","name":"Product","description":"Description","price":10.0,"created_at":"2021-01-01T00:00:00Z","updated_at":"2021-01-02T00:00:00Z"}`, resp.Body)
+	})
+
+	t.Run("Get product maps domain errors", func(t *testing.T) {
+		mockService := mocks.NewMockGetProductUseCase(mockCtrl)
+		mockService.EXPECT().
+			Execute(gomock.Any(), application.GetProductInput{ID: "1"}).
+			Return(nil, domain.ErrNotFoundProduct)
+
+		var event events.APIGatewayV2HTTPRequest
+		loadEvent(t, "apigw-v2-get-product.json", &event)
+
+		resp, err := NewLambdaGetProductUseCaseAdapter(mockService).HandleV2(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.JSONEq(t, `{"error":"product not found"}`, resp.Body)
+	})
+
+	t.Run("Update product reads path parameters", func(t *testing.T) {
+		mockService := mocks.NewMockUpdateProductUseCase(mockCtrl)
+		mockService.EXPECT().
+			Execute(gomock.Any(), application.UpdateProductInput{ID: "1", Name: "Updated Product", Description: "An updated product", Price: 19.99}).
+			Return(&application.UpdateProductOutput{ID: "1", Name: "Updated Product", Description: "An updated product", Price: 19.99, CreatedAt: "2021-01-01T00:00:00Z", UpdatedAt: "2021-01-02T00:00:00Z"}, nil)
+
+		var event events.APIGatewayV2HTTPRequest
+		loadEvent(t, "apigw-v2-update-product.json", &event)
+
+		resp, err := NewLambdaUpdateProductUseCaseAdapter(mockService).HandleV2(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.JSONEq(t, `{"id":"1","name":"Updated Product","description":"An updated product","price":19.99,"created_at":"2021-01-01T00:00:00Z","updated_at":"2021-01-02T00:00:00Z"}`, resp.Body)
+	})
+}
+
+func TestLambdaAdapters_HandleALB(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	t.Run("Get all products", func(t *testing.T) {
+		mockService := mocks.NewMockGetAllProductsUseCase(mockCtrl)
+		mockService.EXPECT().
+			Execute(gomock.Any()).
+			Return([]*application.GetAllProductsOutput{
+				{ID: "1", Name: "Product A", Description: "Description", Price: 10.0, CreatedAt: "2021-01-01T00:00:00Z", UpdatedAt: "2021-01-02T00:00:00Z"},
+			}, nil)
+
+		var event events.ALBTargetGroupRequest
+		loadEvent(t, "alb-get-all-products.json", &event)
+
+		resp, err := NewLambdaGetAllProductsAdapter(mockService).HandleALB(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "200 OK", resp.StatusDescription)
+		assert.Nil(t, resp.MultiValueHeaders)
+		assert.JSONEq(t, `[{"id":"1","name":"Product A","description":"Description","price":10.0,"created_at":"2021-01-01T00:00:00Z","updated_at":"2021-01-02T00:00:00Z"}]`, resp.Body)
+	})
+
+	t.Run("Delete product extracts id from path", func(t *testing.T) {
+		mockService := mocks.NewMockDeleteProductUseCase(mockCtrl)
+		mockService.EXPECT().
+			Execute(gomock.Any(), application.DeleteProductInput{ID: "1"}).
+			Return(nil)
+
+		var event events.ALBTargetGroupRequest
+		loadEvent(t, "alb-delete-product.json", &event)
+
+		resp, err := NewLambdaDeleteProductAdapter(mockService).HandleALB(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.Equal(t, "204 No Content", resp.StatusDescription)
+		assert.Empty(t, resp.Body)
+	})
+
+	t.Run("Update product maps domain errors", func(t *testing.T) {
+		mockService := mocks.NewMockUpdateProductUseCase(mockCtrl)
+		mockService.EXPECT().
+			Execute(gomock.Any(), application.UpdateProductInput{ID: "1", Name: "Updated Product", Description: "An updated product", Price: 19.99}).
+			Return(nil, domain.ErrRepositoryProduct)
+
+		var event events.ALBTargetGroupRequest
+		loadEvent(t, "alb-update-product.json", &event)
+
+		resp, err := NewLambdaUpdateProductUseCaseAdapter(mockService).HandleALB(context.Background(), event)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, "500 Internal Server Error", resp.StatusDescription)
+		assert.JSONEq(t, `{"error":"error in repository"}`, resp.Body)
+	})
+}
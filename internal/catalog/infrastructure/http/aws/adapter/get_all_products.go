@@ -2,16 +2,13 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
-	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
+	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
 )
 
 type GetAllProductsResponse struct {
@@ -25,39 +22,34 @@ type GetAllProductsResponse struct {
 
 type LambdaGetAllProductsAdapter struct {
 	service application.GetAllProductsUseCase
-	db      *dynamodb.DynamoDB
-	table   string
 }
 
 func NewLambdaGetAllProductsAdapter(service application.GetAllProductsUseCase) *LambdaGetAllProductsAdapter {
-	sess := session.Must(session.NewSession())
-	db := dynamodb.New(sess)
-
 	return &LambdaGetAllProductsAdapter{
 		service: service,
-		db:      db,
-		table:   os.Getenv("PRODUCTS_TABLE"),
 	}
 }
 
 func (a *LambdaGetAllProductsAdapter) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	if request.HTTPMethod != http.MethodGet {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusMethodNotAllowed,
-			Body:       `{"error": "method not allowed"}`,
-		}, nil
+	return pkglambda.NewAPIGatewayProxyHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaGetAllProductsAdapter) HandleV2(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	return pkglambda.NewAPIGatewayV2HTTPHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaGetAllProductsAdapter) HandleALB(ctx context.Context, request events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	return pkglambda.NewALBTargetGroupHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaGetAllProductsAdapter) Serve(ctx context.Context, request pkglambda.Request) (pkglambda.Response, error) {
+	if request.Method != http.MethodGet {
+		return errorResponse(http.StatusMethodNotAllowed, httpadapter.ErrHttpMethodNotAllowed), nil
 	}
 
-	products, err := a.service.Execute()
+	products, err := a.service.Execute(ctx)
 	if err != nil {
-		statusCode, ok := httperror.HttpError[err]
-		if !ok {
-			statusCode = http.StatusInternalServerError
-		}
-		return events.APIGatewayProxyResponse{
-			StatusCode: statusCode,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
+		return serviceErrorResponse(ctx, err), nil
 	}
 
 	response := make([]GetAllProductsResponse, len(products))
@@ -72,16 +64,5 @@ func (a *LambdaGetAllProductsAdapter) Handle(ctx context.Context, request events
 		}
 	}
 
-	responseBody, err := json.Marshal(response) // TODO: test error
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
-	}
-
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Body:       string(responseBody),
-	}, nil
+	return jsonResponse(http.StatusOK, response), nil
 }
@@ -83,7 +83,7 @@ func TestLambdaGetAllProductsAdapter_Handle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.mockServiceResult != nil || tt.mockServiceError != nil {
 				mockService.EXPECT().
-					Execute().
+					Execute(gomock.Any()).
 					Return(tt.mockServiceResult, tt.mockServiceError)
 			}
 
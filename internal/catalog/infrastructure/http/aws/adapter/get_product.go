@@ -2,16 +2,13 @@ package adapter
 
 import (
 	"context"
-	"encoding/json"
 	"net/http"
-	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
+	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
 )
 
 type GetProductUseCaseResponse struct {
@@ -25,64 +22,44 @@ type GetProductUseCaseResponse struct {
 
 type LambdaGetProductUseCaseAdapter struct {
 	service application.GetProductUseCase
-	db      *dynamodb.DynamoDB
-	table   string
 }
 
 func NewLambdaGetProductUseCaseAdapter(service application.GetProductUseCase) *LambdaGetProductUseCaseAdapter {
-	sess := session.Must(session.NewSession())
-	db := dynamodb.New(sess)
-
 	return &LambdaGetProductUseCaseAdapter{
 		service: service,
-		db:      db,
-		table:   os.Getenv("PRODUCTS_TABLE"),
 	}
 }
 
 func (a *LambdaGetProductUseCaseAdapter) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	if request.HTTPMethod != http.MethodGet {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusMethodNotAllowed,
-			Body:       `{"error": "method not allowed"}`,
-		}, nil
-	}
+	return pkglambda.NewAPIGatewayProxyHandler(a.Serve)(ctx, request)
+}
 
-	id := request.PathParameters["id"]
+func (a *LambdaGetProductUseCaseAdapter) HandleV2(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	return pkglambda.NewAPIGatewayV2HTTPHandler(a.Serve)(ctx, request)
+}
 
-	product, err := a.service.Execute(application.GetProductInput{
-		ID: id,
+func (a *LambdaGetProductUseCaseAdapter) HandleALB(ctx context.Context, request events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	return pkglambda.NewALBTargetGroupHandler(a.Serve, productResource)(ctx, request)
+}
+
+func (a *LambdaGetProductUseCaseAdapter) Serve(ctx context.Context, request pkglambda.Request) (pkglambda.Response, error) {
+	if request.Method != http.MethodGet {
+		return errorResponse(http.StatusMethodNotAllowed, httpadapter.ErrHttpMethodNotAllowed), nil
+	}
+
+	product, err := a.service.Execute(ctx, application.GetProductInput{
+		ID: request.PathParameters["id"],
 	})
 	if err != nil {
-		statusCode, ok := httperror.HttpError[err]
-		if !ok {
-			statusCode = http.StatusInternalServerError
-		}
-		return events.APIGatewayProxyResponse{
-			StatusCode: statusCode,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
+		return serviceErrorResponse(ctx, err), nil
 	}
 
-	res := GetProductUseCaseResponse{
+	return jsonResponse(http.StatusOK, GetProductUseCaseResponse{
 		ID:          product.ID,
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
-	}
-
-	responseBody, err := json.Marshal(res) // TODO: Test error
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
-	}
-
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Body:       string(responseBody),
-	}, nil
+	}), nil
 }
@@ -74,7 +74,7 @@ func TestLambdaGetProductUseCaseAdapter_Handle(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.mockServiceResult != nil || tt.mockServiceError != nil {
 				mockService.EXPECT().
-					Execute(gomock.Any()).
+					Execute(gomock.Any(), gomock.Any()).
 					Return(tt.mockServiceResult, tt.mockServiceError)
 			}
 
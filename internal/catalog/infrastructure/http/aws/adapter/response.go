@@ -0,0 +1,45 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+const productResource = "/products/{id}"
+
+func jsonResponse(statusCode int, body interface{}) pkglambda.Response {
+	responseBody, err := json.Marshal(body)
+	if err != nil {
+		return errorResponse(http.StatusInternalServerError, err)
+	}
+	return pkglambda.Response{
+		StatusCode: statusCode,
+		Body:       string(responseBody),
+	}
+}
+
+func errorResponse(statusCode int, err error) pkglambda.Response {
+	responseBody, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return pkglambda.Response{
+		StatusCode: statusCode,
+		Body:       string(responseBody),
+	}
+}
+
+// serviceErrorResponse maps a use case error to its response. Errors answered
+// with a 5xx are logged through the invocation's logger.
+func serviceErrorResponse(ctx context.Context, err error) pkglambda.Response {
+	statusCode, ok := httperror.HttpError[err]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+	}
+	if statusCode >= http.StatusInternalServerError {
+		pkgapplication.FromContext(ctx).Error("Product service failed", err, "status", statusCode)
+	}
+	return errorResponse(statusCode, err)
+}
@@ -0,0 +1,49 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+type errorLogger struct {
+	pkgapplication.Logger
+	errs []error
+}
+
+func (l *errorLogger) Error(msg string, err error, keysAndValues ...interface{}) {
+	l.errs = append(l.errs, err)
+}
+
+func TestServiceErrorResponse(t *testing.T) {
+	unknown := errors.New("connection reset")
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		logged         []error
+	}{
+		{name: "Client error", err: domain.ErrNotFoundProduct, expectedStatus: http.StatusNotFound},
+		{name: "Server error", err: domain.ErrRepositoryUnavailable, expectedStatus: http.StatusServiceUnavailable, logged: []error{domain.ErrRepositoryUnavailable}},
+		{name: "Unknown error", err: unknown, expectedStatus: http.StatusInternalServerError, logged: []error{unknown}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &errorLogger{Logger: pkgapplication.NopLogger()}
+			ctx := pkgapplication.IntoContext(context.Background(), logger)
+
+			response := serviceErrorResponse(ctx, tt.err)
+
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			assert.Equal(t, tt.logged, logger.errs)
+		})
+	}
+}
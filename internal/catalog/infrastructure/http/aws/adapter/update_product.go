@@ -4,16 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
-	"os"
 
 	"github.com/aws/aws-lambda-go/events"
-	"github.com/aws/aws-sdk-go/aws/session"
-	"github.com/aws/aws-sdk-go/service/dynamodb"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
 	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
 )
 
 type UpdateProductUseCaseRequest struct {
@@ -33,80 +30,62 @@ type UpdateProductUseCaseResponse struct {
 
 type LambdaUpdateProductUseCaseAdapter struct {
 	service application.UpdateProductUseCase
-	db      *dynamodb.DynamoDB
-	table   string
 }
 
 func NewLambdaUpdateProductUseCaseAdapter(service application.UpdateProductUseCase) *LambdaUpdateProductUseCaseAdapter {
-	sess := session.Must(session.NewSession())
-	db := dynamodb.New(sess)
-
 	return &LambdaUpdateProductUseCaseAdapter{
 		service: service,
-		db:      db,
-		table:   os.Getenv("PRODUCTS_TABLE"),
 	}
 }
 
 func (a *LambdaUpdateProductUseCaseAdapter) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	if request.HTTPMethod != http.MethodPut && request.HTTPMethod != http.MethodPatch {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusMethodNotAllowed,
-			Body:       `{"error": "` + httpadapter.ErrHttpMethodNotAllowed.Error() + `"}`,
-		}, nil
+	return pkglambda.NewAPIGatewayProxyHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaUpdateProductUseCaseAdapter) HandleV2(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	return pkglambda.NewAPIGatewayV2HTTPHandler(a.Serve)(ctx, request)
+}
+
+func (a *LambdaUpdateProductUseCaseAdapter) HandleALB(ctx context.Context, request events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+	return pkglambda.NewALBTargetGroupHandler(a.Serve, productResource)(ctx, request)
+}
+
+func (a *LambdaUpdateProductUseCaseAdapter) Serve(ctx context.Context, request pkglambda.Request) (pkglambda.Response, error) {
+	if request.Method != http.MethodPut && request.Method != http.MethodPatch {
+		return errorResponse(http.StatusMethodNotAllowed, httpadapter.ErrHttpMethodNotAllowed), nil
 	}
 
 	id := request.PathParameters["id"]
 	if id == "" {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Body:       `{"error": "` + domain.ErrInvalidProductID.Error() + `"}`,
-		}, nil
+		return errorResponse(http.StatusBadRequest, domain.ErrInvalidProductID), nil
+	}
+
+	body, err := request.DecodedBody()
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, httpadapter.ErrHttpInvalidJSON), nil
 	}
+
 	var req UpdateProductUseCaseRequest
-	if err := json.Unmarshal([]byte(request.Body), &req); err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusBadRequest,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
+	if err := json.Unmarshal(body, &req); err != nil {
+		return errorResponse(http.StatusBadRequest, err), nil
 	}
 
-	product, err := a.service.Execute(application.UpdateProductInput{
+	product, err := a.service.Execute(ctx, application.UpdateProductInput{
 		ID:          id,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
 	})
 	if err != nil {
-		statusCode, ok := httperror.HttpError[err]
-		if !ok {
-			statusCode = http.StatusInternalServerError
-		}
-		return events.APIGatewayProxyResponse{
-			StatusCode: statusCode,
-			Body:       `{"error": "` + err.Error() + `"}`,
-		}, nil
+		return serviceErrorResponse(ctx, err), nil
 	}
 
-	res := UpdateProductUseCaseResponse{
+	return jsonResponse(http.StatusOK, UpdateProductUseCaseResponse{
 		ID:          product.ID,
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
-	}
-
-	responseBody, err := json.Marshal(res) // TODO: Test error
-	if err != nil {
-		return events.APIGatewayProxyResponse{
-			StatusCode: http.StatusInternalServerError,
-			Body:       err.Error(),
-		}, nil
-	}
-
-	return events.APIGatewayProxyResponse{
-		StatusCode: http.StatusOK,
-		Body:       string(responseBody),
-	}, nil
+	}), nil
 }
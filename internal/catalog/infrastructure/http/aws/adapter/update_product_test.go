@@ -98,11 +98,11 @@ func TestLambdaUpdateProductUseCaseAdapter_Handle(t *testing.T) {
 			if tt.method == http.MethodPut && tt.productID != "" {
 				if tt.mockError != nil {
 					mockUseCase.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(nil, tt.mockError).Times(1)
 				} else if tt.mockOutput != nil {
 					mockUseCase.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(tt.mockOutput, nil).Times(1)
 				}
 			}
@@ -15,6 +15,10 @@ var HttpError = map[error]int{
 	domain.ErrAlreadyExistsProduct:      http.StatusConflict,
 	domain.ErrNotFoundProduct:           http.StatusNotFound,
 	domain.ErrRepositoryProduct:         http.StatusInternalServerError,
+	domain.ErrRepositoryUnavailable:     http.StatusServiceUnavailable,
 	adapter.ErrHttpInvalidJSON:          http.StatusBadRequest,
 	adapter.ErrServiceError:             http.StatusInternalServerError,
+	adapter.ErrHttpValidation:           http.StatusBadRequest,
+	adapter.ErrHttpBodyTooLarge:         http.StatusRequestEntityTooLarge,
+	adapter.ErrHttpUnsupportedMediaType: http.StatusUnsupportedMediaType,
 }
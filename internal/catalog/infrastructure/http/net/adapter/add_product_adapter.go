@@ -26,8 +26,7 @@ type AddProductResponse struct {
 }
 
 type NetHTTPAddProductAdapter struct {
-	service     application.AddProductUseCase
-	methodGuard httpadapter.HttpMethodGuard
+	service application.AddProductUseCase
 }
 
 type HTTPAddProductAdapterOption func(*NetHTTPAddProductAdapter) error
@@ -39,13 +38,6 @@ func WithService(service application.AddProductUseCase) HTTPAddProductAdapterOpt
 	}
 }
 
-func WithMethodGuard(methodGuard httpadapter.HttpMethodGuard) HTTPAddProductAdapterOption {
-	return func(a *NetHTTPAddProductAdapter) error {
-		a.methodGuard = methodGuard
-		return nil
-	}
-}
-
 func NewNetHTTPAddProductAdapter(opts ...HTTPAddProductAdapterOption) *NetHTTPAddProductAdapter {
 	adapter := &NetHTTPAddProductAdapter{}
 
@@ -57,14 +49,6 @@ func NewNetHTTPAddProductAdapter(opts ...HTTPAddProductAdapterOption) *NetHTTPAd
 }
 
 func (a *NetHTTPAddProductAdapter) Handle(w http.ResponseWriter, r *http.Request) {
-	if !a.methodGuard.IsMethodAllowed(r.Method) {
-		response := map[string]string{"error": httpadapter.ErrHttpMethodNotAllowed.Error()}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		json.NewEncoder(w).Encode(response)
-		return
-	}
-
 	var req AddProductRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response := map[string]string{"error": httpadapter.ErrHttpInvalidJSON.Error()}
@@ -74,18 +58,14 @@ func (a *NetHTTPAddProductAdapter) Handle(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	product, err := a.service.Execute(application.AddProductInput{
+	product, err := a.service.Execute(r.Context(), application.AddProductInput{
 		ID:          req.ID,
 		Name:        req.Name,
 		Description: req.Description,
 		Price:       req.Price,
 	})
 	if err != nil {
-		statusCode, ok := httperror.HttpError[err]
-		if !ok {
-			err = httpadapter.ErrServiceError
-			statusCode = httperror.HttpError[err]
-		}
+		statusCode, err := serviceError(r.Context(), err)
 		response := map[string]string{"error": err.Error()}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
@@ -27,12 +27,6 @@ func TestNetHTTPAddProductAdapter_Handle(t *testing.T) {
 		expectedStatusCode int
 		expectedResponse   map[string]interface{}
 	}{
-		{
-			name:               "Method Not Allowed",
-			httpMethod:         http.MethodGet,
-			expectedStatusCode: http.StatusMethodNotAllowed,
-			expectedResponse:   map[string]interface{}{"error": httpadapter.ErrHttpMethodNotAllowed.Error()},
-		},
 		{
 			name:               "Invalid JSON",
 			httpMethod:         http.MethodPost,
@@ -72,21 +66,19 @@ func TestNetHTTPAddProductAdapter_Handle(t *testing.T) {
 			defer mockCtrl.Finish()
 
 			mockService := mocks.NewMockAddProductUseCase(mockCtrl)
-			methodGuard := httpadapter.NewHttpMethodGuard([]string{http.MethodPost})
 
 			adapter := NewNetHTTPAddProductAdapter(
 				WithService(mockService),
-				WithMethodGuard(methodGuard),
 			)
 
 			if tt.httpMethod == http.MethodPost && tt.requestBody != "" {
 				if tt.mockError != nil {
 					mockService.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(nil, tt.mockError).Times(1)
 				} else if tt.mockOutput != nil {
 					mockService.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(tt.mockOutput, nil).Times(1)
 				}
 			}
@@ -4,10 +4,11 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
 	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
-	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
 )
 
 type DeleteProductRequest struct {
@@ -25,15 +26,7 @@ func NewNetHTTPDeleteProductAdapter(useCase application.DeleteProductUseCase) *N
 }
 
 func (a *NetHTTPDeleteProductAdapter) Handle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodDelete {
-		response := map[string]string{"error": httpadapter.ErrHttpMethodNotAllowed.Error()}
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusMethodNotAllowed)
-		json.NewEncoder(w).Encode(response)
-		return
-	}
-
-	productID := r.URL.Path[len("/products/"):]
+	productID := mux.Vars(r)["id"]
 	if productID == "" {
 		response := map[string]string{"error": domain.ErrInvalidProductID.Error()}
 		w.Header().Set("Content-Type", "application/json")
@@ -41,15 +34,11 @@ func (a *NetHTTPDeleteProductAdapter) Handle(w http.ResponseWriter, r *http.Requ
 		json.NewEncoder(w).Encode(response)
 		return
 	}
-	err := a.useCase.Execute(application.DeleteProductInput{
+	err := a.useCase.Execute(r.Context(), application.DeleteProductInput{
 		ID: productID,
 	})
 	if err != nil {
-		statusCode, ok := httperror.HttpError[err]
-		if !ok {
-			err = httpadapter.ErrServiceError
-			statusCode = httperror.HttpError[err]
-		}
+		statusCode, err := serviceError(r.Context(), err)
 		response := map[string]string{"error": err.Error()}
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
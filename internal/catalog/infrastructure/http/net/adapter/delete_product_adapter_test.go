@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
@@ -26,12 +27,6 @@ func TestNetHTTPDeleteProductAdapter_Handle(t *testing.T) {
 		expectedStatusCode int
 		expectedResponse   interface{}
 	}{
-		{
-			name:               "Method Not Allowed",
-			httpMethod:         http.MethodGet,
-			expectedStatusCode: http.StatusMethodNotAllowed,
-			expectedResponse:   map[string]interface{}{"error": httpadapter.ErrHttpMethodNotAllowed.Error()},
-		},
 		{
 			name:               "Invalid Product ID",
 			httpMethod:         http.MethodDelete,
@@ -83,16 +78,17 @@ func TestNetHTTPDeleteProductAdapter_Handle(t *testing.T) {
 			if tt.httpMethod == http.MethodDelete && tt.productID != "" {
 				if tt.mockServiceError != nil {
 					mockService.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(tt.mockServiceError).Times(1)
 				} else {
 					mockService.EXPECT().
-						Execute(gomock.Any()).
+						Execute(gomock.Any(), gomock.Any()).
 						Return(nil).Times(1)
 				}
 			}
 
 			req := httptest.NewRequest(tt.httpMethod, "/products/"+tt.productID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.productID})
 			rec := httptest.NewRecorder()
 
 			adapter.Handle(rec, req)
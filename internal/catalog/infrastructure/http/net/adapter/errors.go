@@ -0,0 +1,27 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+
+	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+// serviceError maps a use case error to the status and error sent to the
+// client. Errors answered with a 5xx are logged through the request's
+// logger, and the unknown ones are replaced by ErrServiceError.
+func serviceError(ctx context.Context, err error) (int, error) {
+	statusCode, ok := httperror.HttpError[err]
+	if !ok {
+		statusCode = http.StatusInternalServerError
+	}
+	if statusCode >= http.StatusInternalServerError {
+		pkgapplication.FromContext(ctx).Error("Product service failed", err, "status", statusCode)
+	}
+	if !ok {
+		err = httpadapter.ErrServiceError
+	}
+	return statusCode, err
+}
@@ -5,8 +5,6 @@ import (
 	"net/http"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
-	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
 )
 
 type GetAllProductsResponse struct {
@@ -29,18 +27,10 @@ func NewNetHTTPGetAllProductsAdapter(useCase application.GetAllProductsUseCase)
 }
 
 func (a *NetHTTPGetAllProductsAdapter) Handle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		http.Error(w, `{"error": "`+httpadapter.ErrHttpMethodNotAllowed.Error()+`"}`, http.StatusMethodNotAllowed)
-		return
-	}
-
-	products, err := a.useCase.Execute()
+	products, err := a.useCase.Execute(r.Context())
 	if err != nil {
-		code, ok := httperror.HttpError[err]
-		if !ok {
-			code = http.StatusInternalServerError
-		}
-		http.Error(w, `{"error": "`+err.Error()+`"}`, code)
+		statusCode, err := serviceError(r.Context(), err)
+		http.Error(w, `{"error": "`+err.Error()+`"}`, statusCode)
 		return
 	}
 
@@ -86,6 +86,14 @@ func TestNetHTTPGetAllProductsAdapter_Handle(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   map[string]interface{}{"error": domain.ErrRepositoryProduct.Error()},
 		},
+		{
+			name:           "Repository unavailable",
+			method:         http.MethodGet,
+			mockProducts:   nil,
+			mockError:      domain.ErrRepositoryUnavailable,
+			expectedStatus: http.StatusServiceUnavailable,
+			expectedBody:   map[string]interface{}{"error": domain.ErrRepositoryUnavailable.Error()},
+		},
 		{
 			name:           "Service unknown error",
 			method:         http.MethodGet,
@@ -94,14 +102,6 @@ func TestNetHTTPGetAllProductsAdapter_Handle(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			expectedBody:   map[string]interface{}{"error": pkghttp.ErrServiceError.Error()},
 		},
-		{
-			name:           "Method not allowed",
-			method:         http.MethodPost,
-			mockProducts:   nil,
-			mockError:      nil,
-			expectedStatus: http.StatusMethodNotAllowed,
-			expectedBody:   map[string]interface{}{"error": pkghttp.ErrHttpMethodNotAllowed.Error()},
-		},
 	}
 
 	for _, tt := range tests {
@@ -113,7 +113,7 @@ func TestNetHTTPGetAllProductsAdapter_Handle(t *testing.T) {
 			adapter := NewNetHTTPGetAllProductsAdapter(mockUseCase)
 
 			if tt.method == http.MethodGet {
-				mockUseCase.EXPECT().Execute().Return(tt.mockProducts, tt.mockError)
+				mockUseCase.EXPECT().Execute(gomock.Any()).Return(tt.mockProducts, tt.mockError)
 			}
 
 			req, _ := http.NewRequest(tt.method, "/products", nil)
@@ -4,9 +4,9 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
-	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
 )
 
 type GetProductRequest struct {
@@ -33,21 +33,16 @@ func NewNetHTTPGetProductAdapter(useCase application.GetProductUseCase) *NetHTTP
 }
 
 func (a *NetHTTPGetProductAdapter) Handle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
-		w.Header().Set("Content-Type", "application/json")
-		http.Error(w, `{"error": "`+httpadapter.ErrHttpMethodNotAllowed.Error()+`"}`, http.StatusMethodNotAllowed)
-		return
-	}
-
-	productID := r.URL.Path[len("/products/"):]
+	productID := mux.Vars(r)["id"]
 	input := application.GetProductInput{
 		ID: productID,
 	}
 
-	product, err := a.useCase.Execute(input)
+	product, err := a.useCase.Execute(r.Context(), input)
 	if err != nil {
+		statusCode, err := serviceError(r.Context(), err)
 		w.Header().Set("Content-Type", "application/json")
-		http.Error(w, `{"error": "`+err.Error()+`"}`, httperror.HttpError[err])
+		http.Error(w, `{"error": "`+err.Error()+`"}`, statusCode)
 		return
 	}
 
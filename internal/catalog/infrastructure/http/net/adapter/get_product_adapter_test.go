@@ -8,11 +8,11 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
-	_adapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
 	"github.com/mateusmacedo/go-sls-marketplace/test/application/mocks"
 )
 
@@ -60,15 +60,6 @@ func TestNetHTTPGetProductAdapter_Handle(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			expectedBody:   map[string]interface{}{"error": domain.ErrNotFoundProduct.Error()},
 		},
-		{
-			name:           "Method not allowed",
-			method:         http.MethodPost,
-			productID:      "123",
-			mockProduct:    nil,
-			mockError:      nil,
-			expectedStatus: http.StatusMethodNotAllowed,
-			expectedBody:   map[string]interface{}{"error": _adapter.ErrHttpMethodNotAllowed.Error()},
-		},
 	}
 
 	for _, tt := range tests {
@@ -78,12 +69,13 @@ func TestNetHTTPGetProductAdapter_Handle(t *testing.T) {
 
 			mockUseCase := mocks.NewMockGetProductUseCase(mockCtrl)
 			if tt.method == http.MethodGet {
-				mockUseCase.EXPECT().Execute(application.GetProductInput{ID: tt.productID}).Return(tt.mockProduct, tt.mockError)
+				mockUseCase.EXPECT().Execute(gomock.Any(), application.GetProductInput{ID: tt.productID}).Return(tt.mockProduct, tt.mockError)
 			}
 
 			adapter := NewNetHTTPGetProductAdapter(mockUseCase)
 
 			req, _ := http.NewRequest(tt.method, "/products/"+tt.productID, nil)
+			req = mux.SetURLVars(req, map[string]string{"id": tt.productID})
 			rr := httptest.NewRecorder()
 
 			adapter.Handle(rr, req)
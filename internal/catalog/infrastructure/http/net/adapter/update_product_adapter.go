@@ -4,10 +4,10 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/gorilla/mux"
+
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
-	httperror "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/error"
-	httpadapter "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
 )
 
 type UpdateProductRequest struct {
@@ -16,6 +16,20 @@ type UpdateProductRequest struct {
 	Price       *float64 `json:"price"`
 }
 
+// validate rejects the requests leaving out a field: the update replaces
+// all of them.
+func (r UpdateProductRequest) validate() error {
+	switch {
+	case r.Name == nil:
+		return domain.ErrInvalidProductName
+	case r.Description == nil:
+		return domain.ErrInvalidProductDescription
+	case r.Price == nil:
+		return domain.ErrInvalidProductPrice
+	}
+	return nil
+}
+
 type UpdateProductResponse struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
@@ -34,13 +48,7 @@ func NewNetHTTPUpdateProductAdapter(useCase application.UpdateProductUseCase) *N
 }
 
 func (a *NetHTTPUpdateProductAdapter) Handle(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPut && r.Method != http.MethodPatch {
-		w.Header().Set("Content-Type", "application/json")
-		http.Error(w, `{"error": "`+httpadapter.ErrHttpMethodNotAllowed.Error()+`"}`, http.StatusMethodNotAllowed)
-		return
-	}
-
-	productID := r.URL.Path[len("/products/"):]
+	productID := mux.Vars(r)["id"]
 	if productID == "" {
 		w.Header().Set("Content-Type", "application/json")
 		http.Error(w, `{"error": "`+domain.ErrInvalidProductID.Error()+`"}`, http.StatusBadRequest)
@@ -54,6 +62,12 @@ func (a *NetHTTPUpdateProductAdapter) Handle(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	if err := req.validate(); err != nil {
+		w.Header().Set("Content-Type", "application/json")
+		http.Error(w, `{"error": "`+err.Error()+`"}`, http.StatusBadRequest)
+		return
+	}
+
 	input := application.UpdateProductInput{
 		ID:          productID,
 		Name:        *req.Name,
@@ -62,10 +76,11 @@ func (a *NetHTTPUpdateProductAdapter) Handle(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Execute the use case
-	product, err := a.useCase.Execute(input)
+	product, err := a.useCase.Execute(r.Context(), input)
 	if err != nil {
+		statusCode, err := serviceError(r.Context(), err)
 		w.Header().Set("Content-Type", "application/json")
-		http.Error(w, `{"error": "`+err.Error()+`"}`, httperror.HttpError[err])
+		http.Error(w, `{"error": "`+err.Error()+`"}`, statusCode)
 		return
 	}
 
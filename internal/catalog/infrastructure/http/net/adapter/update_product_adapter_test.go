@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
@@ -104,6 +105,27 @@ func TestNetHTTPUpdateProductAdapter_Handle(t *testing.T) {
 			expectExecute:  true,
 			method:         http.MethodPut,
 		},
+		{
+			name:           "Invalid input - missing fields",
+			productID:      "5",
+			input:          map[string]interface{}{},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"error": domain.ErrInvalidProductName.Error()},
+			expectExecute:  false,
+			method:         http.MethodPut,
+		},
+		{
+			name:      "Invalid input - missing price",
+			productID: "6",
+			input: UpdateProductRequest{
+				Name:        stringPtr("Product"),
+				Description: stringPtr("A product"),
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   map[string]interface{}{"error": domain.ErrInvalidProductPrice.Error()},
+			expectExecute:  false,
+			method:         http.MethodPut,
+		},
 		{
 			name:           "Invalid JSON input",
 			productID:      "4",
@@ -115,17 +137,6 @@ func TestNetHTTPUpdateProductAdapter_Handle(t *testing.T) {
 			expectExecute:  false,
 			method:         http.MethodPut,
 		},
-		{
-			name:           "Method not allowed",
-			productID:      "5",
-			input:          UpdateProductRequest{},
-			mockOutput:     nil,
-			mockError:      nil,
-			expectedStatus: http.StatusMethodNotAllowed,
-			expectedBody:   map[string]interface{}{"error": "method not allowed"},
-			expectExecute:  false,
-			method:         http.MethodGet,
-		},
 	}
 
 	for _, tt := range tests {
@@ -135,9 +146,9 @@ func TestNetHTTPUpdateProductAdapter_Handle(t *testing.T) {
 
 			mockUseCase := mocks.NewMockUpdateProductUseCase(ctrl)
 			if tt.expectExecute {
-				mockUseCase.EXPECT().Execute(gomock.Any()).Return(tt.mockOutput, tt.mockError)
+				mockUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).Return(tt.mockOutput, tt.mockError)
 			} else {
-				mockUseCase.EXPECT().Execute(gomock.Any()).Times(0)
+				mockUseCase.EXPECT().Execute(gomock.Any(), gomock.Any()).Times(0)
 			}
 
 			adapter := NewNetHTTPUpdateProductAdapter(mockUseCase)
@@ -152,6 +163,7 @@ func TestNetHTTPUpdateProductAdapter_Handle(t *testing.T) {
 			}
 
 			req, _ := http.NewRequest(tt.method, "/products/"+tt.productID, bytes.NewReader(body))
+			req = mux.SetURLVars(req, map[string]string{"id": tt.productID})
 			rr := httptest.NewRecorder()
 
 			adapter.Handle(rr, req)
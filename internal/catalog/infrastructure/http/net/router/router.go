@@ -0,0 +1,109 @@
+package router
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/gorilla/mux"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	httpadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/net/adapter"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/openapi"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+)
+
+// Register mounts the product routes on r behind tracing, the request scope
+// and the OpenAPI validator. The routes r already has, such as probes, keep
+// their own middleware. Requests with a method no route of their path
+// accepts get a 405 problem response with an Allow header.
+func Register(r *mux.Router, factory pkgapplication.Factory, logger pkgapplication.Logger, tracer pkgapplication.Tracer) error {
+	addProductUseCase, err := pkgapplication.Create[application.AddProductUseCase](factory, "AddProductUseCase")
+	if err != nil {
+		return err
+	}
+	deleteProductUseCase, err := pkgapplication.Create[application.DeleteProductUseCase](factory, "DeleteProductUseCase")
+	if err != nil {
+		return err
+	}
+	getAllProductsUseCase, err := pkgapplication.Create[application.GetAllProductsUseCase](factory, "GetAllProductsUseCase")
+	if err != nil {
+		return err
+	}
+	getProductUseCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	if err != nil {
+		return err
+	}
+	updateProductUseCase, err := pkgapplication.Create[application.UpdateProductUseCase](factory, "UpdateProductUseCase")
+	if err != nil {
+		return err
+	}
+
+	addProductHandler := httpadapter.NewNetHTTPAddProductAdapter(
+		httpadapter.WithService(addProductUseCase),
+	)
+
+	deleteProductHandler := httpadapter.NewNetHTTPDeleteProductAdapter(deleteProductUseCase)
+	getAllProductsHandler := httpadapter.NewNetHTTPGetAllProductsAdapter(getAllProductsUseCase)
+	getProductHandler := httpadapter.NewNetHTTPGetProductAdapter(getProductUseCase)
+	updateProductHandler := httpadapter.NewNetHTTPUpdateProductAdapter(updateProductUseCase)
+
+	validator, err := openapi.NewValidator()
+	if err != nil {
+		return err
+	}
+
+	api := r.NewRoute().Subrouter()
+	api.Use(mux.MiddlewareFunc(middleware.Tracing(tracer, RouteTemplate)))
+	api.Use(mux.MiddlewareFunc(middleware.Scope(factory, logger)))
+	api.Use(validator.Middleware)
+	api.HandleFunc("/products", addProductHandler.Handle).Methods(http.MethodPost)
+	api.HandleFunc("/products/{id}", deleteProductHandler.Handle).Methods(http.MethodDelete)
+	api.HandleFunc("/products", getAllProductsHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/products/{id}", getProductHandler.Handle).Methods(http.MethodGet)
+	api.HandleFunc("/products/{id}", updateProductHandler.Handle).Methods(http.MethodPut)
+
+	// mux picks the handler of the innermost router whose routes matched the
+	// path, so both need it.
+	methodNotAllowed := middleware.MethodNotAllowed(allowedMethods(r))
+	r.MethodNotAllowedHandler = methodNotAllowed
+	api.MethodNotAllowedHandler = methodNotAllowed
+
+	return nil
+}
+
+// RouteTemplate labels request metrics and spans with the matched mux route,
+// such as /products/{id}. Both middlewares run inside the router, so only
+// matched requests are recorded.
+func RouteTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return "unmatched"
+}
+
+// allowedMethods lists the methods the routes of router accept for the
+// request's path.
+func allowedMethods(router *mux.Router) func(r *http.Request) []string {
+	return func(r *http.Request) []string {
+		var allowed []string
+		router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+			methods, err := route.GetMethods()
+			if err != nil {
+				return nil
+			}
+			for _, method := range methods {
+				probe := *r
+				probe.Method = method
+				if route.Match(&probe, &mux.RouteMatch{}) && !slices.Contains(allowed, method) {
+					allowed = append(allowed, method)
+				}
+			}
+			return nil
+		})
+		slices.Sort(allowed)
+		return allowed
+	}
+}
@@ -0,0 +1,79 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+)
+
+func TestRegister(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Repository = config.RepositoryConfig{Backend: config.BackendMemory}
+	factory, err := catalog.NewFactory(&cfg, pkgapplication.NopLogger(), prometheus.NewRegistry(), pkgapplication.NopTracer())
+	assert.NoError(t, err)
+	defer factory.Close()
+
+	r := mux.NewRouter()
+	r.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {}).Methods(http.MethodGet)
+	assert.NoError(t, Register(r, factory, pkgapplication.NopLogger(), pkgapplication.NopTracer()))
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+		wantAllow  string
+	}{
+		{name: "Wrong product method", method: http.MethodPatch, path: "/products/1", body: `{}`, wantStatus: http.StatusMethodNotAllowed, wantAllow: "DELETE, GET, PUT"},
+		{name: "Wrong products method", method: http.MethodDelete, path: "/products", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET, POST"},
+		{name: "Wrong probe method", method: http.MethodPost, path: "/healthz", wantStatus: http.StatusMethodNotAllowed, wantAllow: "GET"},
+		{name: "Unknown path", method: http.MethodGet, path: "/orders", wantStatus: http.StatusNotFound},
+		{name: "Add product", method: http.MethodPost, path: "/products", body: `{"id":"1","name":"Product","description":"A product","price":10}`, wantStatus: http.StatusCreated},
+		{name: "Get product", method: http.MethodGet, path: "/products/1", wantStatus: http.StatusOK},
+		{name: "Update product without fields", method: http.MethodPut, path: "/products/1", body: `{}`, wantStatus: http.StatusBadRequest},
+		{name: "Update product", method: http.MethodPut, path: "/products/1", body: `{"name":"Renamed","description":"A product","price":12}`, wantStatus: http.StatusOK},
+		{name: "Delete product", method: http.MethodDelete, path: "/products/1", wantStatus: http.StatusNoContent},
+		{name: "Deleted product", method: http.MethodGet, path: "/products/1", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rr := httptest.NewRecorder()
+
+			r.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantStatus, rr.Code, rr.Body.String())
+			assert.Equal(t, tt.wantAllow, rr.Header().Get("Allow"))
+			if tt.wantStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, middleware.ProblemContentType, rr.Header().Get("Content-Type"))
+				assert.Contains(t, rr.Body.String(), adapter.ErrHttpMethodNotAllowed.Error())
+			}
+		})
+	}
+}
+
+func TestRouteTemplate(t *testing.T) {
+	var template string
+	r := mux.NewRouter()
+	r.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		template = RouteTemplate(r)
+	})
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products/1", nil))
+	assert.Equal(t, "/products/{id}", template)
+	assert.Equal(t, "unmatched", RouteTemplate(httptest.NewRequest(http.MethodGet, "/products/1", nil)))
+}
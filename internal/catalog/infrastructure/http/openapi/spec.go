@@ -0,0 +1,18 @@
+package openapi
+
+import (
+	_ "embed"
+
+	pkgopenapi "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/openapi"
+)
+
+//go:embed openapi.json
+var Spec []byte
+
+func NewValidator(opts ...pkgopenapi.ValidatorOption) (*pkgopenapi.Validator, error) {
+	doc, err := pkgopenapi.Load(Spec)
+	if err != nil {
+		return nil, err
+	}
+	return pkgopenapi.NewValidator(doc, opts...)
+}
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	pkgopenapi "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/openapi"
+)
+
+func TestNewValidator(t *testing.T) {
+	validator, err := NewValidator()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		request        pkgopenapi.Request
+		expectedFields []pkgopenapi.FieldError
+	}{
+		{
+			name: "Valid add product",
+			request: pkgopenapi.Request{
+				Method: http.MethodPost,
+				Path:   "/products",
+				Body:   []byte(`{"id":"1","name":"Product","description":"Description","price":10.0}`),
+			},
+		},
+		{
+			name: "Add product with garbage",
+			request: pkgopenapi.Request{
+				Method: http.MethodPost,
+				Path:   "/products",
+				Body:   []byte(`{"id":"1","name":"","price":-1,"sku":"x"}`),
+			},
+			expectedFields: []pkgopenapi.FieldError{
+				{Field: "description", Message: "is required"},
+				{Field: "name", Message: "must be at least 1 characters long"},
+				{Field: "price", Message: "must be greater than 0"},
+				{Field: "sku", Message: "is not allowed"},
+			},
+		},
+		{
+			name: "Valid update product",
+			request: pkgopenapi.Request{
+				Method: http.MethodPut,
+				Path:   "/products/1",
+				Body:   []byte(`{"name":"Product","description":"Description","price":10.0}`),
+			},
+		},
+		{
+			name: "Partial update product",
+			request: pkgopenapi.Request{
+				Method: http.MethodPatch,
+				Path:   "/products/1",
+				Body:   []byte(`{"name":"Product"}`),
+			},
+			expectedFields: []pkgopenapi.FieldError{
+				{Field: "description", Message: "is required"},
+				{Field: "price", Message: "is required"},
+			},
+		},
+		{
+			name: "Get product",
+			request: pkgopenapi.Request{
+				Method:         http.MethodGet,
+				Resource:       "/products/{id}",
+				PathParameters: map[string]string{"id": "1"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.request)
+			if tt.expectedFields == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, adapter.ErrHttpValidation)
+			assert.Equal(t, tt.expectedFields, err.(*pkgopenapi.ValidationError).Details)
+		})
+	}
+}
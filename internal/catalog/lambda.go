@@ -0,0 +1,77 @@
+package catalog
+
+import (
+	"flag"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/openapi"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+	pkgopenapi "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/openapi"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/log"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/emf"
+)
+
+// Lambda holds what a Lambda function builds its handler from.
+type Lambda struct {
+	Config    *config.Config
+	Logger    pkgapplication.Logger
+	Metrics   pkgapplication.Metrics
+	Tracer    pkgapplication.Tracer
+	Factory   pkgapplication.Factory
+	Validator *pkgopenapi.Validator
+}
+
+// StartLambda bootstraps the function from its arguments and the
+// environment and serves the handler returned by build behind
+// LambdaMiddleware. Bootstrap errors are logged and end the function.
+func StartLambda(build func(*Lambda) (pkglambda.Handler, error)) {
+	logger, _ := log.NewZapLogger()
+	cfg, err := config.Load(flag.NewFlagSet(os.Args[0], flag.ContinueOnError), os.Args[1:], config.WithDefaults(config.LambdaDefaults()))
+	if err != nil {
+		logger.Error("Error loading configuration", err)
+		return
+	}
+	configured, err := NewLogger(cfg.Log)
+	if err != nil {
+		logger.Error("Error creating logger", err)
+		return
+	}
+	logger = configured
+	logger.Info("Loaded configuration", "config", cfg.String())
+
+	// Lambda functions emit their metrics as EMF records on stdout, which
+	// CloudWatch turns into metrics.
+	metrics := emf.NewRegistry(os.Stdout, MetricsNamespace)
+	tracer := NewTracer(cfg.Tracing, logger)
+
+	factory, err := NewFactory(cfg, logger, metrics, tracer)
+	if err != nil {
+		logger.Error("Error initializing Factory", err)
+		return
+	}
+
+	validator, err := openapi.NewValidator()
+	if err != nil {
+		logger.Error("Error creating request validator", err)
+		return
+	}
+
+	handler, err := build(&Lambda{Config: cfg, Logger: logger, Metrics: metrics, Tracer: tracer, Factory: factory, Validator: validator})
+	if err != nil {
+		logger.Error("Error creating handler", err)
+		return
+	}
+
+	lambda.Start(LambdaMiddleware(cfg.Server, factory, logger, metrics, tracer)(handler))
+}
+
+// LambdaMiddleware is the middleware every catalog function runs behind,
+// deployed or emulated. Each invocation gets a request scope of factory,
+// correlated by the request ID.
+func LambdaMiddleware(cfg config.ServerConfig, factory pkgapplication.Factory, logger pkgapplication.Logger, metrics pkgapplication.Metrics, tracer pkgapplication.Tracer) pkglambda.Middleware {
+	return pkglambda.Chain(pkglambda.Tracing(tracer), pkglambda.Metrics(metrics), pkglambda.Default(logger, cfg.CORSAllowedOrigins), pkglambda.Scope(factory, logger))
+}
@@ -0,0 +1,37 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+)
+
+func TestLambdaMiddleware(t *testing.T) {
+	cfg := config.Defaults()
+	cfg.Repository = config.RepositoryConfig{Backend: config.BackendMemory}
+	metrics := prometheus.NewRegistry()
+	factory, err := NewFactory(&cfg, pkgapplication.NopLogger(), metrics, pkgapplication.NopTracer())
+	assert.NoError(t, err)
+	defer factory.Close()
+
+	handler := LambdaMiddleware(cfg.Server, factory, pkgapplication.NopLogger(), metrics, pkgapplication.NopTracer())(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		scope, ok := pkgapplication.ScopeFromContext(ctx)
+		assert.True(t, ok)
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Body:       pkgapplication.MustCreate[string](scope, pkgapplication.CorrelationIDKey),
+		}, nil
+	})
+
+	response, err := handler(context.Background(), events.APIGatewayProxyRequest{Headers: map[string]string{"X-Request-ID": "abc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "abc", response.Body)
+}
@@ -0,0 +1,20 @@
+package catalog
+
+import (
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkglog "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/log"
+)
+
+// NewLogger builds the zap logger described by cfg. Empty settings keep
+// zap's production defaults.
+func NewLogger(cfg config.LogConfig) (pkgapplication.Logger, error) {
+	opts := []pkglog.ZapOption{pkglog.WithSampling(cfg.Sampling)}
+	if cfg.Level != "" {
+		opts = append(opts, pkglog.WithLevel(cfg.Level))
+	}
+	if cfg.Encoding != "" {
+		opts = append(opts, pkglog.WithEncoding(cfg.Encoding))
+	}
+	return pkglog.NewZapLogger(opts...)
+}
@@ -0,0 +1,318 @@
+package catalog
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/decorator"
+	dynamodbadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/dynamodb/adapter"
+	dbadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/adapter"
+	memoryadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/memory/adapter"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// GormPersistenceModule provides the product repositories on top of the
+// *gorm.DB registered as "dbConn".
+func GormPersistenceModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "gorm-persistence",
+		Recipes: map[string]pkgapplication.Recipe{
+			"ProductSaveRepository":    {Dependencies: []string{"dbConn"}, Factory: dbadapter.CreateProductSaveRepository},
+			"ProductFindRepository":    {Dependencies: []string{"dbConn"}, Factory: dbadapter.CreateProductFindRepository},
+			"ProductFindAllRepository": {Dependencies: []string{"dbConn"}, Factory: dbadapter.CreateProductFindAllRepository},
+			"ProductDeleteRepository":  {Dependencies: []string{"dbConn"}, Factory: dbadapter.CreateProductDeleteRepository},
+			"RepositoryHealthCheck":    pkgapplication.NewRecipe1("dbConn", dbadapter.NewDatabaseHealthCheck),
+		},
+	}
+}
+
+// DynamoPersistenceModule provides the product repositories on top of the
+// client registered as "dynamoDBAPI" and the table named "dynamoTableName".
+func DynamoPersistenceModule() pkgapplication.Module {
+	dependencies := []string{"dynamoDBAPI", "dynamoTableName"}
+	return pkgapplication.Module{
+		Name: "dynamo-persistence",
+		Recipes: map[string]pkgapplication.Recipe{
+			"ProductSaveRepository":    {Dependencies: dependencies, Factory: dynamodbadapter.CreateProductSaveRepository},
+			"ProductFindRepository":    {Dependencies: dependencies, Factory: dynamodbadapter.CreateProductFindRepository},
+			"ProductFindAllRepository": {Dependencies: dependencies, Factory: dynamodbadapter.CreateProductFindAllRepository},
+			"ProductDeleteRepository":  {Dependencies: dependencies, Factory: dynamodbadapter.CreateProductDeleteRepository},
+			"RepositoryHealthCheck":    {Dependencies: dependencies, Factory: dynamodbadapter.CreateTableHealthCheck},
+		},
+	}
+}
+
+// DynamoClientModule provides "dynamoDBAPI" and "dynamoTableName" from the
+// *config.Config registered as "config".
+func DynamoClientModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "dynamo-client",
+		Recipes: map[string]pkgapplication.Recipe{
+			"dynamoDBAPI": {Dependencies: []string{"config"}, Factory: createDynamoDBClient},
+			"dynamoTableName": pkgapplication.NewRecipe1("config", func(cfg *config.Config) string {
+				return cfg.AWS.ProductsTable
+			}),
+		},
+	}
+}
+
+func createDynamoDBClient(dependencies map[string]interface{}) (interface{}, error) {
+	cfg, err := pkgapplication.Dependency[*config.Config](dependencies, "config")
+	if err != nil {
+		return nil, err
+	}
+
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.TODO(), awsconfig.WithRegion(cfg.AWS.Region))
+	if err != nil {
+		return nil, err
+	}
+
+	return dynamodb.NewFromConfig(awsCfg, func(o *dynamodb.Options) {
+		if cfg.AWS.DynamoDBEndpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.AWS.DynamoDBEndpoint)
+		}
+	}), nil
+}
+
+// MemoryPersistenceModule provides the product repositories on top of the
+// store registered as "productStore".
+func MemoryPersistenceModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "memory-persistence",
+		Recipes: map[string]pkgapplication.Recipe{
+			"ProductSaveRepository":    {Dependencies: []string{"productStore"}, Factory: memoryadapter.CreateProductSaveRepository},
+			"ProductFindRepository":    {Dependencies: []string{"productStore"}, Factory: memoryadapter.CreateProductFindRepository},
+			"ProductFindAllRepository": {Dependencies: []string{"productStore"}, Factory: memoryadapter.CreateProductFindAllRepository},
+			"ProductDeleteRepository":  {Dependencies: []string{"productStore"}, Factory: memoryadapter.CreateProductDeleteRepository},
+			"RepositoryHealthCheck":    pkgapplication.NewRecipe1("productStore", memoryadapter.NewStoreHealthCheck),
+		},
+	}
+}
+
+// HealthModule provides a "HealthChecker" that probes the storage behind
+// the "RepositoryHealthCheck" of a persistence module.
+func HealthModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "health",
+		Recipes: map[string]pkgapplication.Recipe{
+			"HealthChecker": {Dependencies: []string{"RepositoryHealthCheck"}, Factory: createHealthChecker},
+		},
+	}
+}
+
+func createHealthChecker(dependencies map[string]interface{}) (interface{}, error) {
+	check, err := pkgapplication.Dependency[pkgapplication.HealthCheck](dependencies, "RepositoryHealthCheck")
+	if err != nil {
+		return nil, err
+	}
+
+	checker := pkgapplication.NewHealthChecker()
+	if err := checker.Register(check); err != nil {
+		return nil, err
+	}
+	return checker, nil
+}
+
+// CatalogServicesModule provides the domain services and the use cases on
+// top of the repositories of a persistence module.
+func CatalogServicesModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "catalog-services",
+		Recipes: map[string]pkgapplication.Recipe{
+			// Domain Services
+			"ProductAdder":     {Dependencies: []string{"ProductFindRepository", "ProductSaveRepository"}, Factory: domain.CreateProductAdder},
+			"ProductDeleter":   {Dependencies: []string{"ProductFindRepository", "ProductDeleteRepository"}, Factory: domain.CreateProductDeleter},
+			"ProductFinder":    {Dependencies: []string{"ProductFindRepository"}, Factory: domain.CreateProductFinder},
+			"AllProductFinder": {Dependencies: []string{"ProductFindAllRepository"}, Factory: domain.CreateAllProductFinder},
+			"ProductUpdater":   {Dependencies: []string{"ProductFindRepository", "ProductSaveRepository"}, Factory: domain.CreateProductUpdater},
+
+			// Application Use Cases
+			"AddProductUseCase":     {Dependencies: []string{"ProductAdder"}, Factory: application.CreateAddProductUseCase},
+			"DeleteProductUseCase":  {Dependencies: []string{"ProductDeleter"}, Factory: application.CreateDeleteProductUseCase},
+			"GetAllProductsUseCase": {Dependencies: []string{"AllProductFinder"}, Factory: application.CreateGetAllProductsUseCase},
+			"GetProductUseCase":     {Dependencies: []string{"ProductFinder"}, Factory: application.CreateGetProductUseCase},
+			"UpdateProductUseCase":  {Dependencies: []string{"ProductUpdater"}, Factory: application.CreateUpdateProductUseCase},
+		},
+	}
+}
+
+// MetricsNamespace is the CloudWatch namespace of the metrics emitted by the
+// Lambda functions.
+const MetricsNamespace = "Catalog"
+
+// MetricsModule records the repository calls, labelled with the configured
+// backend, and the use case executions in the pkgapplication.Metrics
+// registered as "metrics". It decorates the recipes of a persistence module
+// and of CatalogServicesModule, so it must be composed after them.
+func MetricsModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "metrics",
+		Decorators: map[string]pkgapplication.Decorator{
+			"ProductSaveRepository": pkgapplication.NewDecorator2("metrics", "config", func(repository domain.ProductSaveRepository, metrics pkgapplication.Metrics, cfg *config.Config) domain.ProductSaveRepository {
+				return decorator.InstrumentSaveRepository(repository, metrics, cfg.Repository.Backend)
+			}),
+			"ProductFindRepository": pkgapplication.NewDecorator2("metrics", "config", func(repository domain.ProductFindRepository, metrics pkgapplication.Metrics, cfg *config.Config) domain.ProductFindRepository {
+				return decorator.InstrumentFindRepository(repository, metrics, cfg.Repository.Backend)
+			}),
+			"ProductFindAllRepository": pkgapplication.NewDecorator2("metrics", "config", func(repository domain.ProductFindAllRepository, metrics pkgapplication.Metrics, cfg *config.Config) domain.ProductFindAllRepository {
+				return decorator.InstrumentFindAllRepository(repository, metrics, cfg.Repository.Backend)
+			}),
+			"ProductDeleteRepository": pkgapplication.NewDecorator2("metrics", "config", func(repository domain.ProductDeleteRepository, metrics pkgapplication.Metrics, cfg *config.Config) domain.ProductDeleteRepository {
+				return decorator.InstrumentDeleteRepository(repository, metrics, cfg.Repository.Backend)
+			}),
+
+			"AddProductUseCase":     pkgapplication.NewDecorator1("metrics", application.InstrumentAddProductUseCase),
+			"DeleteProductUseCase":  pkgapplication.NewDecorator1("metrics", application.InstrumentDeleteProductUseCase),
+			"GetAllProductsUseCase": pkgapplication.NewDecorator1("metrics", application.InstrumentGetAllProductsUseCase),
+			"GetProductUseCase":     pkgapplication.NewDecorator1("metrics", application.InstrumentGetProductUseCase),
+			"UpdateProductUseCase":  pkgapplication.NewDecorator1("metrics", application.InstrumentUpdateProductUseCase),
+		},
+	}
+}
+
+// TracingModule wraps the repository calls, labelled with the configured
+// backend, the domain services and the use cases in spans of the
+// pkgapplication.Tracer registered as "tracer". It decorates the recipes of
+// a persistence module and of CatalogServicesModule, so it must be composed
+// after them, and after MetricsModule for the spans to time the metrics too.
+func TracingModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "tracing",
+		Decorators: map[string]pkgapplication.Decorator{
+			"ProductSaveRepository": pkgapplication.NewDecorator2("tracer", "config", func(repository domain.ProductSaveRepository, tracer pkgapplication.Tracer, cfg *config.Config) domain.ProductSaveRepository {
+				return decorator.TraceSaveRepository(repository, tracer, cfg.Repository.Backend)
+			}),
+			"ProductFindRepository": pkgapplication.NewDecorator2("tracer", "config", func(repository domain.ProductFindRepository, tracer pkgapplication.Tracer, cfg *config.Config) domain.ProductFindRepository {
+				return decorator.TraceFindRepository(repository, tracer, cfg.Repository.Backend)
+			}),
+			"ProductFindAllRepository": pkgapplication.NewDecorator2("tracer", "config", func(repository domain.ProductFindAllRepository, tracer pkgapplication.Tracer, cfg *config.Config) domain.ProductFindAllRepository {
+				return decorator.TraceFindAllRepository(repository, tracer, cfg.Repository.Backend)
+			}),
+			"ProductDeleteRepository": pkgapplication.NewDecorator2("tracer", "config", func(repository domain.ProductDeleteRepository, tracer pkgapplication.Tracer, cfg *config.Config) domain.ProductDeleteRepository {
+				return decorator.TraceDeleteRepository(repository, tracer, cfg.Repository.Backend)
+			}),
+
+			"ProductAdder":     pkgapplication.NewDecorator1("tracer", domain.TraceProductAdder),
+			"ProductDeleter":   pkgapplication.NewDecorator1("tracer", domain.TraceProductDeleter),
+			"ProductFinder":    pkgapplication.NewDecorator1("tracer", domain.TraceProductFinder),
+			"AllProductFinder": pkgapplication.NewDecorator1("tracer", domain.TraceAllProductFinder),
+			"ProductUpdater":   pkgapplication.NewDecorator1("tracer", domain.TraceProductUpdater),
+
+			"AddProductUseCase":     pkgapplication.NewDecorator1("tracer", application.TraceAddProductUseCase),
+			"DeleteProductUseCase":  pkgapplication.NewDecorator1("tracer", application.TraceDeleteProductUseCase),
+			"GetAllProductsUseCase": pkgapplication.NewDecorator1("tracer", application.TraceGetAllProductsUseCase),
+			"GetProductUseCase":     pkgapplication.NewDecorator1("tracer", application.TraceGetProductUseCase),
+			"UpdateProductUseCase":  pkgapplication.NewDecorator1("tracer", application.TraceUpdateProductUseCase),
+		},
+	}
+}
+
+// CacheModule serves product lookups and listings from a "ProductCache"
+// sized by the "config", and invalidates it on every save and delete. The
+// repository decorators share the cache, so the module must decorate all
+// four repositories of one persistence module. Composed after MetricsModule
+// and TracingModule, only the calls that reach the backend are measured.
+func CacheModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "cache",
+		Recipes: map[string]pkgapplication.Recipe{
+			"ProductCache": pkgapplication.NewRecipe1("config", func(cfg *config.Config) *decorator.ProductCache {
+				return decorator.NewProductCache(
+					decorator.WithCacheCapacity(int(cfg.Cache.Size)),
+					decorator.WithCacheTTL(cfg.Cache.TTL),
+					decorator.WithNegativeTTL(cfg.Cache.NegativeTTL),
+				)
+			}),
+		},
+		Decorators: map[string]pkgapplication.Decorator{
+			"ProductSaveRepository":    pkgapplication.NewDecorator1("ProductCache", decorator.CacheSaveRepository),
+			"ProductFindRepository":    pkgapplication.NewDecorator1("ProductCache", decorator.CacheFindRepository),
+			"ProductFindAllRepository": pkgapplication.NewDecorator1("ProductCache", decorator.CacheFindAllRepository),
+			"ProductDeleteRepository":  pkgapplication.NewDecorator1("ProductCache", decorator.CacheDeleteRepository),
+		},
+	}
+}
+
+// ResilienceModule runs the repository calls under the "RepositoryPolicy"
+// built by NewRepositoryPolicy from the "config" and "metrics": transient
+// failures are retried, every attempt is timed out and a backend that keeps
+// failing is cut off by the circuit breaker, with
+// domain.ErrRepositoryUnavailable returned in both cases. Composed after
+// MetricsModule and TracingModule, every attempt is measured and traced;
+// CacheModule goes after it so cache hits skip the policy.
+func ResilienceModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "resilience",
+		Recipes: map[string]pkgapplication.Recipe{
+			"RepositoryPolicy": pkgapplication.NewRecipe2("config", "metrics", NewRepositoryPolicy),
+		},
+		Decorators: map[string]pkgapplication.Decorator{
+			"ProductSaveRepository":    pkgapplication.NewDecorator1("RepositoryPolicy", decorator.ResilientSaveRepository),
+			"ProductFindRepository":    pkgapplication.NewDecorator1("RepositoryPolicy", decorator.ResilientFindRepository),
+			"ProductFindAllRepository": pkgapplication.NewDecorator1("RepositoryPolicy", decorator.ResilientFindAllRepository),
+			"ProductDeleteRepository":  pkgapplication.NewDecorator1("RepositoryPolicy", decorator.ResilientDeleteRepository),
+		},
+	}
+}
+
+// chaosErrors are the domain errors the chaos rules of the configuration
+// refer to by name.
+var chaosErrors = map[string]error{
+	config.ChaosErrorNotFound:      domain.ErrNotFoundProduct,
+	config.ChaosErrorAlreadyExists: domain.ErrAlreadyExistsProduct,
+	config.ChaosErrorRepository:    domain.ErrRepositoryProduct,
+	config.ChaosErrorUnavailable:   domain.ErrRepositoryUnavailable,
+}
+
+// ChaosModule injects the faults of the chaos rules in the "config" into
+// the repositories of a persistence module, and leaves them alone unless
+// chaos is enabled. It must be composed right after the persistence module,
+// so the faults look like the backend's own to the other decorators.
+func ChaosModule() pkgapplication.Module {
+	return pkgapplication.Module{
+		Name: "chaos",
+		Recipes: map[string]pkgapplication.Recipe{
+			"Chaos": {Dependencies: []string{"config"}, Factory: createChaos},
+		},
+		Decorators: map[string]pkgapplication.Decorator{
+			"ProductSaveRepository":    pkgapplication.NewDecorator1("Chaos", decorator.ChaosSaveRepository),
+			"ProductFindRepository":    pkgapplication.NewDecorator1("Chaos", decorator.ChaosFindRepository),
+			"ProductFindAllRepository": pkgapplication.NewDecorator1("Chaos", decorator.ChaosFindAllRepository),
+			"ProductDeleteRepository":  pkgapplication.NewDecorator1("Chaos", decorator.ChaosDeleteRepository),
+		},
+	}
+}
+
+func createChaos(dependencies map[string]interface{}) (interface{}, error) {
+	cfg, err := pkgapplication.Dependency[*config.Config](dependencies, "config")
+	if err != nil {
+		return nil, err
+	}
+	if !cfg.Chaos.Enabled {
+		return decorator.NewChaos(nil), nil
+	}
+
+	rules, err := cfg.Chaos.ParseRules()
+	if err != nil {
+		return nil, err
+	}
+	chaosRules := make([]decorator.ChaosRule, len(rules))
+	for i, rule := range rules {
+		chaosRules[i] = decorator.ChaosRule{
+			Operation: rule.Operation,
+			ProductID: domain.ProductID(rule.ProductID),
+			Rate:      rule.Rate,
+			Latency:   rule.Latency,
+			Hang:      rule.Error == config.ChaosErrorTimeout,
+			Err:       chaosErrors[rule.Error],
+			Limit:     rule.Limit,
+		}
+	}
+	return decorator.NewChaos(chaosRules), nil
+}
@@ -0,0 +1,365 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/application"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/domain"
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	memoryadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/memory/adapter"
+	lambdaadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/aws/adapter"
+	netadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/http/net/adapter"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkglambda "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+	domainmocks "github.com/mateusmacedo/go-sls-marketplace/test/domain/mocks"
+	"github.com/mateusmacedo/go-sls-marketplace/test/infrastructure/mocks"
+)
+
+func TestModules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	dbConn, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	store, err := memoryadapter.NewProductStore()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		services    map[string]interface{}
+		persistence []pkgapplication.Module
+		healthCheck string
+	}{
+		{
+			name:        "Gorm",
+			services:    map[string]interface{}{"dbConn": dbConn},
+			persistence: []pkgapplication.Module{GormPersistenceModule()},
+			healthCheck: "sqlite",
+		},
+		{
+			name:        "DynamoDB",
+			services:    map[string]interface{}{"dynamoDBAPI": mocks.NewMockDynamoDBAPI(ctrl), "dynamoTableName": "ProductsTable"},
+			persistence: []pkgapplication.Module{DynamoPersistenceModule()},
+			healthCheck: "dynamodb",
+		},
+		{
+			name:        "Memory",
+			services:    map[string]interface{}{"productStore": store},
+			persistence: []pkgapplication.Module{MemoryPersistenceModule()},
+			healthCheck: "memory",
+		},
+		{
+			name:        "DynamoDB from config",
+			services:    map[string]interface{}{"config": &config.Config{AWS: config.AWSConfig{Region: "us-east-1", ProductsTable: "ProductsTable"}}},
+			persistence: []pkgapplication.Module{DynamoClientModule(), DynamoPersistenceModule()},
+			healthCheck: "dynamodb",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator := pkgapplication.NewSimpleServiceLocator()
+			for name, service := range tt.services {
+				locator.Register(name, service)
+			}
+
+			factory, err := pkgapplication.Compose(locator, append(tt.persistence, CatalogServicesModule(), HealthModule())...)
+			assert.NoError(t, err)
+
+			_, err = pkgapplication.Create[application.AddProductUseCase](factory, "AddProductUseCase")
+			assert.NoError(t, err)
+			_, err = pkgapplication.Create[application.DeleteProductUseCase](factory, "DeleteProductUseCase")
+			assert.NoError(t, err)
+			_, err = pkgapplication.Create[application.GetAllProductsUseCase](factory, "GetAllProductsUseCase")
+			assert.NoError(t, err)
+			_, err = pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+			assert.NoError(t, err)
+			_, err = pkgapplication.Create[application.UpdateProductUseCase](factory, "UpdateProductUseCase")
+			assert.NoError(t, err)
+
+			check, err := pkgapplication.Create[pkgapplication.HealthCheck](factory, "RepositoryHealthCheck")
+			assert.NoError(t, err)
+			assert.Equal(t, tt.healthCheck, check.Name())
+			_, err = pkgapplication.Create[pkgapplication.HealthChecker](factory, "HealthChecker")
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestModulesReportMissingDependencies(t *testing.T) {
+	_, err := pkgapplication.Compose(pkgapplication.NewSimpleServiceLocator(), DynamoPersistenceModule(), CatalogServicesModule())
+	assert.ErrorContains(t, err, "module dynamo-persistence: recipe ProductFindRepository: dependency dynamoDBAPI not found")
+
+	_, err = pkgapplication.Compose(pkgapplication.NewSimpleServiceLocator(), CatalogServicesModule())
+	assert.ErrorContains(t, err, "module catalog-services: recipe ProductAdder: dependency ProductFindRepository not found")
+}
+
+func TestMetricsModule(t *testing.T) {
+	store, err := memoryadapter.NewProductStore()
+	assert.NoError(t, err)
+	registry := prometheus.NewRegistry()
+
+	locator := pkgapplication.NewSimpleServiceLocator()
+	locator.Register("productStore", store)
+	locator.Register("metrics", registry)
+	locator.Register("config", &config.Config{Repository: config.RepositoryConfig{Backend: config.BackendMemory}})
+
+	factory, err := pkgapplication.Compose(locator, MemoryPersistenceModule(), CatalogServicesModule(), MetricsModule())
+	assert.NoError(t, err)
+
+	useCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	assert.NoError(t, err)
+	_, err = useCase.Execute(context.Background(), application.GetProductInput{ID: "missing"})
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	assert.Contains(t, out.String(), `catalog_use_case_executions_total{use_case="GetProduct",outcome="not_found"} 1`)
+	assert.Contains(t, out.String(), `catalog_repository_operations_total{backend="memory",operation="find",outcome="not_found"} 1`)
+
+	_, err = pkgapplication.Compose(pkgapplication.NewSimpleServiceLocator(), MetricsModule())
+	assert.ErrorIs(t, err, pkgapplication.ErrNothingToDecorate)
+}
+
+func TestTracingModule(t *testing.T) {
+	store, err := memoryadapter.NewProductStore()
+	assert.NoError(t, err)
+	exporter := tracing.NewInMemoryExporter()
+	tracer := tracing.NewTracer(exporter, tracing.WithSyncExport())
+
+	locator := pkgapplication.NewSimpleServiceLocator()
+	locator.Register("productStore", store)
+	locator.Register("metrics", prometheus.NewRegistry())
+	locator.Register("tracer", tracer)
+	locator.Register("config", &config.Config{Repository: config.RepositoryConfig{Backend: config.BackendMemory}})
+
+	factory, err := pkgapplication.Compose(locator, MemoryPersistenceModule(), CatalogServicesModule(), MetricsModule(), TracingModule())
+	assert.NoError(t, err)
+
+	useCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	assert.NoError(t, err)
+	ctx, root := tracer.Start(context.Background(), "GET /products/{id}")
+	_, err = useCase.Execute(ctx, application.GetProductInput{ID: "missing"})
+	assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+	root.End()
+
+	spans := exporter.Spans()
+	names := make([]string, len(spans))
+	for i, span := range spans {
+		names[i] = span.Name
+	}
+	assert.Equal(t, []string{"ProductFindRepository.Find", "ProductFinder.GetProduct", "GetProductUseCase.Execute", "GET /products/{id}"}, names)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, spans[i+1].SpanID, spans[i].ParentSpanID)
+	}
+	assert.Equal(t, "memory", spans[0].Attribute("db.system"))
+
+	_, err = pkgapplication.Compose(pkgapplication.NewSimpleServiceLocator(), TracingModule())
+	assert.ErrorIs(t, err, pkgapplication.ErrNothingToDecorate)
+}
+
+func TestCacheModule(t *testing.T) {
+	store, err := memoryadapter.NewProductStore()
+	assert.NoError(t, err)
+	registry := prometheus.NewRegistry()
+
+	locator := pkgapplication.NewSimpleServiceLocator()
+	locator.Register("productStore", store)
+	locator.Register("metrics", registry)
+	locator.Register("config", &config.Config{
+		Cache:      config.CacheConfig{Enabled: true, Size: 10, TTL: time.Minute, NegativeTTL: time.Minute},
+		Repository: config.RepositoryConfig{Backend: config.BackendMemory},
+	})
+
+	factory, err := pkgapplication.Compose(locator, MemoryPersistenceModule(), CatalogServicesModule(), MetricsModule(), CacheModule())
+	assert.NoError(t, err)
+
+	getProduct, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	assert.NoError(t, err)
+	addProduct, err := pkgapplication.Create[application.AddProductUseCase](factory, "AddProductUseCase")
+	assert.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = getProduct.Execute(context.Background(), application.GetProductInput{ID: "1"})
+		assert.ErrorIs(t, err, domain.ErrNotFoundProduct)
+	}
+	_, err = addProduct.Execute(context.Background(), application.AddProductInput{ID: "1", Name: "Product", Description: "Description", Price: 10})
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err = getProduct.Execute(context.Background(), application.GetProductInput{ID: "1"})
+		assert.NoError(t, err, "the save invalidated the cached miss")
+	}
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	assert.Contains(t, out.String(), `catalog_repository_operations_total{backend="memory",operation="find",outcome="not_found"} 1`)
+	assert.Contains(t, out.String(), `catalog_repository_operations_total{backend="memory",operation="find",outcome="success"} 1`)
+}
+
+func TestResilienceModule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	find := domainmocks.NewMockProductFindRepository(ctrl)
+	find.EXPECT().Find(gomock.Any(), domain.ProductID("1")).Return(nil, sqlite3.Error{Code: sqlite3.ErrBusy}).Times(2)
+	registry := prometheus.NewRegistry()
+
+	locator := pkgapplication.NewSimpleServiceLocator()
+	locator.Register("metrics", registry)
+	locator.Register("config", &config.Config{
+		Resilience: config.ResilienceConfig{MaxAttempts: 2, BreakerThreshold: 2, BreakerCooldown: time.Minute},
+		Repository: config.RepositoryConfig{Backend: config.BackendSQLite},
+	})
+	persistence := pkgapplication.Module{
+		Name: "test-persistence",
+		Recipes: map[string]pkgapplication.Recipe{
+			"ProductFindRepository": {Factory: func(map[string]interface{}) (interface{}, error) { return find, nil }},
+		},
+	}
+	finderOnly := pkgapplication.Module{
+		Name: "finder",
+		Recipes: map[string]pkgapplication.Recipe{
+			"ProductFinder":     {Dependencies: []string{"ProductFindRepository"}, Factory: domain.CreateProductFinder},
+			"GetProductUseCase": {Dependencies: []string{"ProductFinder"}, Factory: application.CreateGetProductUseCase},
+		},
+	}
+	resilience := ResilienceModule()
+	for name := range resilience.Decorators {
+		if name != "ProductFindRepository" {
+			delete(resilience.Decorators, name)
+		}
+	}
+
+	factory, err := pkgapplication.Compose(locator, persistence, finderOnly, resilience)
+	assert.NoError(t, err)
+
+	useCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = useCase.Execute(context.Background(), application.GetProductInput{ID: "1"})
+		assert.Equal(t, domain.ErrRepositoryUnavailable, err, "the HTTP adapters answer it with 503")
+	}
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	assert.Contains(t, out.String(), `catalog_repository_retries_total{backend="sqlite",operation="find"} 1`)
+	assert.Contains(t, out.String(), `catalog_repository_rejections_total{backend="sqlite",operation="find"} 1`)
+	assert.Contains(t, out.String(), `catalog_repository_circuit_transitions_total{backend="sqlite",state="open"} 1`)
+	assert.Contains(t, out.String(), `catalog_repository_circuit_state{backend="sqlite"} 2`)
+}
+
+func TestChaosModule(t *testing.T) {
+	tests := []struct {
+		name         string
+		rules        []string
+		method       string
+		id           string
+		wantStatus   int
+		wantProducts int
+	}{
+		{name: "Not found", rules: []string{"operation=find id=2 error=not_found"}, method: http.MethodGet, id: "2", wantStatus: http.StatusNotFound},
+		{name: "Other product", rules: []string{"operation=find id=2 error=not_found"}, method: http.MethodGet, id: "1", wantStatus: http.StatusOK},
+		{name: "Repository error", rules: []string{"operation=find error=repository_error"}, method: http.MethodGet, id: "1", wantStatus: http.StatusInternalServerError},
+		{name: "Unavailable", rules: []string{"operation=delete error=unavailable"}, method: http.MethodDelete, id: "1", wantStatus: http.StatusServiceUnavailable},
+		{name: "Timeout", rules: []string{"operation=find error=timeout"}, method: http.MethodDelete, id: "1", wantStatus: http.StatusServiceUnavailable},
+		{name: "Partial listing", rules: []string{"operation=find_all limit=2"}, method: http.MethodGet, wantStatus: http.StatusOK, wantProducts: 2},
+		{name: "Failed listing", rules: []string{"operation=find_all rate=1 error=unavailable"}, method: http.MethodGet, wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := memoryadapter.NewProductStore()
+			assert.NoError(t, err)
+			for _, id := range []domain.ProductID{"1", "2", "3"} {
+				assert.NoError(t, store.Save(&domain.Product{ID: id, Name: "Product", Description: "Description", Price: 10}))
+			}
+
+			locator := pkgapplication.NewSimpleServiceLocator()
+			locator.Register("productStore", store)
+			locator.Register("metrics", prometheus.NewRegistry())
+			locator.Register("config", &config.Config{
+				Chaos:      config.ChaosConfig{Enabled: true, Rules: tt.rules},
+				Resilience: config.ResilienceConfig{Timeout: 10 * time.Millisecond},
+				Repository: config.RepositoryConfig{Backend: config.BackendMemory},
+			})
+
+			factory, err := pkgapplication.Compose(locator, MemoryPersistenceModule(), ChaosModule(), CatalogServicesModule(), ResilienceModule())
+			assert.NoError(t, err)
+
+			var handler http.HandlerFunc
+			var serve func(context.Context, pkglambda.Request) (pkglambda.Response, error)
+			switch {
+			case tt.method == http.MethodDelete:
+				useCase, err := pkgapplication.Create[application.DeleteProductUseCase](factory, "DeleteProductUseCase")
+				assert.NoError(t, err)
+				handler = netadapter.NewNetHTTPDeleteProductAdapter(useCase).Handle
+				serve = lambdaadapter.NewLambdaDeleteProductAdapter(useCase).Serve
+			case tt.id != "":
+				useCase, err := pkgapplication.Create[application.GetProductUseCase](factory, "GetProductUseCase")
+				assert.NoError(t, err)
+				handler = netadapter.NewNetHTTPGetProductAdapter(useCase).Handle
+				serve = lambdaadapter.NewLambdaGetProductUseCaseAdapter(useCase).Serve
+			default:
+				useCase, err := pkgapplication.Create[application.GetAllProductsUseCase](factory, "GetAllProductsUseCase")
+				assert.NoError(t, err)
+				handler = netadapter.NewNetHTTPGetAllProductsAdapter(useCase).Handle
+				serve = lambdaadapter.NewLambdaGetAllProductsAdapter(useCase).Serve
+			}
+
+			path := "/products"
+			if tt.id != "" {
+				path += "/" + tt.id
+			}
+			rr := httptest.NewRecorder()
+			handler(rr, mux.SetURLVars(httptest.NewRequest(tt.method, path, nil), map[string]string{"id": tt.id}))
+			assert.Equal(t, tt.wantStatus, rr.Code, "net/http")
+
+			response, err := serve(context.Background(), pkglambda.Request{Method: tt.method, Path: path, PathParameters: map[string]string{"id": tt.id}})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, response.StatusCode, "lambda")
+
+			if tt.wantProducts > 0 {
+				var products []map[string]interface{}
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &products))
+				assert.Len(t, products, tt.wantProducts)
+				assert.NoError(t, json.Unmarshal([]byte(response.Body), &products))
+				assert.Len(t, products, tt.wantProducts)
+			}
+		})
+	}
+}
+
+func TestChaosModule_InvalidRules(t *testing.T) {
+	store, err := memoryadapter.NewProductStore()
+	assert.NoError(t, err)
+	locator := pkgapplication.NewSimpleServiceLocator()
+	locator.Register("productStore", store)
+	locator.Register("config", &config.Config{Chaos: config.ChaosConfig{Enabled: true, Rules: []string{"operation=find"}}})
+
+	factory, err := pkgapplication.Compose(locator, MemoryPersistenceModule(), ChaosModule())
+	assert.NoError(t, err)
+
+	_, err = factory.Create("ProductFindRepository")
+	assert.ErrorIs(t, err, config.ErrInvalidConfig)
+}
+
+func TestNewTracer(t *testing.T) {
+	assert.Equal(t, pkgapplication.NopTracer(), NewTracer(config.TracingConfig{Exporter: config.TracingExporterNone}, pkgapplication.NopLogger()))
+	assert.IsType(t, &tracing.Tracer{}, NewTracer(config.TracingConfig{Exporter: config.TracingExporterStdout}, pkgapplication.NopLogger()))
+	assert.IsType(t, &tracing.Tracer{}, NewTracer(config.TracingConfig{Exporter: config.TracingExporterOTLP, Endpoint: "http://localhost:4318/v1/traces"}, pkgapplication.NopLogger()))
+}
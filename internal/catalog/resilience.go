@@ -0,0 +1,48 @@
+package catalog
+
+import (
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	dynamodbadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/dynamodb/adapter"
+	dbadapter "github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/db/gorm/adapter"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/resilience"
+)
+
+// NewRepositoryPolicy builds the policy the repositories of the configured
+// backend run under, retrying the errors its adapter classifies as
+// transient, and records its retries, timeouts, rejected calls and circuit
+// transitions in metrics, labelled with the backend.
+func NewRepositoryPolicy(cfg *config.Config, metrics pkgapplication.Metrics) *resilience.Policy {
+	backend := cfg.Repository.Backend
+	retries := metrics.Counter("catalog_repository_retries_total", "Repository calls retried after a transient error, by backend and operation.", "backend", "operation")
+	timeouts := metrics.Counter("catalog_repository_timeouts_total", "Repository call attempts that timed out, by backend and operation.", "backend", "operation")
+	rejections := metrics.Counter("catalog_repository_rejections_total", "Repository calls failed fast by the open circuit, by backend and operation.", "backend", "operation")
+	transitions := metrics.Counter("catalog_repository_circuit_transitions_total", "Circuit breaker transitions by backend and new state.", "backend", "state")
+	state := metrics.Gauge("catalog_repository_circuit_state", "Circuit breaker state by backend: 0 closed, 1 half open, 2 open.", "backend")
+	state.Set(float64(resilience.StateClosed), backend)
+
+	opts := []resilience.Option{
+		resilience.WithMaxAttempts(int(cfg.Resilience.MaxAttempts)),
+		resilience.WithBackoff(cfg.Resilience.InitialBackoff, cfg.Resilience.MaxBackoff),
+		resilience.WithTimeout(cfg.Resilience.Timeout),
+		resilience.WithOperationTimeout("find_all", cfg.Resilience.FindAllTimeout),
+		resilience.OnRetry(func(operation string, err error) { retries.Inc(backend, operation) }),
+		resilience.OnTimeout(func(operation string) { timeouts.Inc(backend, operation) }),
+		resilience.OnRejected(func(operation string) { rejections.Inc(backend, operation) }),
+	}
+	switch backend {
+	case config.BackendDynamoDB:
+		opts = append(opts, resilience.WithTransient(dynamodbadapter.IsTransient))
+	case config.BackendSQLite, config.BackendPostgres:
+		opts = append(opts, resilience.WithTransient(dbadapter.IsTransient))
+	}
+	if cfg.Resilience.BreakerThreshold > 0 {
+		opts = append(opts, resilience.WithBreaker(resilience.NewBreaker(int(cfg.Resilience.BreakerThreshold), cfg.Resilience.BreakerCooldown,
+			resilience.OnStateChange(func(from, to resilience.State) {
+				transitions.Inc(backend, to.String())
+				state.Set(float64(to), backend)
+			}),
+		)))
+	}
+	return resilience.NewPolicy(opts...)
+}
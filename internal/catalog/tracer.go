@@ -0,0 +1,24 @@
+package catalog
+
+import (
+	"os"
+
+	"github.com/mateusmacedo/go-sls-marketplace/internal/catalog/infrastructure/config"
+	pkgapplication "github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+)
+
+// NewTracer builds the tracer described by cfg. Failed exports are reported
+// to logger. Without an exporter the tracer records nothing.
+func NewTracer(cfg config.TracingConfig, logger pkgapplication.Logger) pkgapplication.Tracer {
+	var exporter tracing.Exporter
+	switch cfg.Exporter {
+	case config.TracingExporterStdout:
+		exporter = tracing.NewStdoutExporter(os.Stdout)
+	case config.TracingExporterOTLP:
+		exporter = tracing.NewOTLPExporter(cfg.Endpoint)
+	default:
+		return pkgapplication.NopTracer()
+	}
+	return tracing.NewTracer(exporter, tracing.WithServiceName(cfg.ServiceName), tracing.WithLogger(logger))
+}
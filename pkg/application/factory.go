@@ -1,51 +1,546 @@
 package application
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
 )
 
+var (
+	ErrDependencyCycle   = errors.New("dependency cycle detected")
+	ErrScopeRequired     = errors.New("scope required")
+	ErrCaptiveDependency = errors.New("captive dependency")
+	ErrContainerClosed   = errors.New("container closed")
+	ErrDuplicateRecipe   = errors.New("duplicate recipe")
+	ErrNothingToDecorate = errors.New("nothing to decorate")
+)
+
+// Lifetime controls how often a recipe is built.
+type Lifetime int
+
+const (
+	// Singleton recipes are built once per factory.
+	Singleton Lifetime = iota
+	// Transient recipes are built every time they are resolved.
+	Transient
+	// Scoped recipes are built once per Scope and cannot be created outside one.
+	Scoped
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "singleton"
+	case Transient:
+		return "transient"
+	case Scoped:
+		return "scoped"
+	default:
+		return fmt.Sprintf("Lifetime(%d)", int(l))
+	}
+}
+
 type Recipe struct {
 	Dependencies []string
 	Factory      func(dependencies map[string]interface{}) (interface{}, error)
+	Lifetime     Lifetime
+	// Close releases an instance built by Factory on shutdown. When nil,
+	// instances implementing io.Closer are closed with their Close method.
+	Close func(instance interface{}) error
+}
+
+// Creator builds named components.
+type Creator interface {
+	Create(name string) (interface{}, error)
 }
 
 type Factory interface {
+	Creator
 	RegisterRecipe(name string, recipe Recipe)
-	Create(name string) (interface{}, error)
+	// RegisterModule registers every recipe of module and remembers which
+	// module declared it so validation errors can name it.
+	RegisterModule(module Module) error
+	// DeclareScopeValue records that every Scope registers name, so Validate
+	// accepts it as a dependency of scoped and transient recipes.
+	DeclareScopeValue(name string)
+	Validate() error
+	NewScope() Scope
+	// Close releases the singletons built so far in reverse creation order.
+	Close() error
+}
+
+// Scope builds scoped recipes once and carries request-bound values such as
+// a logger with a correlation ID. It must be closed when the request ends.
+type Scope interface {
+	Creator
+	Register(name string, value interface{})
+	Close() error
+}
+
+// instances holds what the factory or a scope has built. Each name is built
+// once: concurrent creations of a name being built wait for that build, and
+// builds of different names run in parallel.
+type instances struct {
+	built    map[string]interface{}
+	building map[string]*build
+	closers  []namedCloser
+	closed   bool
+	mu       sync.Mutex
+}
+
+type build struct {
+	done     chan struct{}
+	instance interface{}
+	err      error
+}
+
+type namedCloser struct {
+	name  string
+	close func() error
+}
+
+func newInstances() *instances {
+	return &instances{built: make(map[string]interface{}), building: make(map[string]*build)}
+}
+
+func (i *instances) isClosed() bool {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.closed
+}
+
+func (i *instances) forget(name string) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delete(i.built, name)
+}
+
+// once returns the instance built for name, or builds it with fn unless
+// another goroutine is already doing so.
+func (i *instances) once(name string, recipe Recipe, fn func() (interface{}, error)) (interface{}, error) {
+	i.mu.Lock()
+	if i.closed {
+		i.mu.Unlock()
+		return nil, ErrContainerClosed
+	}
+	if instance, exists := i.built[name]; exists {
+		i.mu.Unlock()
+		return instance, nil
+	}
+	if b, exists := i.building[name]; exists {
+		i.mu.Unlock()
+		<-b.done
+		return b.instance, b.err
+	}
+	b := &build{done: make(chan struct{})}
+	i.building[name] = b
+	i.mu.Unlock()
+
+	b.instance, b.err = fn()
+	if b.err == nil {
+		b.err = i.keep(name, recipe, b.instance, true)
+		if b.err != nil {
+			b.instance = nil
+		}
+	}
+
+	i.mu.Lock()
+	delete(i.building, name)
+	i.mu.Unlock()
+	close(b.done)
+	return b.instance, b.err
+}
+
+// keep tracks instance so it is closed with i, and remembers it when cache
+// is set. An instance built after i was closed is closed right away.
+func (i *instances) keep(name string, recipe Recipe, instance interface{}, cache bool) error {
+	closer := closerOf(name, recipe, instance)
+
+	i.mu.Lock()
+	if i.closed {
+		i.mu.Unlock()
+		if closer != nil {
+			closer.close()
+		}
+		return ErrContainerClosed
+	}
+	if cache {
+		i.built[name] = instance
+	}
+	if closer != nil {
+		i.closers = append(i.closers, *closer)
+	}
+	i.mu.Unlock()
+	return nil
+}
+
+func closerOf(name string, recipe Recipe, instance interface{}) *namedCloser {
+	if recipe.Close != nil {
+		return &namedCloser{name, func() error { return recipe.Close(instance) }}
+	}
+	if closer, ok := instance.(io.Closer); ok {
+		return &namedCloser{name, closer.Close}
+	}
+	return nil
+}
+
+func (i *instances) close() error {
+	i.mu.Lock()
+	if i.closed {
+		i.mu.Unlock()
+		return nil
+	}
+	i.closed = true
+	closers := i.closers
+	i.built = nil
+	i.closers = nil
+	i.mu.Unlock()
+
+	var errs []error
+	for j := len(closers) - 1; j >= 0; j-- {
+		if err := closers[j].close(); err != nil {
+			errs = append(errs, fmt.Errorf("closing %s: %w", closers[j].name, err))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 type factory struct {
 	serviceLocator ServiceLocator
 	recipes        map[string]Recipe
+	modules        map[string]string
+	scopeValues    map[string]bool
+	root           *instances
+	logger         Logger
+	// mu guards the registrations; the instances have their own lock, so
+	// no lock is held while a recipe builds.
+	mu sync.RWMutex
+}
+
+type FactoryOption func(*factory)
+
+// WithFactoryLogger logs registrations and the instances built at debug
+// level. By default the factory uses the logger of its locator, if any.
+func WithFactoryLogger(logger Logger) FactoryOption {
+	return func(f *factory) {
+		f.logger = logger
+	}
 }
 
-func NewFactory(locator ServiceLocator) Factory {
-	return &factory{
+func NewFactory(locator ServiceLocator, opts ...FactoryOption) Factory {
+	f := &factory{
 		serviceLocator: locator,
 		recipes:        make(map[string]Recipe),
+		modules:        make(map[string]string),
+		scopeValues:    make(map[string]bool),
+		root:           newInstances(),
+		logger:         NopLogger(),
+	}
+	if l, ok := locator.(interface{ Logger() Logger }); ok {
+		f.logger = l.Logger()
 	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
 }
 
 func (f *factory) RegisterRecipe(name string, recipe Recipe) {
-	fmt.Printf("Registering recipe: %s\n", name)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logger.Debug("Registering recipe", "name", name, "lifetime", recipe.Lifetime.String())
 	f.recipes[name] = recipe
+	delete(f.modules, name)
+	f.root.forget(name)
+}
+
+func (f *factory) RegisterModule(module Module) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	names := make([]string, 0, len(module.Recipes))
+	for name := range module.Recipes {
+		if _, exists := f.recipes[name]; exists {
+			owner := f.modules[name]
+			if owner == "" {
+				return fmt.Errorf("%w: %s from module %s is already registered", ErrDuplicateRecipe, name, module.Name)
+			}
+			return fmt.Errorf("%w: %s is registered by modules %s and %s", ErrDuplicateRecipe, name, owner, module.Name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	decorated := make([]string, 0, len(module.Decorators))
+	for name := range module.Decorators {
+		if _, exists := f.recipes[name]; !exists {
+			if _, exists := module.Recipes[name]; !exists {
+				return fmt.Errorf("%w: module %s decorates %s, which has no recipe", ErrNothingToDecorate, module.Name, name)
+			}
+		}
+		decorated = append(decorated, name)
+	}
+	sort.Strings(decorated)
+
+	f.logger.Debug("Registering module", "module", module.Name, "recipes", names, "decorators", decorated)
+	for _, name := range names {
+		f.recipes[name] = module.Recipes[name]
+		f.modules[name] = module.Name
+	}
+	for _, name := range decorated {
+		f.recipes[name] = decorate(f.recipes[name], module.Decorators[name])
+		f.root.forget(name)
+	}
+	for _, name := range module.ScopeValues {
+		f.scopeValues[name] = true
+	}
+	return nil
+}
+
+func (f *factory) DeclareScopeValue(name string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.scopeValues[name] = true
 }
 
+// Create builds the named recipe, first building any dependency that is
+// itself a recipe. Dependencies without a recipe come from the service
+// locator. Scoped recipes can only be created through a Scope. Transient
+// instances are closed with the singleton or scope they were built for;
+// closing the ones created directly by Create is up to the caller.
 func (f *factory) Create(name string) (interface{}, error) {
-	recipe, exists := f.recipes[name]
+	if f.root.isClosed() {
+		return nil, ErrContainerClosed
+	}
+	recipe, exists := f.recipe(name)
 	if !exists {
 		return nil, fmt.Errorf("recipe %s not found", name)
 	}
+	return f.create(name, recipe, nil, nil, nil)
+}
+
+func (f *factory) recipe(name string) (Recipe, bool) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	recipe, exists := f.recipes[name]
+	return recipe, exists
+}
+
+func (f *factory) isScopeValue(name string) bool {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.scopeValues[name]
+}
+
+// create builds name within s, if any. owner closes the transient
+// instances built along the way; it is nil for the ones the caller owns.
+func (f *factory) create(name string, recipe Recipe, path []string, s *scope, owner *instances) (interface{}, error) {
+	path, err := visit(path, name)
+	if err != nil {
+		return nil, err
+	}
 
-	dependencies := make(map[string]interface{})
+	switch recipe.Lifetime {
+	case Singleton:
+		return f.root.once(name, recipe, func() (interface{}, error) {
+			return f.build(name, recipe, path, nil, f.root)
+		})
+	case Scoped:
+		if s == nil {
+			return nil, fmt.Errorf("%w: %s is scoped", ErrScopeRequired, name)
+		}
+		return s.instances.once(name, recipe, func() (interface{}, error) {
+			return f.build(name, recipe, path, s, s.instances)
+		})
+	}
+
+	instance, err := f.build(name, recipe, path, s, owner)
+	if err != nil || owner == nil {
+		return instance, err
+	}
+	if err := owner.keep(name, recipe, instance, false); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+func (f *factory) build(name string, recipe Recipe, path []string, s *scope, owner *instances) (interface{}, error) {
+	dependencies := make(map[string]interface{}, len(recipe.Dependencies))
 	for _, depName := range recipe.Dependencies {
-		dep, err := f.serviceLocator.Resolve(depName)
+		dep, err := f.resolve(name, recipe, depName, path, s, owner)
 		if err != nil {
 			return nil, err
 		}
 		dependencies[depName] = dep
 	}
 
-	fmt.Printf("Creating use case: %s\n", name)
+	f.logger.Debug("Creating instance", "name", name, "lifetime", recipe.Lifetime.String())
 	return recipe.Factory(dependencies)
 }
+
+func (f *factory) resolve(name string, recipe Recipe, depName string, path []string, s *scope, owner *instances) (interface{}, error) {
+	if recipe.Lifetime == Singleton && f.isScopeValue(depName) {
+		return nil, fmt.Errorf("%w: singleton %s depends on scope value %s", ErrCaptiveDependency, name, depName)
+	}
+	if s != nil {
+		if value, exists := s.value(depName); exists {
+			return value, nil
+		}
+	}
+	if dep, exists := f.recipe(depName); exists {
+		if recipe.Lifetime == Singleton && dep.Lifetime == Scoped {
+			return nil, fmt.Errorf("%w: singleton %s depends on scoped %s", ErrCaptiveDependency, name, depName)
+		}
+		return f.create(depName, dep, path, s, owner)
+	}
+	return f.serviceLocator.Resolve(depName)
+}
+
+// Validate walks the dependency graph of every registered recipe without
+// building anything and reports all cycles, unresolvable dependencies and
+// singletons that would capture scoped state.
+func (f *factory) Validate() error {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	names := make([]string, 0, len(f.recipes))
+	for name := range f.recipes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	reported := make(map[string]bool)
+	valid := make(map[string]bool)
+	for _, name := range names {
+		if err := f.validate(name, nil, valid); err != nil && !reported[err.Error()] {
+			reported[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f *factory) validate(name string, path []string, valid map[string]bool) error {
+	if valid[name] {
+		return nil
+	}
+
+	path, err := visit(path, name)
+	if err != nil {
+		return err
+	}
+
+	recipe := f.recipes[name]
+	for _, depName := range recipe.Dependencies {
+		if _, exists := f.recipes[depName]; exists {
+			if err := f.validate(depName, path, valid); err != nil {
+				return err
+			}
+			if recipe.Lifetime == Singleton && f.needsScope(depName) {
+				return fmt.Errorf("%w: singleton %s depends on scoped %s", ErrCaptiveDependency, name, depName)
+			}
+			continue
+		}
+		if f.scopeValues[depName] {
+			if recipe.Lifetime == Singleton {
+				return fmt.Errorf("%w: singleton %s depends on scope value %s", ErrCaptiveDependency, name, depName)
+			}
+			continue
+		}
+		if _, err := f.serviceLocator.Resolve(depName); err != nil {
+			if module := f.modules[name]; module != "" {
+				return fmt.Errorf("module %s: recipe %s: %w", module, name, err)
+			}
+			return fmt.Errorf("recipe %s: %w", name, err)
+		}
+	}
+
+	valid[name] = true
+	return nil
+}
+
+// needsScope reports whether building name requires a Scope, either because
+// it is scoped itself or because it is transient and needs scoped state.
+func (f *factory) needsScope(name string) bool {
+	recipe := f.recipes[name]
+	switch recipe.Lifetime {
+	case Scoped:
+		return true
+	case Transient:
+		for _, depName := range recipe.Dependencies {
+			if f.scopeValues[depName] {
+				return true
+			}
+			if _, exists := f.recipes[depName]; exists && f.needsScope(depName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (f *factory) NewScope() Scope {
+	return &scope{
+		factory:   f,
+		values:    make(map[string]interface{}),
+		instances: newInstances(),
+	}
+}
+
+func (f *factory) Close() error {
+	return f.root.close()
+}
+
+type scope struct {
+	factory   *factory
+	values    map[string]interface{}
+	instances *instances
+	mu        sync.RWMutex
+}
+
+func (s *scope) Register(name string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[name] = value
+}
+
+func (s *scope) value(name string) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, exists := s.values[name]
+	return value, exists
+}
+
+func (s *scope) Create(name string) (interface{}, error) {
+	if s.instances.isClosed() || s.factory.root.isClosed() {
+		return nil, ErrContainerClosed
+	}
+	if value, exists := s.value(name); exists {
+		return value, nil
+	}
+	recipe, exists := s.factory.recipe(name)
+	if !exists {
+		return nil, fmt.Errorf("recipe %s not found", name)
+	}
+	return s.factory.create(name, recipe, nil, s, s.instances)
+}
+
+// Close releases the scoped and transient instances built in this scope in
+// reverse creation order. Singletons are left to the factory.
+func (s *scope) Close() error {
+	return s.instances.close()
+}
+
+func visit(path []string, name string) ([]string, error) {
+	for i, visited := range path {
+		if visited == name {
+			cycle := append(append([]string{}, path[i:]...), name)
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+	}
+	return append(path, name), nil
+}
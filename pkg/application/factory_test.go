@@ -1,7 +1,9 @@
 package application
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"testing"
 )
 
@@ -114,3 +116,405 @@ func TestFactory_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestFactory_CreateResolvesNestedRecipes(t *testing.T) {
+	locator := NewMockServiceLocator(map[string]interface{}{"Config": "cfg"}, nil)
+	factory := NewFactory(locator)
+
+	builds := map[string]int{}
+	recipe := func(name string, dependencies ...string) Recipe {
+		return Recipe{
+			Dependencies: dependencies,
+			Factory: func(resolved map[string]interface{}) (interface{}, error) {
+				builds[name]++
+				parts := make([]string, 0, len(dependencies))
+				for _, dependency := range dependencies {
+					parts = append(parts, fmt.Sprint(resolved[dependency]))
+				}
+				return fmt.Sprintf("%s%v", name, parts), nil
+			},
+		}
+	}
+	factory.RegisterRecipe("Repository", recipe("Repository", "Config"))
+	factory.RegisterRecipe("Service", recipe("Service", "Repository"))
+	factory.RegisterRecipe("UseCase", recipe("UseCase", "Service", "Repository"))
+
+	result, err := factory.Create("UseCase")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "UseCase[Service[Repository[cfg]] Repository[cfg]]"
+	if result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	if _, err := factory.Create("Service"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for name, count := range builds {
+		if count != 1 {
+			t.Errorf("expected %s to be built once, got %d", name, count)
+		}
+	}
+}
+
+func TestFactory_CreateDoesNotCacheFailures(t *testing.T) {
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+
+	attempts := 0
+	factory.RegisterRecipe("Flaky", Recipe{
+		Factory: func(map[string]interface{}) (interface{}, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, fmt.Errorf("temporary failure")
+			}
+			return "ok", nil
+		},
+	})
+
+	if _, err := factory.Create("Flaky"); err == nil {
+		t.Fatal("expected the first attempt to fail")
+	}
+	if result, err := factory.Create("Flaky"); err != nil || result != "ok" {
+		t.Errorf("expected ok, got %v, %v", result, err)
+	}
+}
+
+func TestFactory_CycleDetection(t *testing.T) {
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	build := func(map[string]interface{}) (interface{}, error) { return "built", nil }
+	factory.RegisterRecipe("A", Recipe{Dependencies: []string{"B"}, Factory: build})
+	factory.RegisterRecipe("B", Recipe{Dependencies: []string{"C"}, Factory: build})
+	factory.RegisterRecipe("C", Recipe{Dependencies: []string{"A"}, Factory: build})
+	factory.RegisterRecipe("Self", Recipe{Dependencies: []string{"Self"}, Factory: build})
+
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"B", "dependency cycle detected: B -> C -> A -> B"},
+		{"Self", "dependency cycle detected: Self -> Self"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := factory.Create(tt.name)
+			if !errors.Is(err, ErrDependencyCycle) || err.Error() != tt.expected {
+				t.Errorf("expected error %q, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestFactory_Validate(t *testing.T) {
+	build := func(map[string]interface{}) (interface{}, error) {
+		t.Fatal("validate must not build recipes")
+		return nil, nil
+	}
+
+	tests := []struct {
+		name     string
+		recipes  map[string]Recipe
+		expected []string
+	}{
+		{
+			name: "Valid graph",
+			recipes: map[string]Recipe{
+				"Service": {Dependencies: []string{"Repository"}, Factory: build},
+				"UseCase": {Dependencies: []string{"Service", "Repository"}, Factory: build},
+			},
+		},
+		{
+			name: "Missing dependency",
+			recipes: map[string]Recipe{
+				"Service": {Dependencies: []string{"Clock"}, Factory: build},
+				"UseCase": {Dependencies: []string{"Service"}, Factory: build},
+			},
+			expected: []string{"recipe Service: service Clock not found"},
+		},
+		{
+			name: "Cycles and missing dependencies are all reported",
+			recipes: map[string]Recipe{
+				"A":       {Dependencies: []string{"B"}, Factory: build},
+				"B":       {Dependencies: []string{"A"}, Factory: build},
+				"Service": {Dependencies: []string{"Clock"}, Factory: build},
+			},
+			expected: []string{
+				"dependency cycle detected: A -> B -> A",
+				"dependency cycle detected: B -> A -> B",
+				"recipe Service: service Clock not found",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory(NewMockServiceLocator(map[string]interface{}{"Repository": "repo"}, nil))
+			for name, recipe := range tt.recipes {
+				factory.RegisterRecipe(name, recipe)
+			}
+
+			err := factory.Validate()
+
+			if len(tt.expected) == 0 {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != strings.Join(tt.expected, "\n") {
+				t.Errorf("expected error %q, got %v", strings.Join(tt.expected, "\n"), err)
+			}
+		})
+	}
+}
+
+type recordingCloser struct {
+	name   string
+	closed *[]string
+	err    error
+}
+
+func (c *recordingCloser) Close() error {
+	*c.closed = append(*c.closed, c.name)
+	return c.err
+}
+
+func TestFactory_Lifetimes(t *testing.T) {
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	factory.DeclareScopeValue("correlationID")
+
+	counter := 0
+	build := func(dependencies map[string]interface{}) (interface{}, error) {
+		counter++
+		return fmt.Sprintf("#%d%v", counter, dependencies["correlationID"]), nil
+	}
+	factory.RegisterRecipe("Singleton", Recipe{Factory: build})
+	factory.RegisterRecipe("Transient", Recipe{Factory: build, Lifetime: Transient})
+	factory.RegisterRecipe("Scoped", Recipe{Dependencies: []string{"correlationID"}, Factory: build, Lifetime: Scoped})
+
+	if err := factory.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, _ := factory.Create("Singleton")
+	second, _ := factory.Create("Singleton")
+	if first != second {
+		t.Errorf("expected the same singleton, got %v and %v", first, second)
+	}
+
+	first, _ = factory.Create("Transient")
+	second, _ = factory.Create("Transient")
+	if first == second {
+		t.Errorf("expected distinct transients, got %v twice", first)
+	}
+
+	if _, err := factory.Create("Scoped"); !errors.Is(err, ErrScopeRequired) {
+		t.Errorf("expected %v, got %v", ErrScopeRequired, err)
+	}
+
+	scopeA := factory.NewScope()
+	scopeA.Register("correlationID", "a")
+	scopeB := factory.NewScope()
+	scopeB.Register("correlationID", "b")
+
+	a1, _ := scopeA.Create("Scoped")
+	a2, _ := scopeA.Create("Scoped")
+	b1, _ := scopeB.Create("Scoped")
+	if a1 != a2 {
+		t.Errorf("expected the same instance within a scope, got %v and %v", a1, a2)
+	}
+	if a1 == b1 || !strings.HasSuffix(a1.(string), "a") || !strings.HasSuffix(b1.(string), "b") {
+		t.Errorf("expected one instance per scope, got %v and %v", a1, b1)
+	}
+
+	if value, err := scopeA.Create("correlationID"); err != nil || value != "a" {
+		t.Errorf("expected scope value a, got %v, %v", value, err)
+	}
+	if singleton, _ := scopeA.Create("Singleton"); singleton != "#1<nil>" {
+		t.Errorf("expected the factory singleton, got %v", singleton)
+	}
+}
+
+func TestFactory_CaptiveDependencies(t *testing.T) {
+	build := func(map[string]interface{}) (interface{}, error) { return "built", nil }
+
+	tests := []struct {
+		name     string
+		recipes  map[string]Recipe
+		create   string
+		expected string
+	}{
+		{
+			name: "Singleton depending on scoped",
+			recipes: map[string]Recipe{
+				"Service": {Dependencies: []string{"Session"}, Factory: build},
+				"Session": {Factory: build, Lifetime: Scoped},
+			},
+			create:   "Service",
+			expected: "captive dependency: singleton Service depends on scoped Session",
+		},
+		{
+			name: "Singleton depending on a scope value",
+			recipes: map[string]Recipe{
+				"Service": {Dependencies: []string{"logger"}, Factory: build},
+			},
+			create:   "Service",
+			expected: "captive dependency: singleton Service depends on scope value logger",
+		},
+		{
+			name: "Singleton depending on scoped through a transient",
+			recipes: map[string]Recipe{
+				"Service": {Dependencies: []string{"Handler"}, Factory: build},
+				"Handler": {Dependencies: []string{"Session"}, Factory: build, Lifetime: Transient},
+				"Session": {Factory: build, Lifetime: Scoped},
+			},
+			create:   "Service",
+			expected: "captive dependency: singleton Service depends on scoped Handler",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+			factory.DeclareScopeValue("logger")
+			for name, recipe := range tt.recipes {
+				factory.RegisterRecipe(name, recipe)
+			}
+
+			if err := factory.Validate(); !errors.Is(err, ErrCaptiveDependency) || err.Error() != tt.expected {
+				t.Errorf("expected validation error %q, got %v", tt.expected, err)
+			}
+
+			scope := factory.NewScope()
+			scope.Register("logger", "logger")
+			if _, err := scope.Create(tt.create); !errors.Is(err, ErrCaptiveDependency) && !errors.Is(err, ErrScopeRequired) {
+				t.Errorf("expected a captive dependency error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestFactory_Close(t *testing.T) {
+	var closed []string
+	closer := func(name string, err error) Recipe {
+		return Recipe{Factory: func(map[string]interface{}) (interface{}, error) {
+			return &recordingCloser{name: name, closed: &closed, err: err}, nil
+		}}
+	}
+
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	factory.RegisterRecipe("Database", closer("Database", nil))
+	repository := closer("Repository", fmt.Errorf("flush failed"))
+	repository.Dependencies = []string{"Database"}
+	factory.RegisterRecipe("Repository", repository)
+	factory.RegisterRecipe("Cache", Recipe{
+		Dependencies: []string{"Repository"},
+		Factory:      func(map[string]interface{}) (interface{}, error) { return "cache", nil },
+		Close: func(instance interface{}) error {
+			closed = append(closed, fmt.Sprint(instance))
+			return nil
+		},
+	})
+	factory.RegisterRecipe("Unused", closer("Unused", nil))
+	session := closer("Session", nil)
+	session.Lifetime = Scoped
+	session.Dependencies = []string{"Cache"}
+	factory.RegisterRecipe("Session", session)
+
+	scope := factory.NewScope()
+	if _, err := scope.Create("Session"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := scope.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := scope.Close(); err != nil {
+		t.Fatalf("expected closing twice to be a no-op, got %v", err)
+	}
+	if _, err := scope.Create("Session"); !errors.Is(err, ErrContainerClosed) {
+		t.Errorf("expected %v, got %v", ErrContainerClosed, err)
+	}
+
+	err := factory.Close()
+	if err == nil || err.Error() != "closing Repository: flush failed" {
+		t.Errorf("expected the repository close error, got %v", err)
+	}
+
+	expected := []string{"Session", "cache", "Repository", "Database"}
+	if strings.Join(closed, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected close order %v, got %v", expected, closed)
+	}
+	if _, err := factory.Create("Database"); !errors.Is(err, ErrContainerClosed) {
+		t.Errorf("expected %v, got %v", ErrContainerClosed, err)
+	}
+}
+
+func TestFactory_BuildsWithoutHoldingTheFactory(t *testing.T) {
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	started := make(chan struct{})
+	release := make(chan struct{})
+	builds := 0
+	factory.RegisterRecipe("Slow", Recipe{Factory: func(map[string]interface{}) (interface{}, error) {
+		builds++
+		close(started)
+		<-release
+		return "slow", nil
+	}})
+	factory.RegisterRecipe("Fast", Recipe{Factory: func(map[string]interface{}) (interface{}, error) { return "fast", nil }})
+	factory.RegisterRecipe("Lazy", Recipe{Factory: func(map[string]interface{}) (interface{}, error) {
+		// A factory may create other recipes itself.
+		return factory.Create("Fast")
+	}})
+
+	results := make(chan interface{}, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			instance, _ := factory.Create("Slow")
+			results <- instance
+		}()
+	}
+	<-started
+
+	if instance, err := factory.Create("Lazy"); err != nil || instance != "fast" {
+		t.Errorf("expected fast while Slow builds, got %v, %v", instance, err)
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if instance := <-results; instance != "slow" {
+			t.Errorf("expected slow, got %v", instance)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("expected the concurrent creations to share one build, got %d", builds)
+	}
+}
+
+func TestFactory_DoesNotTrackTransientsCreatedDirectly(t *testing.T) {
+	var closed []string
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	factory.RegisterRecipe("Connection", Recipe{Lifetime: Transient, Factory: func(map[string]interface{}) (interface{}, error) {
+		return &recordingCloser{name: "Connection", closed: &closed}, nil
+	}})
+	factory.RegisterRecipe("Pool", Recipe{Dependencies: []string{"Connection"}, Factory: func(map[string]interface{}) (interface{}, error) {
+		return "pool", nil
+	}})
+
+	for i := 0; i < 3; i++ {
+		if _, err := factory.Create("Connection"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if _, err := factory.Create("Pool"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := factory.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(closed, ",") != "Connection" {
+		t.Errorf("expected only the connection of the pool to be closed, got %v", closed)
+	}
+}
@@ -0,0 +1,175 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrDuplicateHealthCheck = errors.New("duplicate health check")
+
+type HealthStatus string
+
+const (
+	HealthUp   HealthStatus = "up"
+	HealthDown HealthStatus = "down"
+)
+
+// HealthCheck probes one dependency, such as a database, and returns an
+// error when it cannot serve requests.
+type HealthCheck interface {
+	Name() string
+	Check(ctx context.Context) error
+}
+
+// NewHealthCheck adapts check into a HealthCheck reported as name.
+func NewHealthCheck(name string, check func(ctx context.Context) error) HealthCheck {
+	return &healthCheck{name: name, check: check}
+}
+
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+func (c *healthCheck) Name() string {
+	return c.name
+}
+
+func (c *healthCheck) Check(ctx context.Context) error {
+	return c.check(ctx)
+}
+
+// CheckResult is the outcome of one HealthCheck.
+type CheckResult struct {
+	Name    string
+	Status  HealthStatus
+	Latency time.Duration
+	Error   string
+}
+
+func (r CheckResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Name      string       `json:"name"`
+		Status    HealthStatus `json:"status"`
+		Latency   string       `json:"latency"`
+		LatencyMs float64      `json:"latency_ms"`
+		Error     string       `json:"error,omitempty"`
+	}{r.Name, r.Status, r.Latency.String(), float64(r.Latency) / float64(time.Millisecond), r.Error})
+}
+
+// HealthReport is up only when every check is up.
+type HealthReport struct {
+	Status HealthStatus  `json:"status"`
+	Checks []CheckResult `json:"checks"`
+}
+
+func (r HealthReport) Healthy() bool {
+	return r.Status == HealthUp
+}
+
+// HealthChecker is a registry of health checks that runs them together.
+type HealthChecker interface {
+	Register(check HealthCheck) error
+	// Check runs every registered check concurrently, each bounded by the
+	// checker's timeout, and reports them sorted by name.
+	Check(ctx context.Context) HealthReport
+}
+
+type HealthCheckerOption func(*healthChecker)
+
+// WithCheckTimeout bounds how long a single check may run before it is
+// reported down. It defaults to two seconds.
+func WithCheckTimeout(timeout time.Duration) HealthCheckerOption {
+	return func(c *healthChecker) {
+		c.timeout = timeout
+	}
+}
+
+type healthChecker struct {
+	checks  map[string]HealthCheck
+	timeout time.Duration
+	mu      sync.RWMutex
+}
+
+func NewHealthChecker(opts ...HealthCheckerOption) HealthChecker {
+	c := &healthChecker{checks: make(map[string]HealthCheck), timeout: 2 * time.Second}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func (c *healthChecker) Register(check HealthCheck) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, exists := c.checks[check.Name()]; exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateHealthCheck, check.Name())
+	}
+	c.checks[check.Name()] = check
+	return nil
+}
+
+func (c *healthChecker) Check(ctx context.Context) HealthReport {
+	c.mu.RLock()
+	checks := make([]HealthCheck, 0, len(c.checks))
+	for _, check := range c.checks {
+		checks = append(checks, check)
+	}
+	c.mu.RUnlock()
+	sort.Slice(checks, func(i, j int) bool { return checks[i].Name() < checks[j].Name() })
+
+	report := HealthReport{Status: HealthUp, Checks: make([]CheckResult, len(checks))}
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	for _, result := range report.Checks {
+		if result.Status != HealthUp {
+			report.Status = HealthDown
+		}
+	}
+	return report
+}
+
+// run reports a check that ignores its context as down once the timeout
+// passes, leaving it to finish in the background.
+func (c *healthChecker) run(ctx context.Context, check HealthCheck) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				done <- fmt.Errorf("panic: %v", recovered)
+			}
+		}()
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	result := CheckResult{Name: check.Name(), Status: HealthUp, Latency: time.Since(start)}
+	if err != nil {
+		result.Status, result.Error = HealthDown, err.Error()
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthChecker_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		checks   []HealthCheck
+		expected HealthStatus
+		results  map[string]HealthStatus
+		errors   map[string]string
+	}{
+		{
+			name:     "no checks",
+			expected: HealthUp,
+			results:  map[string]HealthStatus{},
+		},
+		{
+			name: "all up",
+			checks: []HealthCheck{
+				NewHealthCheck("database", func(context.Context) error { return nil }),
+				NewHealthCheck("cache", func(context.Context) error { return nil }),
+			},
+			expected: HealthUp,
+			results:  map[string]HealthStatus{"cache": HealthUp, "database": HealthUp},
+		},
+		{
+			name: "one down",
+			checks: []HealthCheck{
+				NewHealthCheck("database", func(context.Context) error { return errors.New("connection refused") }),
+				NewHealthCheck("cache", func(context.Context) error { return nil }),
+			},
+			expected: HealthDown,
+			results:  map[string]HealthStatus{"cache": HealthUp, "database": HealthDown},
+			errors:   map[string]string{"database": "connection refused"},
+		},
+		{
+			name: "timeout",
+			checks: []HealthCheck{
+				NewHealthCheck("slow", func(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }),
+			},
+			expected: HealthDown,
+			results:  map[string]HealthStatus{"slow": HealthDown},
+			errors:   map[string]string{"slow": context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "ignores context",
+			checks: []HealthCheck{
+				NewHealthCheck("stuck", func(context.Context) error { time.Sleep(time.Second); return nil }),
+			},
+			expected: HealthDown,
+			results:  map[string]HealthStatus{"stuck": HealthDown},
+			errors:   map[string]string{"stuck": context.DeadlineExceeded.Error()},
+		},
+		{
+			name: "panic",
+			checks: []HealthCheck{
+				NewHealthCheck("broken", func(context.Context) error { panic("boom") }),
+			},
+			expected: HealthDown,
+			results:  map[string]HealthStatus{"broken": HealthDown},
+			errors:   map[string]string{"broken": "panic: boom"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewHealthChecker(WithCheckTimeout(50 * time.Millisecond))
+			for _, check := range tt.checks {
+				if err := checker.Register(check); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			report := checker.Check(context.Background())
+			if report.Status != tt.expected || report.Healthy() != (tt.expected == HealthUp) {
+				t.Errorf("expected status %s, got %s", tt.expected, report.Status)
+			}
+			if len(report.Checks) != len(tt.results) {
+				t.Fatalf("expected %d results, got %d", len(tt.results), len(report.Checks))
+			}
+			for i, result := range report.Checks {
+				if i > 0 && report.Checks[i-1].Name > result.Name {
+					t.Errorf("results are not sorted by name: %v", report.Checks)
+				}
+				if result.Status != tt.results[result.Name] {
+					t.Errorf("expected %s to be %s, got %s", result.Name, tt.results[result.Name], result.Status)
+				}
+				if result.Error != tt.errors[result.Name] {
+					t.Errorf("expected %s error %q, got %q", result.Name, tt.errors[result.Name], result.Error)
+				}
+				if result.Latency <= 0 || result.Latency > 500*time.Millisecond {
+					t.Errorf("unexpected latency %s for %s", result.Latency, result.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestHealthChecker_RegisterDuplicate(t *testing.T) {
+	checker := NewHealthChecker()
+	check := NewHealthCheck("database", func(context.Context) error { return nil })
+
+	if err := checker.Register(check); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := checker.Register(check); !errors.Is(err, ErrDuplicateHealthCheck) {
+		t.Errorf("expected ErrDuplicateHealthCheck, got %v", err)
+	}
+}
+
+func TestHealthReport_MarshalJSON(t *testing.T) {
+	report := HealthReport{Status: HealthDown, Checks: []CheckResult{
+		{Name: "database", Status: HealthDown, Latency: 1500 * time.Microsecond, Error: "connection refused"},
+		{Name: "cache", Status: HealthUp, Latency: time.Millisecond},
+	}}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := `{"status":"down","checks":[` +
+		`{"name":"database","status":"down","latency":"1.5ms","latency_ms":1.5,"error":"connection refused"},` +
+		`{"name":"cache","status":"up","latency":"1ms","latency_ms":1}]}`
+	if strings.TrimSpace(string(body)) != expected {
+		t.Errorf("expected %s, got %s", expected, body)
+	}
+}
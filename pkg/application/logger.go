@@ -1,6 +1,53 @@
 package application
 
+import (
+	"context"
+)
+
 type Logger interface {
+	Debug(msg string, keysAndValues ...interface{})
 	Info(msg string, keysAndValues ...interface{})
+	Warn(msg string, keysAndValues ...interface{})
 	Error(msg string, err error, keysAndValues ...interface{})
+	// With returns a child logger that adds keysAndValues to every entry.
+	With(keysAndValues ...interface{}) Logger
+}
+
+// NopLogger returns a Logger that discards every entry.
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...interface{}) {}
+
+func (nopLogger) Info(string, ...interface{}) {}
+
+func (nopLogger) Warn(string, ...interface{}) {}
+
+func (nopLogger) Error(string, error, ...interface{}) {}
+
+func (l nopLogger) With(...interface{}) Logger { return l }
+
+type loggerContextKey struct{}
+
+// IntoContext returns a copy of ctx carrying logger.
+func IntoContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger stored by IntoContext, then the logger of
+// the request scope in ctx, so entries carry the request's fields. Without
+// either it returns a NopLogger.
+func FromContext(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok {
+		return logger
+	}
+	if scope, ok := ScopeFromContext(ctx); ok {
+		if logger, err := Create[Logger](scope, LoggerKey); err == nil {
+			return logger
+		}
+	}
+	return NopLogger()
 }
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+func TestFromContext(t *testing.T) {
+	logger := &recordingLogger{}
+	factory := NewFactory(NewSimpleServiceLocator())
+
+	tests := []struct {
+		name     string
+		ctx      func() context.Context
+		expected []string
+	}{
+		{
+			name:     "Logger stored in the context",
+			ctx:      func() context.Context { return IntoContext(context.Background(), logger.With("request_id", "abc")) },
+			expected: []string{"info message[request_id abc]"},
+		},
+		{
+			name: "Logger of the request scope",
+			ctx: func() context.Context {
+				return ContextWithScope(context.Background(), NewRequestScope(factory, logger, "xyz"))
+			},
+			expected: []string{"info message[correlationID xyz]"},
+		},
+		{
+			name:     "No logger",
+			ctx:      context.Background,
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger.entries = nil
+
+			FromContext(tt.ctx()).Info("message")
+
+			if fmt.Sprint(logger.entries) != fmt.Sprint(tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, logger.entries)
+			}
+		})
+	}
+}
+
+func TestLoggerWith(t *testing.T) {
+	logger := &recordingLogger{}
+
+	child := logger.With("module", "catalog")
+	child.With("id", 1).Warn("nested")
+	child.Debug("child")
+	logger.Info("parent")
+
+	expected := []string{"warn nested[module catalog id 1]", "debug child[module catalog]", "info parent[]"}
+	if fmt.Sprint(logger.entries) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, logger.entries)
+	}
+}
+
+func TestInjectedLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	locator := NewSimpleServiceLocator(WithLocatorLogger(logger))
+	locator.Register("config", "cfg")
+
+	factory := NewFactory(locator)
+	factory.RegisterRecipe("Service", NewRecipe1("config", func(config string) string {
+		return "service " + config
+	}))
+	if _, err := factory.Create("Service"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"debug Registering dependency[name config]",
+		"debug Registering recipe[name Service lifetime singleton]",
+		"debug Resolving dependency[name config]",
+		"debug Creating instance[name Service lifetime singleton]",
+	}
+	if fmt.Sprint(logger.entries) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, logger.entries)
+	}
+
+	other := &recordingLogger{}
+	NewFactory(locator, WithFactoryLogger(other)).RegisterRecipe("Service", Recipe{})
+	if len(other.entries) != 1 {
+		t.Errorf("expected the factory logger to override the locator one, got %v", other.entries)
+	}
+}
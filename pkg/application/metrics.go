@@ -0,0 +1,52 @@
+package application
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the histogram
+// buckets used for request and query latencies.
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics creates named instruments. Label values are passed in the order
+// of the label names given when the instrument was created. Asking twice
+// for the same name returns the same instrument.
+type Metrics interface {
+	Counter(name, help string, labelNames ...string) Counter
+	// Histogram uses DefaultLatencyBuckets when buckets is nil.
+	Histogram(name, help string, buckets []float64, labelNames ...string) Histogram
+	Gauge(name, help string, labelNames ...string) Gauge
+}
+
+type Counter interface {
+	Inc(labelValues ...string)
+	Add(delta float64, labelValues ...string)
+}
+
+type Histogram interface {
+	Observe(value float64, labelValues ...string)
+}
+
+type Gauge interface {
+	Set(value float64, labelValues ...string)
+	Add(delta float64, labelValues ...string)
+}
+
+// NopMetrics returns Metrics whose instruments discard every observation.
+func NopMetrics() Metrics {
+	return nopMetrics{}
+}
+
+type nopMetrics struct{}
+
+func (nopMetrics) Counter(string, string, ...string) Counter { return nopInstrument{} }
+
+func (nopMetrics) Histogram(string, string, []float64, ...string) Histogram { return nopInstrument{} }
+
+func (nopMetrics) Gauge(string, string, ...string) Gauge { return nopInstrument{} }
+
+type nopInstrument struct{}
+
+func (nopInstrument) Inc(...string) {}
+
+func (nopInstrument) Add(float64, ...string) {}
+
+func (nopInstrument) Observe(float64, ...string) {}
+
+func (nopInstrument) Set(float64, ...string) {}
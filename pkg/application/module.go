@@ -0,0 +1,115 @@
+package application
+
+import "slices"
+
+// Module is a named bundle of recipes that are registered together, such as
+// the repositories of one persistence backend.
+type Module struct {
+	Name    string
+	Recipes map[string]Recipe
+	// Decorators wrap recipes registered by earlier modules, such as the
+	// repositories of a persistence module.
+	Decorators map[string]Decorator
+	// ScopeValues lists the names every request scope registers for the
+	// module's scoped recipes.
+	ScopeValues []string
+}
+
+// Decorator wraps the instance built by a recipe, for example to add
+// metrics or caching to a repository. The decorated instance replaces the
+// original everywhere, and decorators registered later wrap earlier ones.
+type Decorator struct {
+	// Dependencies are resolved like the dependencies of a recipe.
+	Dependencies []string
+	Decorate     func(instance interface{}, dependencies map[string]interface{}) (interface{}, error)
+}
+
+// NewDecorator1 adapts a function that wraps a T using one dependency into
+// a Decorator whose types are checked when it is applied.
+func NewDecorator1[T, A any](dependency string, decorate func(T, A) T) Decorator {
+	return Decorator{
+		Dependencies: []string{dependency},
+		Decorate: func(instance interface{}, dependencies map[string]interface{}) (interface{}, error) {
+			typed, err := As[T]("decorated instance", instance)
+			if err != nil {
+				return nil, err
+			}
+			a, err := Dependency[A](dependencies, dependency)
+			if err != nil {
+				return nil, err
+			}
+			return decorate(typed, a), nil
+		},
+	}
+}
+
+// NewDecorator2 is like NewDecorator1 with two dependencies.
+func NewDecorator2[T, A, B any](dependencyA, dependencyB string, decorate func(T, A, B) T) Decorator {
+	return Decorator{
+		Dependencies: []string{dependencyA, dependencyB},
+		Decorate: func(instance interface{}, dependencies map[string]interface{}) (interface{}, error) {
+			typed, err := As[T]("decorated instance", instance)
+			if err != nil {
+				return nil, err
+			}
+			a, err := Dependency[A](dependencies, dependencyA)
+			if err != nil {
+				return nil, err
+			}
+			b, err := Dependency[B](dependencies, dependencyB)
+			if err != nil {
+				return nil, err
+			}
+			return decorate(typed, a, b), nil
+		},
+	}
+}
+
+// decorate returns recipe with decorator applied to what it builds. The
+// decorator's dependencies join the recipe's, so validation and cycle
+// detection cover them.
+func decorate(recipe Recipe, decorator Decorator) Recipe {
+	build := recipe.Factory
+	dependencies := append([]string{}, recipe.Dependencies...)
+	for _, name := range decorator.Dependencies {
+		if !slices.Contains(dependencies, name) {
+			dependencies = append(dependencies, name)
+		}
+	}
+
+	recipe.Dependencies = dependencies
+	recipe.Factory = func(dependencies map[string]interface{}) (interface{}, error) {
+		instance, err := build(dependencies)
+		if err != nil {
+			return nil, err
+		}
+		return decorator.Decorate(instance, dependencies)
+	}
+	return recipe
+}
+
+// Compose builds a factory from modules and validates the resulting graph,
+// so a missing dependency is reported at startup together with the module
+// whose recipe needed it.
+func Compose(locator ServiceLocator, modules ...Module) (Factory, error) {
+	factory := NewFactory(locator)
+	for _, module := range modules {
+		if err := factory.RegisterModule(module); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := factory.Validate(); err != nil {
+		return nil, err
+	}
+
+	return factory, nil
+}
+
+// RequestScopeModule declares the values registered by NewRequestScope.
+func RequestScopeModule() Module {
+	return Module{
+		Name:        "request-scope",
+		ScopeValues: []string{LoggerKey, CorrelationIDKey},
+	}
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCompose(t *testing.T) {
+	build := func(map[string]interface{}) (interface{}, error) { return "built", nil }
+	persistence := Module{
+		Name:    "persistence",
+		Recipes: map[string]Recipe{"Repository": {Dependencies: []string{"dbConn"}, Factory: build}},
+	}
+	services := Module{
+		Name:    "services",
+		Recipes: map[string]Recipe{"Service": {Dependencies: []string{"Repository", LoggerKey}, Factory: build, Lifetime: Scoped}},
+	}
+
+	tests := []struct {
+		name        string
+		services    map[string]interface{}
+		modules     []Module
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "Composed modules",
+			services: map[string]interface{}{"dbConn": "db"},
+			modules:  []Module{persistence, services, RequestScopeModule()},
+		},
+		{
+			name:     "Missing dependency names the module",
+			services: map[string]interface{}{},
+			modules:  []Module{persistence, services, RequestScopeModule()},
+			expected: "module persistence: recipe Repository: service dbConn not found",
+		},
+		{
+			name:     "Undeclared scope value",
+			services: map[string]interface{}{"dbConn": "db"},
+			modules:  []Module{persistence, services},
+			expected: "module services: recipe Service: service logger not found",
+		},
+		{
+			name:        "Duplicate recipe",
+			services:    map[string]interface{}{"dbConn": "db"},
+			modules:     []Module{persistence, {Name: "other-persistence", Recipes: persistence.Recipes}},
+			expected:    "duplicate recipe: Repository is registered by modules persistence and other-persistence",
+			expectedErr: ErrDuplicateRecipe,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory, err := Compose(NewMockServiceLocator(tt.services, nil), tt.modules...)
+
+			if tt.expected != "" {
+				if err == nil || err.Error() != tt.expected {
+					t.Fatalf("expected error %q, got %v", tt.expected, err)
+				}
+				if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+					t.Errorf("expected error to wrap %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			scope := NewRequestScope(factory, &recordingLogger{}, "abc")
+			if _, err := scope.Create("Service"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestFactory_RegisterModuleConflictsWithRecipe(t *testing.T) {
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	factory.RegisterRecipe("Repository", Recipe{})
+
+	err := factory.RegisterModule(Module{Name: "persistence", Recipes: map[string]Recipe{"Repository": {}}})
+	if !errors.Is(err, ErrDuplicateRecipe) || err.Error() != "duplicate recipe: Repository from module persistence is already registered" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+type suffixed struct {
+	next   greeter
+	suffix string
+}
+
+func (s suffixed) Greet() string { return s.next.Greet() + s.suffix }
+
+func TestCompose_Decorators(t *testing.T) {
+	base := Module{
+		Name:    "base",
+		Recipes: map[string]Recipe{"Greeter": NewRecipe1("name", func(name string) greeter { return &englishGreeter{name: name} })},
+	}
+	decorator := func(moduleName, dependency string) Module {
+		return Module{
+			Name: moduleName,
+			Decorators: map[string]Decorator{
+				"Greeter": NewDecorator1(dependency, func(next greeter, suffix string) greeter { return suffixed{next, suffix} }),
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		modules     []Module
+		expected    string
+		expectedErr error
+	}{
+		{name: "Undecorated", modules: []Module{base}, expected: "hello world"},
+		{name: "Decorated", modules: []Module{base, decorator("exclaim", "exclamation")}, expected: "hello world!"},
+		{name: "Later decorators wrap earlier ones", modules: []Module{base, decorator("exclaim", "exclamation"), decorator("ask", "question")}, expected: "hello world!?"},
+		{name: "Missing decorator dependency", modules: []Module{base, decorator("exclaim", "missing")}, expected: "module base: recipe Greeter: service missing not found"},
+		{name: "Nothing to decorate", modules: []Module{decorator("exclaim", "exclamation")}, expected: "nothing to decorate: module exclaim decorates Greeter, which has no recipe", expectedErr: ErrNothingToDecorate},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator := NewMockServiceLocator(map[string]interface{}{"name": "world", "exclamation": "!", "question": "?"}, nil)
+			factory, err := Compose(locator, tt.modules...)
+			if err != nil {
+				if err.Error() != tt.expected {
+					t.Fatalf("expected error %q, got %v", tt.expected, err)
+				}
+				if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+					t.Errorf("expected error to wrap %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+
+			g, err := Create[greeter](factory, "Greeter")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if g.Greet() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, g.Greet())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// Names under which request scopes register their request-bound values.
+const (
+	LoggerKey        = "logger"
+	CorrelationIDKey = "correlationID"
+)
+
+type scopeContextKey struct{}
+
+// ContextWithScope returns a copy of ctx carrying scope.
+func ContextWithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeContextKey{}, scope)
+}
+
+// ScopeFromContext returns the scope stored by ContextWithScope, if any.
+func ScopeFromContext(ctx context.Context) (Scope, bool) {
+	scope, ok := ctx.Value(scopeContextKey{}).(Scope)
+	return scope, ok
+}
+
+// NewRequestScope opens a scope for a single request or invocation and
+// registers the correlation ID and a logger that tags every entry with it.
+func NewRequestScope(factory Factory, logger Logger, correlationID string) Scope {
+	scope := factory.NewScope()
+	scope.Register(CorrelationIDKey, correlationID)
+	scope.Register(LoggerKey, logger.With(CorrelationIDKey, correlationID))
+	return scope
+}
+
+// NewCorrelationID returns a random identifier for requests that arrive
+// without one.
+func NewCorrelationID() string {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id[:])
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+type recordingLogger struct {
+	entries []string
+	root    *recordingLogger
+	values  []interface{}
+}
+
+func (l *recordingLogger) record(entry string) {
+	root := l
+	if l.root != nil {
+		root = l.root
+	}
+	root.entries = append(root.entries, entry)
+}
+
+func (l *recordingLogger) Debug(msg string, keysAndValues ...interface{}) {
+	l.record(fmt.Sprint("debug ", msg, append(l.values, keysAndValues...)))
+}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(fmt.Sprint("info ", msg, append(l.values, keysAndValues...)))
+}
+
+func (l *recordingLogger) Warn(msg string, keysAndValues ...interface{}) {
+	l.record(fmt.Sprint("warn ", msg, append(l.values, keysAndValues...)))
+}
+
+func (l *recordingLogger) Error(msg string, err error, keysAndValues ...interface{}) {
+	l.record(fmt.Sprint("error ", msg, " ", err, append(l.values, keysAndValues...)))
+}
+
+func (l *recordingLogger) With(keysAndValues ...interface{}) Logger {
+	root := l
+	if l.root != nil {
+		root = l.root
+	}
+	values := append(append([]interface{}{}, l.values...), keysAndValues...)
+	return &recordingLogger{root: root, values: values}
+}
+
+func TestNewRequestScope(t *testing.T) {
+	factory := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil))
+	logger := &recordingLogger{}
+
+	scope := NewRequestScope(factory, logger, "abc")
+	defer scope.Close()
+
+	correlationID, err := Create[string](scope, CorrelationIDKey)
+	if err != nil || correlationID != "abc" {
+		t.Fatalf("expected correlation ID abc, got %q, %v", correlationID, err)
+	}
+
+	scopedLogger, err := Create[Logger](scope, LoggerKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	scopedLogger.Info("saved", "id", 1)
+	scopedLogger.Error("failed", fmt.Errorf("boom"))
+
+	expected := []string{"info saved[correlationID abc id 1]", "error failed boom [correlationID abc]"}
+	if fmt.Sprint(logger.entries) != fmt.Sprint(expected) {
+		t.Errorf("expected %v, got %v", expected, logger.entries)
+	}
+}
+
+func TestScopeFromContext(t *testing.T) {
+	if _, ok := ScopeFromContext(context.Background()); ok {
+		t.Error("expected no scope in an empty context")
+	}
+
+	scope := NewFactory(NewMockServiceLocator(map[string]interface{}{}, nil)).NewScope()
+	got, ok := ScopeFromContext(ContextWithScope(context.Background(), scope))
+	if !ok || got != scope {
+		t.Errorf("expected the stored scope, got %v", got)
+	}
+}
+
+func TestNewCorrelationID(t *testing.T) {
+	first, second := NewCorrelationID(), NewCorrelationID()
+	if len(first) != 32 || first == second {
+		t.Errorf("expected two distinct 32 character IDs, got %q and %q", first, second)
+	}
+}
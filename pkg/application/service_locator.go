@@ -12,19 +12,39 @@ type ServiceLocator interface {
 
 type serviceLocator struct {
 	dependencies map[string]interface{}
+	logger       Logger
 	mu           sync.RWMutex
 }
 
-func NewSimpleServiceLocator() *serviceLocator {
-	return &serviceLocator{
+type ServiceLocatorOption func(*serviceLocator)
+
+// WithLocatorLogger logs registrations and resolutions at debug level. A
+// factory composed from the locator logs through the same logger.
+func WithLocatorLogger(logger Logger) ServiceLocatorOption {
+	return func(sl *serviceLocator) {
+		sl.logger = logger
+	}
+}
+
+func NewSimpleServiceLocator(opts ...ServiceLocatorOption) *serviceLocator {
+	sl := &serviceLocator{
 		dependencies: make(map[string]interface{}),
+		logger:       NopLogger(),
 	}
+	for _, opt := range opts {
+		opt(sl)
+	}
+	return sl
+}
+
+func (sl *serviceLocator) Logger() Logger {
+	return sl.logger
 }
 
 func (sl *serviceLocator) Register(name string, dependency interface{}) {
 	sl.mu.Lock()
 	defer sl.mu.Unlock()
-	fmt.Printf("Registering dependency: %s\n", name)
+	sl.logger.Debug("Registering dependency", "name", name)
 	sl.dependencies[name] = dependency
 }
 
@@ -35,6 +55,6 @@ func (sl *serviceLocator) Resolve(name string) (interface{}, error) {
 	if !exists {
 		return nil, fmt.Errorf("dependency %s not found", name)
 	}
-	fmt.Printf("Resolving dependency: %s\n", name)
+	sl.logger.Debug("Resolving dependency", "name", name)
 	return dependency, nil
 }
@@ -0,0 +1,38 @@
+package application
+
+import "context"
+
+// Tracer starts spans that time a unit of work, such as an HTTP request, a
+// use case or a repository call. The span is a child of the span carried
+// by ctx, and the returned context carries the new span to the calls made
+// within the work. Attributes are key-value pairs, as for Logger.
+type Tracer interface {
+	Start(ctx context.Context, name string, kv ...interface{}) (context.Context, Span)
+}
+
+type Span interface {
+	SetAttributes(kv ...interface{})
+	// RecordError marks the span as failed with err. A nil err is ignored,
+	// so the error of the traced call can be passed as is.
+	RecordError(err error)
+	End()
+}
+
+// NopTracer returns a Tracer whose spans record nothing.
+func NopTracer() Tracer {
+	return nopTracer{}
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string, _ ...interface{}) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...interface{}) {}
+
+func (nopSpan) RecordError(error) {}
+
+func (nopSpan) End() {}
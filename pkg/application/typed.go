@@ -0,0 +1,146 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrDependencyNotFound     = errors.New("dependency not found")
+	ErrNilDependency          = errors.New("nil dependency")
+	ErrDependencyTypeMismatch = errors.New("dependency type mismatch")
+)
+
+// Resolve looks up name in the locator and asserts it to T, reporting the
+// expected and actual types instead of panicking on a mismatch.
+func Resolve[T any](locator ServiceLocator, name string) (T, error) {
+	dependency, err := locator.Resolve(name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return As[T](name, dependency)
+}
+
+// MustResolve is like Resolve but panics on error. It is meant for wiring
+// code in main packages where a missing dependency is a programming error.
+func MustResolve[T any](locator ServiceLocator, name string) T {
+	dependency, err := Resolve[T](locator, name)
+	if err != nil {
+		panic(err)
+	}
+	return dependency
+}
+
+// Create builds name with the factory or scope and asserts the result to T.
+func Create[T any](factory Creator, name string) (T, error) {
+	service, err := factory.Create(name)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return As[T](name, service)
+}
+
+// MustCreate is like Create but panics on error.
+func MustCreate[T any](factory Creator, name string) T {
+	service, err := Create[T](factory, name)
+	if err != nil {
+		panic(err)
+	}
+	return service
+}
+
+// Dependency extracts name from a recipe's dependency map as T.
+func Dependency[T any](dependencies map[string]interface{}, name string) (T, error) {
+	dependency, exists := dependencies[name]
+	if !exists {
+		var zero T
+		return zero, fmt.Errorf("%w: %s", ErrDependencyNotFound, name)
+	}
+	return As[T](name, dependency)
+}
+
+// As asserts that the dependency registered under name is a non-nil T.
+func As[T any](name string, dependency interface{}) (T, error) {
+	var zero T
+	if isNil(dependency) {
+		return zero, fmt.Errorf("%w: %s", ErrNilDependency, name)
+	}
+	typed, ok := dependency.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s is %T, expected %s", ErrDependencyTypeMismatch, name, dependency, typeName[T]())
+	}
+	return typed, nil
+}
+
+// NewRecipe1 adapts a single-argument constructor into a Recipe whose
+// dependency is checked against the constructor's parameter type.
+func NewRecipe1[A, R any](dependency string, constructor func(A) R) Recipe {
+	return Recipe{
+		Dependencies: []string{dependency},
+		Factory: func(dependencies map[string]interface{}) (interface{}, error) {
+			a, err := Dependency[A](dependencies, dependency)
+			if err != nil {
+				return nil, err
+			}
+			return constructor(a), nil
+		},
+	}
+}
+
+// NewRecipe2 adapts a two-argument constructor into a Recipe.
+func NewRecipe2[A, B, R any](dependencyA, dependencyB string, constructor func(A, B) R) Recipe {
+	return Recipe{
+		Dependencies: []string{dependencyA, dependencyB},
+		Factory: func(dependencies map[string]interface{}) (interface{}, error) {
+			a, err := Dependency[A](dependencies, dependencyA)
+			if err != nil {
+				return nil, err
+			}
+			b, err := Dependency[B](dependencies, dependencyB)
+			if err != nil {
+				return nil, err
+			}
+			return constructor(a, b), nil
+		},
+	}
+}
+
+// NewRecipe3 adapts a three-argument constructor into a Recipe.
+func NewRecipe3[A, B, C, R any](dependencyA, dependencyB, dependencyC string, constructor func(A, B, C) R) Recipe {
+	return Recipe{
+		Dependencies: []string{dependencyA, dependencyB, dependencyC},
+		Factory: func(dependencies map[string]interface{}) (interface{}, error) {
+			a, err := Dependency[A](dependencies, dependencyA)
+			if err != nil {
+				return nil, err
+			}
+			b, err := Dependency[B](dependencies, dependencyB)
+			if err != nil {
+				return nil, err
+			}
+			c, err := Dependency[C](dependencies, dependencyC)
+			if err != nil {
+				return nil, err
+			}
+			return constructor(a, b, c), nil
+		},
+	}
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Pointer, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+type greeter interface {
+	Greet() string
+}
+
+type englishGreeter struct{ name string }
+
+func (g *englishGreeter) Greet() string { return "hello " + g.name }
+
+func TestResolve(t *testing.T) {
+	var nilGreeter *englishGreeter
+
+	tests := []struct {
+		name        string
+		services    map[string]interface{}
+		serviceErr  error
+		key         string
+		expected    string
+		expectedErr error
+		message     string
+	}{
+		{
+			name:     "Matching type",
+			services: map[string]interface{}{"Greeter": &englishGreeter{name: "bob"}},
+			key:      "Greeter",
+			expected: "hello bob",
+		},
+		{
+			name:        "Locator error",
+			services:    map[string]interface{}{},
+			key:         "Greeter",
+			expectedErr: nil,
+			message:     "service Greeter not found",
+		},
+		{
+			name:        "Nil interface",
+			services:    map[string]interface{}{"Greeter": nil},
+			key:         "Greeter",
+			expectedErr: ErrNilDependency,
+			message:     "nil dependency: Greeter",
+		},
+		{
+			name:        "Typed nil pointer",
+			services:    map[string]interface{}{"Greeter": nilGreeter},
+			key:         "Greeter",
+			expectedErr: ErrNilDependency,
+			message:     "nil dependency: Greeter",
+		},
+		{
+			name:        "Type mismatch",
+			services:    map[string]interface{}{"Greeter": "not a greeter"},
+			key:         "Greeter",
+			expectedErr: ErrDependencyTypeMismatch,
+			message:     "dependency type mismatch: Greeter is string, expected application.greeter",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locator := NewMockServiceLocator(tt.services, tt.serviceErr)
+
+			result, err := Resolve[greeter](locator, tt.key)
+
+			if tt.message != "" {
+				if err == nil || err.Error() != tt.message {
+					t.Fatalf("expected error %q, got %v", tt.message, err)
+				}
+				if tt.expectedErr != nil && !errors.Is(err, tt.expectedErr) {
+					t.Errorf("expected error to wrap %v, got %v", tt.expectedErr, err)
+				}
+				if result != nil {
+					t.Errorf("expected zero value, got %v", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Greet() != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result.Greet())
+			}
+		})
+	}
+}
+
+func TestMustResolve(t *testing.T) {
+	locator := NewMockServiceLocator(map[string]interface{}{"Port": 8080}, nil)
+
+	if port := MustResolve[int](locator, "Port"); port != 8080 {
+		t.Errorf("expected 8080, got %d", port)
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrDependencyTypeMismatch) {
+			t.Errorf("expected a type mismatch panic, got %v", err)
+		}
+	}()
+	MustResolve[string](locator, "Port")
+}
+
+func TestCreate(t *testing.T) {
+	locator := NewMockServiceLocator(map[string]interface{}{"Name": "alice"}, nil)
+	factory := NewFactory(locator)
+	factory.RegisterRecipe("Greeter", NewRecipe1("Name", func(name string) greeter {
+		return &englishGreeter{name: name}
+	}))
+	factory.RegisterRecipe("Broken", NewRecipe1("Missing", func(name string) greeter {
+		return &englishGreeter{name: name}
+	}))
+
+	result, err := Create[greeter](factory, "Greeter")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Greet() != "hello alice" {
+		t.Errorf("expected %q, got %q", "hello alice", result.Greet())
+	}
+
+	if _, err := Create[fmt.Stringer](factory, "Greeter"); !errors.Is(err, ErrDependencyTypeMismatch) {
+		t.Errorf("expected type mismatch, got %v", err)
+	}
+
+	if _, err := Create[greeter](factory, "Broken"); err == nil || err.Error() != "service Missing not found" {
+		t.Errorf("expected locator error, got %v", err)
+	}
+
+	if MustCreate[greeter](factory, "Greeter") == nil {
+		t.Errorf("expected a greeter")
+	}
+}
+
+func TestRecipes(t *testing.T) {
+	join := func(parts ...string) string { return fmt.Sprint(parts) }
+
+	tests := []struct {
+		name         string
+		recipe       Recipe
+		dependencies map[string]interface{}
+		expected     interface{}
+		expectedErr  error
+	}{
+		{
+			name:         "One dependency",
+			recipe:       NewRecipe1("A", func(a string) string { return join(a) }),
+			dependencies: map[string]interface{}{"A": "a"},
+			expected:     "[a]",
+		},
+		{
+			name:         "Two dependencies",
+			recipe:       NewRecipe2("A", "B", func(a string, b int) string { return join(a, fmt.Sprint(b)) }),
+			dependencies: map[string]interface{}{"A": "a", "B": 2},
+			expected:     "[a 2]",
+		},
+		{
+			name:         "Three dependencies",
+			recipe:       NewRecipe3("A", "B", "C", func(a, b, c string) string { return join(a, b, c) }),
+			dependencies: map[string]interface{}{"A": "a", "B": "b", "C": "c"},
+			expected:     "[a b c]",
+		},
+		{
+			name:         "Missing dependency",
+			recipe:       NewRecipe2("A", "B", func(a, b string) string { return join(a, b) }),
+			dependencies: map[string]interface{}{"A": "a"},
+			expectedErr:  ErrDependencyNotFound,
+		},
+		{
+			name:         "Mismatched dependency",
+			recipe:       NewRecipe3("A", "B", "C", func(a, b, c string) string { return join(a, b, c) }),
+			dependencies: map[string]interface{}{"A": "a", "B": "b", "C": 3},
+			expectedErr:  ErrDependencyTypeMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.recipe.Factory(tt.dependencies)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("expected error %v, got %v", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,576 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	maxBatchWriteItems    = 25
+	maxTransactWriteItems = 100
+)
+
+type Client struct {
+	mu          sync.RWMutex
+	tables      map[string]*table
+	maxPageSize int
+}
+
+type Option func(*Client) error
+
+// WithMaxPageSize caps the items evaluated per Query or Scan page, standing in for DynamoDB's 1 MB page limit.
+func WithMaxPageSize(size int) Option {
+	return func(c *Client) error {
+		if size <= 0 {
+			return fmt.Errorf("max page size must be positive, got %d", size)
+		}
+		c.maxPageSize = size
+		return nil
+	}
+}
+
+func New(opts ...Option) (*Client, error) {
+	c := &Client{tables: make(map[string]*table)}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Client) CreateTable(ctx context.Context, params *dynamodb.CreateTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.CreateTableOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	name := aws.ToString(params.TableName)
+	if name == "" {
+		return nil, validationError("TableName is required")
+	}
+	if _, ok := c.tables[name]; ok {
+		return nil, &types.ResourceInUseException{Message: aws.String("Table already exists: " + name)}
+	}
+
+	key, err := newKeySchema(params.KeySchema)
+	if err != nil {
+		return nil, err
+	}
+	t := &table{
+		name:       name,
+		key:        key,
+		attributes: params.AttributeDefinitions,
+		indexes:    make(map[string]*index),
+		items:      make(map[string]map[string]types.AttributeValue),
+	}
+	for _, gsi := range params.GlobalSecondaryIndexes {
+		indexKey, err := newKeySchema(gsi.KeySchema)
+		if err != nil {
+			return nil, err
+		}
+		idx := &index{name: aws.ToString(gsi.IndexName), key: indexKey}
+		if gsi.Projection != nil {
+			idx.projection = *gsi.Projection
+		}
+		t.indexes[idx.name] = idx
+	}
+	c.tables[name] = t
+
+	return &dynamodb.CreateTableOutput{TableDescription: t.describe()}, nil
+}
+
+func (c *Client) DescribeTable(ctx context.Context, params *dynamodb.DescribeTableInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DescribeTableOutput, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t, err := c.table(params.TableName)
+	if err != nil {
+		return nil, err
+	}
+	return &dynamodb.DescribeTableOutput{Table: t.describe()}, nil
+}
+
+func (c *Client) PutItem(ctx context.Context, params *dynamodb.PutItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.PutItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w, err := c.preparePut(params.TableName, params.Item, params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues)
+	if err != nil {
+		return nil, withConditionItem(err, params.ReturnValuesOnConditionCheckFailure)
+	}
+	w.commit()
+
+	output := &dynamodb.PutItemOutput{}
+	if params.ReturnValues == types.ReturnValueAllOld && w.old != nil {
+		output.Attributes = copyItem(w.old)
+	}
+	return output, nil
+}
+
+func (c *Client) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t, err := c.table(params.TableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.keyOf(params.Key, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var projection []path
+	if params.ProjectionExpression != nil {
+		var p *parser
+		projection, p, err = parseProjection(*params.ProjectionExpression, params.ExpressionAttributeNames)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkUnused(params.ExpressionAttributeNames, nil, p); err != nil {
+			return nil, err
+		}
+	}
+
+	item, ok := t.items[key]
+	if !ok {
+		return &dynamodb.GetItemOutput{}, nil
+	}
+	if projection != nil {
+		return &dynamodb.GetItemOutput{Item: project(item, projection)}, nil
+	}
+	return &dynamodb.GetItemOutput{Item: copyItem(item)}, nil
+}
+
+func (c *Client) UpdateItem(ctx context.Context, params *dynamodb.UpdateItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.UpdateItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if params.AttributeUpdates != nil || params.Expected != nil {
+		return nil, validationError("Legacy AttributeUpdates and Expected parameters are not supported")
+	}
+
+	w, err := c.prepareUpdate(params.TableName, params.Key, params.UpdateExpression, params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues)
+	if err != nil {
+		return nil, withConditionItem(err, params.ReturnValuesOnConditionCheckFailure)
+	}
+	w.commit()
+
+	output := &dynamodb.UpdateItemOutput{}
+	switch params.ReturnValues {
+	case types.ReturnValueAllOld:
+		output.Attributes = copyItem(w.old)
+	case types.ReturnValueAllNew:
+		output.Attributes = copyItem(w.item)
+	case types.ReturnValueUpdatedOld:
+		output.Attributes = pick(w.old, w.touched)
+	case types.ReturnValueUpdatedNew:
+		output.Attributes = pick(w.item, w.touched)
+	}
+	return output, nil
+}
+
+func (c *Client) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w, err := c.prepareDelete(params.TableName, params.Key, params.ConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues)
+	if err != nil {
+		return nil, withConditionItem(err, params.ReturnValuesOnConditionCheckFailure)
+	}
+	w.commit()
+
+	output := &dynamodb.DeleteItemOutput{}
+	if params.ReturnValues == types.ReturnValueAllOld && w.old != nil {
+		output.Attributes = copyItem(w.old)
+	}
+	return output, nil
+}
+
+func (c *Client) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if params.KeyConditionExpression == nil {
+		return nil, validationError("Either the KeyConditions or KeyConditionExpression parameter must be specified in the request")
+	}
+
+	t, err := c.table(params.TableName)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := t.index(params.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	keyCondition, keyParser, err := parseCondition(*params.KeyConditionExpression, params.ExpressionAttributeNames, params.ExpressionAttributeValues)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateKeyCondition(keyCondition, t.accessKey(idx)); err != nil {
+		return nil, err
+	}
+
+	page, err := c.read(t, idx, readInput{
+		keyCondition:      keyCondition,
+		filter:            params.FilterExpression,
+		projection:        params.ProjectionExpression,
+		names:             params.ExpressionAttributeNames,
+		values:            params.ExpressionAttributeValues,
+		limit:             params.Limit,
+		exclusiveStartKey: params.ExclusiveStartKey,
+		descending:        params.ScanIndexForward != nil && !*params.ScanIndexForward,
+		countOnly:         params.Select == types.SelectCount,
+		parsers:           []*parser{keyParser},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dynamodb.QueryOutput{
+		Items:            page.items,
+		Count:            page.count,
+		ScannedCount:     page.scanned,
+		LastEvaluatedKey: page.lastEvaluatedKey,
+	}, nil
+}
+
+func (c *Client) Scan(ctx context.Context, params *dynamodb.ScanInput, optFns ...func(*dynamodb.Options)) (*dynamodb.ScanOutput, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	t, err := c.table(params.TableName)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := t.index(params.IndexName)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := c.read(t, idx, readInput{
+		filter:            params.FilterExpression,
+		projection:        params.ProjectionExpression,
+		names:             params.ExpressionAttributeNames,
+		values:            params.ExpressionAttributeValues,
+		limit:             params.Limit,
+		exclusiveStartKey: params.ExclusiveStartKey,
+		countOnly:         params.Select == types.SelectCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dynamodb.ScanOutput{
+		Items:            page.items,
+		Count:            page.count,
+		ScannedCount:     page.scanned,
+		LastEvaluatedKey: page.lastEvaluatedKey,
+	}, nil
+}
+
+func (c *Client) BatchWriteItem(ctx context.Context, params *dynamodb.BatchWriteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.BatchWriteItemOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	total := 0
+	for _, requests := range params.RequestItems {
+		total += len(requests)
+	}
+	if total == 0 || total > maxBatchWriteItems {
+		return nil, validationError(fmt.Sprintf("Too many items requested for the BatchWriteItem call: must have between 1 and %d items", maxBatchWriteItems))
+	}
+
+	tableNames := make([]string, 0, len(params.RequestItems))
+	for name := range params.RequestItems {
+		tableNames = append(tableNames, name)
+	}
+	sort.Strings(tableNames)
+
+	var writes []*write
+	seen := make(map[string]bool)
+	for _, name := range tableNames {
+		for _, request := range params.RequestItems[name] {
+			var (
+				w   *write
+				err error
+			)
+			switch {
+			case request.PutRequest != nil && request.DeleteRequest == nil:
+				w, err = c.preparePut(aws.String(name), request.PutRequest.Item, nil, nil, nil)
+			case request.DeleteRequest != nil && request.PutRequest == nil:
+				w, err = c.prepareDelete(aws.String(name), request.DeleteRequest.Key, nil, nil, nil)
+			default:
+				err = validationError("Supplied AttributeValue has more than one datatypes set, must contain exactly one of the supported datatypes")
+			}
+			if err != nil {
+				return nil, err
+			}
+			if seen[name+"\x00"+w.key] {
+				return nil, validationError("Provided list of item keys contains duplicates")
+			}
+			seen[name+"\x00"+w.key] = true
+			writes = append(writes, w)
+		}
+	}
+
+	for _, w := range writes {
+		w.commit()
+	}
+	return &dynamodb.BatchWriteItemOutput{UnprocessedItems: map[string][]types.WriteRequest{}}, nil
+}
+
+func (c *Client) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(params.TransactItems) == 0 || len(params.TransactItems) > maxTransactWriteItems {
+		return nil, validationError(fmt.Sprintf("Member must have length less than or equal to %d and greater than or equal to 1", maxTransactWriteItems))
+	}
+
+	writes := make([]*write, len(params.TransactItems))
+	reasons := make([]types.CancellationReason, len(params.TransactItems))
+	seen := make(map[string]bool)
+	cancelled := false
+
+	for i, item := range params.TransactItems {
+		var (
+			w   *write
+			err error
+		)
+		switch {
+		case item.Put != nil:
+			op := item.Put
+			w, err = c.preparePut(op.TableName, op.Item, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues)
+			err = withConditionItem(err, op.ReturnValuesOnConditionCheckFailure)
+		case item.Update != nil:
+			op := item.Update
+			w, err = c.prepareUpdate(op.TableName, op.Key, op.UpdateExpression, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues)
+			err = withConditionItem(err, op.ReturnValuesOnConditionCheckFailure)
+		case item.Delete != nil:
+			op := item.Delete
+			w, err = c.prepareDelete(op.TableName, op.Key, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues)
+			err = withConditionItem(err, op.ReturnValuesOnConditionCheckFailure)
+		case item.ConditionCheck != nil:
+			op := item.ConditionCheck
+			w, err = c.prepareConditionCheck(op.TableName, op.Key, op.ConditionExpression, op.ExpressionAttributeNames, op.ExpressionAttributeValues)
+			err = withConditionItem(err, op.ReturnValuesOnConditionCheckFailure)
+		default:
+			return nil, validationError("TransactItems can only contain one of Check, Put, Update or Delete")
+		}
+
+		reasons[i] = types.CancellationReason{Code: aws.String("None")}
+		if failed, ok := err.(*types.ConditionalCheckFailedException); ok {
+			reasons[i] = types.CancellationReason{Code: aws.String("ConditionalCheckFailed"), Message: failed.Message, Item: failed.Item}
+			cancelled = true
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		target := w.table.name + "\x00" + w.key
+		if seen[target] {
+			return nil, validationError("Transaction request cannot include multiple operations on one item")
+		}
+		seen[target] = true
+		writes[i] = w
+	}
+
+	if cancelled {
+		codes := make([]string, len(reasons))
+		for i, reason := range reasons {
+			codes[i] = aws.ToString(reason.Code)
+		}
+		return nil, &types.TransactionCanceledException{
+			Message:             aws.String(fmt.Sprintf("Transaction cancelled, please refer cancellation reasons for specific reasons [%s]", strings.Join(codes, ", "))),
+			CancellationReasons: reasons,
+		}
+	}
+
+	for _, w := range writes {
+		w.commit()
+	}
+	return &dynamodb.TransactWriteItemsOutput{}, nil
+}
+
+type write struct {
+	table   *table
+	key     string
+	item    map[string]types.AttributeValue
+	old     map[string]types.AttributeValue
+	touched []string
+	check   bool
+}
+
+func (w *write) commit() {
+	switch {
+	case w.check:
+	case w.item == nil:
+		delete(w.table.items, w.key)
+	default:
+		w.table.items[w.key] = w.item
+	}
+}
+
+func (c *Client) preparePut(tableName *string, item map[string]types.AttributeValue, conditionExpression *string, names map[string]string, values map[string]types.AttributeValue) (*write, error) {
+	t, err := c.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.keyOf(item, false)
+	if err != nil {
+		return nil, err
+	}
+	old := t.items[key]
+	if err := checkCondition(old, conditionExpression, names, values); err != nil {
+		return nil, err
+	}
+	return &write{table: t, key: key, item: copyItem(item), old: old}, nil
+}
+
+func (c *Client) prepareUpdate(tableName *string, keyAttributes map[string]types.AttributeValue, updateExpression, conditionExpression *string, names map[string]string, values map[string]types.AttributeValue) (*write, error) {
+	t, err := c.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.keyOf(keyAttributes, true)
+	if err != nil {
+		return nil, err
+	}
+	if updateExpression == nil {
+		return nil, validationError("UpdateExpression is required")
+	}
+
+	actions, updateParser, err := parseUpdate(*updateExpression, names, values)
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range actions {
+		if name := action.path[0].name; name == t.key.hash || name == t.key.rng {
+			return nil, validationError(fmt.Sprintf("One or more parameter values were invalid: Cannot update attribute %s. This attribute is part of the key", name))
+		}
+	}
+
+	old := t.items[key]
+	var conditionParser *parser
+	if conditionExpression != nil {
+		var c condition
+		c, conditionParser, err = parseCondition(*conditionExpression, names, values)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkUnused(names, values, updateParser, conditionParser); err != nil {
+			return nil, err
+		}
+		if !c.matches(old) {
+			return nil, conditionFailed(old)
+		}
+	} else if err := checkUnused(names, values, updateParser); err != nil {
+		return nil, err
+	}
+
+	base := old
+	if base == nil {
+		base = copyItem(keyAttributes)
+	}
+	updated, touched, err := applyUpdate(base, actions)
+	if err != nil {
+		return nil, err
+	}
+	return &write{table: t, key: key, item: updated, old: old, touched: touched}, nil
+}
+
+func (c *Client) prepareDelete(tableName *string, keyAttributes map[string]types.AttributeValue, conditionExpression *string, names map[string]string, values map[string]types.AttributeValue) (*write, error) {
+	t, err := c.table(tableName)
+	if err != nil {
+		return nil, err
+	}
+	key, err := t.keyOf(keyAttributes, true)
+	if err != nil {
+		return nil, err
+	}
+	old := t.items[key]
+	if err := checkCondition(old, conditionExpression, names, values); err != nil {
+		return nil, err
+	}
+	return &write{table: t, key: key, old: old}, nil
+}
+
+func (c *Client) prepareConditionCheck(tableName *string, keyAttributes map[string]types.AttributeValue, conditionExpression *string, names map[string]string, values map[string]types.AttributeValue) (*write, error) {
+	if conditionExpression == nil {
+		return nil, validationError("ConditionExpression is required for ConditionCheck")
+	}
+	w, err := c.prepareDelete(tableName, keyAttributes, conditionExpression, names, values)
+	if err != nil {
+		return nil, err
+	}
+	w.check = true
+	return w, nil
+}
+
+func checkCondition(item map[string]types.AttributeValue, expression *string, names map[string]string, values map[string]types.AttributeValue) error {
+	if expression == nil {
+		return checkUnused(names, values)
+	}
+	c, p, err := parseCondition(*expression, names, values)
+	if err != nil {
+		return err
+	}
+	if err := checkUnused(names, values, p); err != nil {
+		return err
+	}
+	if !c.matches(item) {
+		return conditionFailed(item)
+	}
+	return nil
+}
+
+func conditionFailed(item map[string]types.AttributeValue) error {
+	return &types.ConditionalCheckFailedException{Message: aws.String("The conditional request failed"), Item: item}
+}
+
+// withConditionItem only exposes the current item on a failed condition when the caller asked for it.
+func withConditionItem(err error, returnValues types.ReturnValuesOnConditionCheckFailure) error {
+	failed, ok := err.(*types.ConditionalCheckFailedException)
+	if !ok {
+		return err
+	}
+	if returnValues == types.ReturnValuesOnConditionCheckFailureAllOld && failed.Item != nil {
+		return &types.ConditionalCheckFailedException{Message: failed.Message, Item: copyItem(failed.Item)}
+	}
+	return &types.ConditionalCheckFailedException{Message: failed.Message}
+}
+
+func pick(item map[string]types.AttributeValue, names []string) map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+	picked := make(map[string]types.AttributeValue)
+	for _, name := range names {
+		if value, ok := item[name]; ok {
+			picked[name] = copyValue(value)
+		}
+	}
+	return picked
+}
+
+func (c *Client) table(name *string) (*table, error) {
+	t, ok := c.tables[aws.ToString(name)]
+	if !ok {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("Requested resource not found")}
+	}
+	return t, nil
+}
+
+func validationError(message string) error {
+	return &smithy.GenericAPIError{Code: "ValidationException", Message: message, Fault: smithy.FaultClient}
+}
@@ -0,0 +1,702 @@
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ordersTable = "Orders"
+
+func s(value string) types.AttributeValue { return &types.AttributeValueMemberS{Value: value} }
+func n(value string) types.AttributeValue { return &types.AttributeValueMemberN{Value: value} }
+
+func order(customer, id, status, total string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{
+		"customer": s(customer),
+		"id":       s(id),
+		"status":   s(status),
+		"total":    n(total),
+	}
+}
+
+func orderKey(customer, id string) map[string]types.AttributeValue {
+	return map[string]types.AttributeValue{"customer": s(customer), "id": s(id)}
+}
+
+func newOrdersClient(t *testing.T, opts ...Option) *Client {
+	client, err := New(opts...)
+	require.NoError(t, err)
+
+	_, err = client.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName: aws.String(ordersTable),
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String("customer"), KeyType: types.KeyTypeHash},
+			{AttributeName: aws.String("id"), KeyType: types.KeyTypeRange},
+		},
+		GlobalSecondaryIndexes: []types.GlobalSecondaryIndex{
+			{
+				IndexName: aws.String("by-status"),
+				KeySchema: []types.KeySchemaElement{
+					{AttributeName: aws.String("status"), KeyType: types.KeyTypeHash},
+					{AttributeName: aws.String("total"), KeyType: types.KeyTypeRange},
+				},
+				Projection: &types.Projection{ProjectionType: types.ProjectionTypeKeysOnly},
+			},
+		},
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func put(t *testing.T, client *Client, item map[string]types.AttributeValue) {
+	_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String(ordersTable), Item: item})
+	require.NoError(t, err)
+}
+
+func get(t *testing.T, client *Client, key map[string]types.AttributeValue) map[string]types.AttributeValue {
+	result, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{TableName: aws.String(ordersTable), Key: key})
+	require.NoError(t, err)
+	return result.Item
+}
+
+func assertValidationError(t *testing.T, err error, message string) {
+	t.Helper()
+	var apiErr smithy.APIError
+	require.True(t, errors.As(err, &apiErr), "expected an API error, got %v", err)
+	assert.Equal(t, "ValidationException", apiErr.ErrorCode())
+	assert.Contains(t, apiErr.ErrorMessage(), message)
+}
+
+func TestClient_TableManagement(t *testing.T) {
+	client := newOrdersClient(t)
+
+	_, err := client.CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName: aws.String(ordersTable),
+		KeySchema: []types.KeySchemaElement{{AttributeName: aws.String("id"), KeyType: types.KeyTypeHash}},
+	})
+	var inUse *types.ResourceInUseException
+	assert.ErrorAs(t, err, &inUse)
+
+	description, err := client.DescribeTable(context.Background(), &dynamodb.DescribeTableInput{TableName: aws.String(ordersTable)})
+	require.NoError(t, err)
+	assert.Equal(t, types.TableStatusActive, description.Table.TableStatus)
+	assert.Len(t, description.Table.KeySchema, 2)
+	assert.Equal(t, "by-status", aws.ToString(description.Table.GlobalSecondaryIndexes[0].IndexName))
+
+	_, err = client.GetItem(context.Background(), &dynamodb.GetItemInput{TableName: aws.String("Missing"), Key: orderKey("c", "1")})
+	var notFound *types.ResourceNotFoundException
+	assert.ErrorAs(t, err, &notFound)
+
+	_, err = New(WithMaxPageSize(0))
+	assert.EqualError(t, err, "max page size must be positive, got 0")
+}
+
+func TestClient_PutGetDelete(t *testing.T) {
+	client := newOrdersClient(t)
+	item := order("alice", "1", "open", "10")
+	put(t, client, item)
+
+	// The stored item must not alias the caller's map.
+	item["status"] = s("mutated")
+	assert.Equal(t, order("alice", "1", "open", "10"), get(t, client, orderKey("alice", "1")))
+
+	result, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{
+		TableName:                aws.String(ordersTable),
+		Key:                      orderKey("alice", "1"),
+		ProjectionExpression:     aws.String("#s, total"),
+		ExpressionAttributeNames: map[string]string{"#s": "status"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]types.AttributeValue{"status": s("open"), "total": n("10")}, result.Item)
+
+	old, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:    aws.String(ordersTable),
+		Item:         order("alice", "1", "paid", "10"),
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, s("open"), old.Attributes["status"])
+
+	deleted, err := client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+		TableName:    aws.String(ordersTable),
+		Key:          orderKey("alice", "1"),
+		ReturnValues: types.ReturnValueAllOld,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, s("paid"), deleted.Attributes["status"])
+	assert.Nil(t, get(t, client, orderKey("alice", "1")))
+}
+
+func TestClient_KeyValidation(t *testing.T) {
+	client := newOrdersClient(t)
+
+	tests := []struct {
+		name    string
+		run     func() error
+		message string
+	}{
+		{
+			name: "Missing range key",
+			run: func() error {
+				_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String(ordersTable), Item: map[string]types.AttributeValue{"customer": s("alice")}})
+				return err
+			},
+			message: "One of the required keys was not given a value: id",
+		},
+		{
+			name: "Empty string key",
+			run: func() error {
+				_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String(ordersTable), Item: order("", "1", "open", "1")})
+				return err
+			},
+			message: "cannot contain an empty string value",
+		},
+		{
+			name: "Key type mismatch",
+			run: func() error {
+				_, err := client.GetItem(context.Background(), &dynamodb.GetItemInput{TableName: aws.String(ordersTable), Key: map[string]types.AttributeValue{"customer": s("alice"), "id": &types.AttributeValueMemberBOOL{Value: true}}})
+				return err
+			},
+			message: "Type mismatch for key id",
+		},
+		{
+			name: "Key with extra attributes",
+			run: func() error {
+				_, err := client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{TableName: aws.String(ordersTable), Key: order("alice", "1", "open", "1")})
+				return err
+			},
+			message: "The provided key element does not match the schema",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertValidationError(t, tt.run(), tt.message)
+		})
+	}
+}
+
+func TestClient_ConditionExpressions(t *testing.T) {
+	client := newOrdersClient(t)
+	put(t, client, order("alice", "1", "open", "10"))
+
+	tests := []struct {
+		name      string
+		condition string
+		names     map[string]string
+		values    map[string]types.AttributeValue
+		matches   bool
+	}{
+		{name: "attribute_exists", condition: "attribute_exists(id)", matches: true},
+		{name: "attribute_not_exists", condition: "attribute_not_exists(id)", matches: false},
+		{name: "Equality", condition: "#s = :s", names: map[string]string{"#s": "status"}, values: map[string]types.AttributeValue{":s": s("open")}, matches: true},
+		{name: "Numeric comparison", condition: "total > :t", values: map[string]types.AttributeValue{":t": n("9.5")}, matches: true},
+		{name: "Numbers compare by value", condition: "total = :t", values: map[string]types.AttributeValue{":t": n("10.00")}, matches: true},
+		{name: "Not equal on missing attribute", condition: "missing <> :t", values: map[string]types.AttributeValue{":t": n("1")}, matches: true},
+		{name: "Comparison on missing attribute", condition: "missing < :t", values: map[string]types.AttributeValue{":t": n("1")}, matches: false},
+		{name: "Type mismatch", condition: "total > :t", values: map[string]types.AttributeValue{":t": s("1")}, matches: false},
+		{name: "BETWEEN", condition: "total BETWEEN :lo AND :hi", values: map[string]types.AttributeValue{":lo": n("5"), ":hi": n("10")}, matches: true},
+		{name: "IN", condition: "#s IN (:a, :b)", names: map[string]string{"#s": "status"}, values: map[string]types.AttributeValue{":a": s("paid"), ":b": s("open")}, matches: true},
+		{name: "begins_with", condition: "begins_with(customer, :p)", values: map[string]types.AttributeValue{":p": s("al")}, matches: true},
+		{name: "contains", condition: "contains(customer, :p)", values: map[string]types.AttributeValue{":p": s("ic")}, matches: true},
+		{name: "size", condition: "size(customer) = :n", values: map[string]types.AttributeValue{":n": n("5")}, matches: true},
+		{name: "attribute_type", condition: "attribute_type(total, :t)", values: map[string]types.AttributeValue{":t": s("N")}, matches: true},
+		{name: "Precedence of AND over OR", condition: "attribute_not_exists(id) OR total > :t AND total < :t", values: map[string]types.AttributeValue{":t": n("10")}, matches: false},
+		{name: "Parentheses and NOT", condition: "NOT (attribute_not_exists(id) OR total < :t)", values: map[string]types.AttributeValue{":t": n("10")}, matches: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+				TableName:                 aws.String(ordersTable),
+				Item:                      order("alice", "1", "open", "10"),
+				ConditionExpression:       aws.String(tt.condition),
+				ExpressionAttributeNames:  tt.names,
+				ExpressionAttributeValues: tt.values,
+			})
+			if tt.matches {
+				assert.NoError(t, err)
+				return
+			}
+			var failed *types.ConditionalCheckFailedException
+			assert.ErrorAs(t, err, &failed)
+		})
+	}
+}
+
+func TestClient_ConditionFailureReturnsItem(t *testing.T) {
+	client := newOrdersClient(t)
+	put(t, client, order("alice", "1", "open", "10"))
+
+	_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+		TableName:                           aws.String(ordersTable),
+		Item:                                order("alice", "1", "paid", "10"),
+		ConditionExpression:                 aws.String("attribute_not_exists(id)"),
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	})
+	var failed *types.ConditionalCheckFailedException
+	require.ErrorAs(t, err, &failed)
+	assert.Equal(t, order("alice", "1", "open", "10"), failed.Item)
+
+	_, err = client.DeleteItem(context.Background(), &dynamodb.DeleteItemInput{
+		TableName:           aws.String(ordersTable),
+		Key:                 orderKey("alice", "1"),
+		ConditionExpression: aws.String("attribute_not_exists(id)"),
+	})
+	require.ErrorAs(t, err, &failed)
+	assert.Nil(t, failed.Item)
+	assert.NotNil(t, get(t, client, orderKey("alice", "1")))
+}
+
+func TestClient_ExpressionValidation(t *testing.T) {
+	client := newOrdersClient(t)
+
+	tests := []struct {
+		name      string
+		condition string
+		names     map[string]string
+		values    map[string]types.AttributeValue
+		message   string
+	}{
+		{name: "Undefined value", condition: "total = :t", message: "An expression attribute value used in expression is not defined; attribute value: :t"},
+		{name: "Undefined name", condition: "#t = :t", values: map[string]types.AttributeValue{":t": n("1")}, message: "An expression attribute name used in the document path is not defined; attribute name: #t"},
+		{name: "Unused value", condition: "attribute_exists(id)", values: map[string]types.AttributeValue{":t": n("1")}, message: "Value provided in ExpressionAttributeValues unused in expressions: keys: {:t}"},
+		{name: "Unused name", condition: "attribute_exists(id)", names: map[string]string{"#t": "total"}, message: "Value provided in ExpressionAttributeNames unused in expressions: keys: {#t}"},
+		{name: "Reserved word", condition: "status = :s", values: map[string]types.AttributeValue{":s": s("open")}, message: "Attribute name is a reserved keyword; reserved keyword: status"},
+		{name: "Syntax error", condition: "total = ", message: "syntax error, unexpected end of input"},
+		{name: "Unexpected character", condition: "total ! :t", values: map[string]types.AttributeValue{":t": n("1")}, message: "unexpected character '!'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.PutItem(context.Background(), &dynamodb.PutItemInput{
+				TableName:                 aws.String(ordersTable),
+				Item:                      order("alice", "1", "open", "10"),
+				ConditionExpression:       aws.String(tt.condition),
+				ExpressionAttributeNames:  tt.names,
+				ExpressionAttributeValues: tt.values,
+			})
+			assertValidationError(t, err, tt.message)
+		})
+	}
+}
+
+func TestClient_UpdateItem(t *testing.T) {
+	client := newOrdersClient(t)
+	item := order("alice", "1", "open", "10")
+	item["tags"] = &types.AttributeValueMemberSS{Value: []string{"a"}}
+	item["history"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{s("created")}}
+	item["meta"] = &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"source": s("web")}}
+	item["labels"] = &types.AttributeValueMemberSS{Value: []string{"a", "b"}}
+	item["note"] = s("remove me")
+	put(t, client, item)
+
+	result, err := client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName: aws.String(ordersTable),
+		Key:       orderKey("alice", "1"),
+		UpdateExpression: aws.String("SET #s = :paid, total = total + :delta, created = if_not_exists(created, :now), " +
+			"history = list_append(history, :events), meta.channel = :channel REMOVE note ADD visits :one, tags :tags DELETE labels :a"),
+		ConditionExpression:      aws.String("#s = :open"),
+		ExpressionAttributeNames: map[string]string{"#s": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":paid":    s("paid"),
+			":open":    s("open"),
+			":delta":   n("2.5"),
+			":now":     s("2024-01-01"),
+			":events":  &types.AttributeValueMemberL{Value: []types.AttributeValue{s("paid")}},
+			":channel": s("email"),
+			":one":     n("1"),
+			":tags":    &types.AttributeValueMemberSS{Value: []string{"b", "c"}},
+			":a":       &types.AttributeValueMemberSS{Value: []string{"a"}},
+		},
+		ReturnValues: types.ReturnValueAllNew,
+	})
+	require.NoError(t, err)
+
+	expected := order("alice", "1", "paid", "12.5")
+	expected["created"] = s("2024-01-01")
+	expected["history"] = &types.AttributeValueMemberL{Value: []types.AttributeValue{s("created"), s("paid")}}
+	expected["meta"] = &types.AttributeValueMemberM{Value: map[string]types.AttributeValue{"source": s("web"), "channel": s("email")}}
+	expected["visits"] = n("1")
+	expected["tags"] = &types.AttributeValueMemberSS{Value: []string{"a", "b", "c"}}
+	expected["labels"] = &types.AttributeValueMemberSS{Value: []string{"b"}}
+	assert.Equal(t, expected, result.Attributes)
+	assert.Equal(t, expected, get(t, client, orderKey("alice", "1")))
+
+	updated, err := client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(ordersTable),
+		Key:                       orderKey("alice", "1"),
+		UpdateExpression:          aws.String("SET created = if_not_exists(created, :later), total = :total - total"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":later": s("2025-01-01"), ":total": n("20")},
+		ReturnValues:              types.ReturnValueUpdatedOld,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]types.AttributeValue{"created": s("2024-01-01"), "total": n("12.5")}, updated.Attributes)
+	assert.Equal(t, n("7.5"), get(t, client, orderKey("alice", "1"))["total"])
+}
+
+func TestClient_UpdateItemCreatesMissingItem(t *testing.T) {
+	client := newOrdersClient(t)
+
+	result, err := client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(ordersTable),
+		Key:                       orderKey("bob", "7"),
+		UpdateExpression:          aws.String("ADD total :n"),
+		ConditionExpression:       aws.String("attribute_not_exists(id)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":n": n("3")},
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]types.AttributeValue{"total": n("3")}, result.Attributes)
+	assert.Equal(t, map[string]types.AttributeValue{"customer": s("bob"), "id": s("7"), "total": n("3")}, get(t, client, orderKey("bob", "7")))
+}
+
+func TestClient_UpdateItemValidation(t *testing.T) {
+	client := newOrdersClient(t)
+	put(t, client, order("alice", "1", "open", "10"))
+
+	tests := []struct {
+		name    string
+		update  string
+		values  map[string]types.AttributeValue
+		message string
+	}{
+		{name: "Key attribute", update: "SET id = :v", values: map[string]types.AttributeValue{":v": s("2")}, message: "Cannot update attribute id. This attribute is part of the key"},
+		{name: "Overlapping paths", update: "SET total = :v REMOVE total", values: map[string]types.AttributeValue{":v": n("1")}, message: "Two document paths overlap"},
+		{name: "Arithmetic on missing attribute", update: "SET total = missing + :v", values: map[string]types.AttributeValue{":v": n("1")}, message: "refers to an attribute that does not exist"},
+		{name: "Arithmetic on string", update: "SET total = customer + :v", values: map[string]types.AttributeValue{":v": n("1")}, message: "incorrect data type"},
+		{name: "Repeated clause", update: "SET total = :v SET total = :v", values: map[string]types.AttributeValue{":v": n("1")}, message: "can only be used once"},
+		{name: "Missing parent path", update: "SET meta.channel = :v", values: map[string]types.AttributeValue{":v": s("email")}, message: "document path provided in the update expression is invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.UpdateItem(context.Background(), &dynamodb.UpdateItemInput{
+				TableName:                 aws.String(ordersTable),
+				Key:                       orderKey("alice", "1"),
+				UpdateExpression:          aws.String(tt.update),
+				ExpressionAttributeValues: tt.values,
+			})
+			assertValidationError(t, err, tt.message)
+			assert.Equal(t, order("alice", "1", "open", "10"), get(t, client, orderKey("alice", "1")))
+		})
+	}
+}
+
+func seedOrders(t *testing.T, client *Client) {
+	put(t, client, order("alice", "1", "open", "30"))
+	put(t, client, order("alice", "2", "paid", "10"))
+	put(t, client, order("alice", "3", "open", "20"))
+	put(t, client, order("alice", "4", "open", "5"))
+	put(t, client, order("bob", "1", "open", "15"))
+	put(t, client, map[string]types.AttributeValue{"customer": s("carol"), "id": s("1"), "total": n("1")})
+}
+
+func ids(items []map[string]types.AttributeValue) []string {
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		result = append(result, fmt.Sprintf("%s/%s", item["customer"].(*types.AttributeValueMemberS).Value, item["id"].(*types.AttributeValueMemberS).Value))
+	}
+	return result
+}
+
+func TestClient_Query(t *testing.T) {
+	client := newOrdersClient(t)
+	seedOrders(t, client)
+
+	tests := []struct {
+		name     string
+		input    dynamodb.QueryInput
+		expected []string
+		count    int32
+		scanned  int32
+	}{
+		{
+			name: "Partition key",
+			input: dynamodb.QueryInput{
+				KeyConditionExpression:    aws.String("customer = :c"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{":c": s("alice")},
+			},
+			expected: []string{"alice/1", "alice/2", "alice/3", "alice/4"},
+			count:    4,
+			scanned:  4,
+		},
+		{
+			name: "Sort key condition descending",
+			input: dynamodb.QueryInput{
+				KeyConditionExpression:    aws.String("customer = :c AND id BETWEEN :from AND :to"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{":c": s("alice"), ":from": s("2"), ":to": s("3")},
+				ScanIndexForward:          aws.Bool(false),
+			},
+			expected: []string{"alice/3", "alice/2"},
+			count:    2,
+			scanned:  2,
+		},
+		{
+			name: "Filter applies after key condition",
+			input: dynamodb.QueryInput{
+				KeyConditionExpression:    aws.String("customer = :c AND begins_with(id, :p)"),
+				FilterExpression:          aws.String("#s = :s"),
+				ExpressionAttributeNames:  map[string]string{"#s": "status"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":c": s("alice"), ":p": s(""), ":s": s("open")},
+			},
+			expected: []string{"alice/1", "alice/3", "alice/4"},
+			count:    3,
+			scanned:  4,
+		},
+		{
+			name: "Global secondary index ordered by its sort key",
+			input: dynamodb.QueryInput{
+				IndexName:                 aws.String("by-status"),
+				KeyConditionExpression:    aws.String("#s = :s AND total >= :min"),
+				ExpressionAttributeNames:  map[string]string{"#s": "status"},
+				ExpressionAttributeValues: map[string]types.AttributeValue{":s": s("open"), ":min": n("10")},
+			},
+			expected: []string{"bob/1", "alice/3", "alice/1"},
+			count:    3,
+			scanned:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := tt.input
+			input.TableName = aws.String(ordersTable)
+			result, err := client.Query(context.Background(), &input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ids(result.Items))
+			assert.Equal(t, tt.count, result.Count)
+			assert.Equal(t, tt.scanned, result.ScannedCount)
+			assert.Nil(t, result.LastEvaluatedKey)
+		})
+	}
+
+	t.Run("Keys only projection on index", func(t *testing.T) {
+		result, err := client.Query(context.Background(), &dynamodb.QueryInput{
+			TableName:                 aws.String(ordersTable),
+			IndexName:                 aws.String("by-status"),
+			KeyConditionExpression:    aws.String("#s = :s"),
+			ExpressionAttributeNames:  map[string]string{"#s": "status"},
+			ExpressionAttributeValues: map[string]types.AttributeValue{":s": s("paid")},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]types.AttributeValue{order("alice", "2", "paid", "10")}, result.Items)
+	})
+
+	t.Run("Count only", func(t *testing.T) {
+		result, err := client.Query(context.Background(), &dynamodb.QueryInput{
+			TableName:                 aws.String(ordersTable),
+			KeyConditionExpression:    aws.String("customer = :c"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{":c": s("alice")},
+			Select:                    types.SelectCount,
+		})
+		require.NoError(t, err)
+		assert.Nil(t, result.Items)
+		assert.Equal(t, int32(4), result.Count)
+	})
+}
+
+func TestClient_QueryValidation(t *testing.T) {
+	client := newOrdersClient(t)
+
+	tests := []struct {
+		name      string
+		index     *string
+		condition string
+		message   string
+	}{
+		{name: "Missing partition key", condition: "id = :v", message: "Query condition missed key schema element: customer"},
+		{name: "Partition key inequality", condition: "customer > :v", message: "Query key condition not supported"},
+		{name: "Non key attribute", condition: "customer = :v AND total = :v", message: "Query condition missed key schema element"},
+		{name: "OR is not allowed", condition: "customer = :v OR id = :v", message: "Invalid operator used in KeyConditionExpression"},
+		{name: "Unknown index", index: aws.String("missing"), condition: "customer = :v", message: "The table does not have the specified index: missing"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Query(context.Background(), &dynamodb.QueryInput{
+				TableName:                 aws.String(ordersTable),
+				IndexName:                 tt.index,
+				KeyConditionExpression:    aws.String(tt.condition),
+				ExpressionAttributeValues: map[string]types.AttributeValue{":v": s("alice")},
+			})
+			assertValidationError(t, err, tt.message)
+		})
+	}
+}
+
+func TestClient_ScanPagination(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		limit    *int32
+		filter   bool
+		expected [][]string
+	}{
+		{
+			name:     "Single page",
+			expected: [][]string{{"alice/1", "alice/2", "alice/3", "alice/4", "bob/1", "carol/1"}},
+		},
+		{
+			name:     "Limit",
+			limit:    aws.Int32(4),
+			expected: [][]string{{"alice/1", "alice/2", "alice/3", "alice/4"}, {"bob/1", "carol/1"}},
+		},
+		{
+			name:     "Max page size",
+			opts:     []Option{WithMaxPageSize(3)},
+			expected: [][]string{{"alice/1", "alice/2", "alice/3"}, {"alice/4", "bob/1", "carol/1"}},
+		},
+		{
+			name:     "Limit counts items before the filter",
+			limit:    aws.Int32(2),
+			filter:   true,
+			expected: [][]string{{"alice/1"}, {"alice/3", "alice/4"}, {"bob/1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newOrdersClient(t, tt.opts...)
+			seedOrders(t, client)
+
+			input := &dynamodb.ScanInput{TableName: aws.String(ordersTable), Limit: tt.limit}
+			if tt.filter {
+				input.FilterExpression = aws.String("#s = :s")
+				input.ExpressionAttributeNames = map[string]string{"#s": "status"}
+				input.ExpressionAttributeValues = map[string]types.AttributeValue{":s": s("open")}
+			}
+
+			var pages [][]string
+			for {
+				result, err := client.Scan(context.Background(), input)
+				require.NoError(t, err)
+				pages = append(pages, ids(result.Items))
+				if result.LastEvaluatedKey == nil {
+					break
+				}
+				input.ExclusiveStartKey = result.LastEvaluatedKey
+			}
+			assert.Equal(t, tt.expected, pages)
+		})
+	}
+}
+
+func TestClient_QueryIndexPagination(t *testing.T) {
+	client := newOrdersClient(t)
+	seedOrders(t, client)
+
+	input := &dynamodb.QueryInput{
+		TableName:                 aws.String(ordersTable),
+		IndexName:                 aws.String("by-status"),
+		KeyConditionExpression:    aws.String("#s = :s"),
+		ExpressionAttributeNames:  map[string]string{"#s": "status"},
+		ExpressionAttributeValues: map[string]types.AttributeValue{":s": s("open")},
+		ScanIndexForward:          aws.Bool(false),
+		Limit:                     aws.Int32(2),
+	}
+
+	first, err := client.Query(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice/1", "alice/3"}, ids(first.Items))
+	assert.Equal(t, map[string]types.AttributeValue{"status": s("open"), "total": n("20"), "customer": s("alice"), "id": s("3")}, first.LastEvaluatedKey)
+
+	input.ExclusiveStartKey = first.LastEvaluatedKey
+	second, err := client.Query(context.Background(), input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob/1", "alice/4"}, ids(second.Items))
+	assert.Nil(t, second.LastEvaluatedKey)
+}
+
+func TestClient_BatchWriteItem(t *testing.T) {
+	client := newOrdersClient(t)
+	put(t, client, order("alice", "1", "open", "10"))
+
+	result, err := client.BatchWriteItem(context.Background(), &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			ordersTable: {
+				{PutRequest: &types.PutRequest{Item: order("alice", "2", "open", "20")}},
+				{PutRequest: &types.PutRequest{Item: order("bob", "1", "open", "30")}},
+				{DeleteRequest: &types.DeleteRequest{Key: orderKey("alice", "1")}},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.UnprocessedItems)
+	assert.Nil(t, get(t, client, orderKey("alice", "1")))
+	assert.NotNil(t, get(t, client, orderKey("alice", "2")))
+	assert.NotNil(t, get(t, client, orderKey("bob", "1")))
+
+	_, err = client.BatchWriteItem(context.Background(), &dynamodb.BatchWriteItemInput{
+		RequestItems: map[string][]types.WriteRequest{
+			ordersTable: {
+				{PutRequest: &types.PutRequest{Item: order("carol", "1", "open", "20")}},
+				{DeleteRequest: &types.DeleteRequest{Key: orderKey("carol", "1")}},
+			},
+		},
+	})
+	assertValidationError(t, err, "Provided list of item keys contains duplicates")
+	assert.Nil(t, get(t, client, orderKey("carol", "1")))
+
+	requests := make([]types.WriteRequest, 26)
+	for i := range requests {
+		requests[i] = types.WriteRequest{PutRequest: &types.PutRequest{Item: order("dave", fmt.Sprint(i), "open", "1")}}
+	}
+	_, err = client.BatchWriteItem(context.Background(), &dynamodb.BatchWriteItemInput{RequestItems: map[string][]types.WriteRequest{ordersTable: requests}})
+	assertValidationError(t, err, "Too many items requested for the BatchWriteItem call")
+}
+
+func TestClient_TransactWriteItems(t *testing.T) {
+	client := newOrdersClient(t)
+	put(t, client, order("alice", "1", "open", "10"))
+	put(t, client, order("alice", "2", "open", "20"))
+
+	transaction := func(condition string) *dynamodb.TransactWriteItemsInput {
+		return &dynamodb.TransactWriteItemsInput{
+			TransactItems: []types.TransactWriteItem{
+				{Put: &types.Put{TableName: aws.String(ordersTable), Item: order("alice", "3", "open", "30"), ConditionExpression: aws.String("attribute_not_exists(id)")}},
+				{Update: &types.Update{
+					TableName:                 aws.String(ordersTable),
+					Key:                       orderKey("alice", "1"),
+					UpdateExpression:          aws.String("SET total = total - :n"),
+					ConditionExpression:       aws.String(condition),
+					ExpressionAttributeValues: map[string]types.AttributeValue{":n": n("10")},
+				}},
+				{Delete: &types.Delete{TableName: aws.String(ordersTable), Key: orderKey("alice", "2")}},
+				{ConditionCheck: &types.ConditionCheck{TableName: aws.String(ordersTable), Key: orderKey("bob", "1"), ConditionExpression: aws.String("attribute_not_exists(id)")}},
+			},
+		}
+	}
+
+	_, err := client.TransactWriteItems(context.Background(), transaction("total > :n"))
+	var cancelled *types.TransactionCanceledException
+	require.ErrorAs(t, err, &cancelled)
+	assert.Equal(t, "Transaction cancelled, please refer cancellation reasons for specific reasons [None, ConditionalCheckFailed, None, None]", aws.ToString(cancelled.Message))
+	assert.Nil(t, get(t, client, orderKey("alice", "3")))
+	assert.Equal(t, n("10"), get(t, client, orderKey("alice", "1"))["total"])
+	assert.NotNil(t, get(t, client, orderKey("alice", "2")))
+
+	_, err = client.TransactWriteItems(context.Background(), transaction("total >= :n"))
+	require.NoError(t, err)
+	assert.NotNil(t, get(t, client, orderKey("alice", "3")))
+	assert.Equal(t, n("0"), get(t, client, orderKey("alice", "1"))["total"])
+	assert.Nil(t, get(t, client, orderKey("alice", "2")))
+
+	_, err = client.TransactWriteItems(context.Background(), &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Put: &types.Put{TableName: aws.String(ordersTable), Item: order("alice", "9", "open", "1")}},
+			{Delete: &types.Delete{TableName: aws.String(ordersTable), Key: orderKey("alice", "9")}},
+		},
+	})
+	assertValidationError(t, err, "Transaction request cannot include multiple operations on one item")
+	assert.Nil(t, get(t, client, orderKey("alice", "9")))
+}
@@ -0,0 +1,760 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenName
+	tokenValue
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func tokenize(expression string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expression)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == ':' || r == '#' || r == '_' || unicode.IsLetter(r):
+			start := i
+			i++
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			kind := tokenName
+			if r == ':' {
+				kind = tokenValue
+			}
+			tokens = append(tokens, token{kind: kind, text: string(runes[start:i])})
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && unicode.IsDigit(runes[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i])})
+		case r == '<' || r == '>':
+			if i+1 < len(runes) && (runes[i+1] == '=' || (r == '<' && runes[i+1] == '>')) {
+				tokens = append(tokens, token{kind: tokenSymbol, text: string(runes[i : i+2])})
+				i += 2
+				continue
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r)})
+			i++
+		case strings.ContainsRune("=(),.[]+-", r):
+			tokens = append(tokens, token{kind: tokenSymbol, text: string(r)})
+			i++
+		default:
+			return nil, validationError(fmt.Sprintf("Invalid expression: syntax error, unexpected character %q", r))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	names  map[string]string
+	values map[string]types.AttributeValue
+	// Placeholders seen while parsing, so unused ones can be reported like DynamoDB does.
+	usedNames  map[string]bool
+	usedValues map[string]bool
+}
+
+func newParser(expression string, names map[string]string, values map[string]types.AttributeValue) (*parser, error) {
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{
+		tokens:     tokens,
+		names:      names,
+		values:     values,
+		usedNames:  make(map[string]bool),
+		usedValues: make(map[string]bool),
+	}, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(word string) bool {
+	t := p.peek()
+	if t.kind == tokenName && strings.EqualFold(t.text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) symbol(symbol string) bool {
+	t := p.peek()
+	if t.kind == tokenSymbol && t.text == symbol {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(symbol string) error {
+	if !p.symbol(symbol) {
+		return p.syntaxError()
+	}
+	return nil
+}
+
+func (p *parser) syntaxError() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return validationError("Invalid expression: syntax error, unexpected end of input")
+	}
+	return validationError(fmt.Sprintf("Invalid expression: syntax error, unexpected token %q", t.text))
+}
+
+func (p *parser) end() error {
+	if p.peek().kind != tokenEOF {
+		return p.syntaxError()
+	}
+	return nil
+}
+
+type pathElement struct {
+	name  string
+	index int
+	list  bool
+}
+
+type path []pathElement
+
+func (pa path) String() string {
+	var b strings.Builder
+	for i, element := range pa {
+		if element.list {
+			fmt.Fprintf(&b, "[%d]", element.index)
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(element.name)
+	}
+	return b.String()
+}
+
+func (p *parser) parsePath() (path, error) {
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	result := path{{name: name}}
+	for {
+		switch {
+		case p.symbol("."):
+			name, err := p.parseName()
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, pathElement{name: name})
+		case p.symbol("["):
+			t := p.next()
+			if t.kind != tokenNumber {
+				return nil, p.syntaxError()
+			}
+			index, _ := strconv.Atoi(t.text)
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			result = append(result, pathElement{index: index, list: true})
+		default:
+			return result, nil
+		}
+	}
+}
+
+func (p *parser) parseName() (string, error) {
+	t := p.next()
+	if t.kind != tokenName {
+		p.pos--
+		return "", p.syntaxError()
+	}
+	if strings.HasPrefix(t.text, "#") {
+		name, ok := p.names[t.text]
+		if !ok {
+			return "", validationError(fmt.Sprintf("Invalid expression: An expression attribute name used in the document path is not defined; attribute name: %s", t.text))
+		}
+		p.usedNames[t.text] = true
+		return name, nil
+	}
+	if isReservedWord(t.text) {
+		return "", validationError(fmt.Sprintf("Invalid expression: Attribute name is a reserved keyword; reserved keyword: %s", t.text))
+	}
+	return t.text, nil
+}
+
+func (p *parser) parseValue() (types.AttributeValue, error) {
+	t := p.next()
+	value, ok := p.values[t.text]
+	if !ok {
+		return nil, validationError(fmt.Sprintf("Invalid expression: An expression attribute value used in expression is not defined; attribute value: %s", t.text))
+	}
+	p.usedValues[t.text] = true
+	return value, nil
+}
+
+// The subset of DynamoDB reserved words that collide with attribute names in practice.
+var reservedWords = map[string]bool{
+	"AND": true, "BETWEEN": true, "BY": true, "COUNT": true, "DATA": true, "DATE": true,
+	"DELETE": true, "DESC": true, "IN": true, "KEY": true, "NAME": true, "NOT": true,
+	"OR": true, "ORDER": true, "SET": true, "SIZE": true, "STATUS": true, "TIMESTAMP": true,
+	"TYPE": true, "VALUE": true, "VALUES": true, "YEAR": true, "REMOVE": true, "ADD": true,
+}
+
+func isReservedWord(word string) bool {
+	return reservedWords[strings.ToUpper(word)]
+}
+
+func resolvePath(item map[string]types.AttributeValue, pa path) (types.AttributeValue, bool) {
+	var current types.AttributeValue = &types.AttributeValueMemberM{Value: item}
+	for _, element := range pa {
+		switch v := current.(type) {
+		case *types.AttributeValueMemberM:
+			if element.list {
+				return nil, false
+			}
+			next, ok := v.Value[element.name]
+			if !ok {
+				return nil, false
+			}
+			current = next
+		case *types.AttributeValueMemberL:
+			if !element.list || element.index >= len(v.Value) {
+				return nil, false
+			}
+			current = v.Value[element.index]
+		default:
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// setPath writes value at pa, creating nothing: every parent must already exist, as in DynamoDB.
+func setPath(item map[string]types.AttributeValue, pa path, value types.AttributeValue) error {
+	if len(pa) == 1 {
+		if value == nil {
+			delete(item, pa[0].name)
+		} else {
+			item[pa[0].name] = value
+		}
+		return nil
+	}
+
+	parent, ok := resolvePath(item, pa[:len(pa)-1])
+	if !ok {
+		return validationError("The document path provided in the update expression is invalid for update")
+	}
+	last := pa[len(pa)-1]
+	switch v := parent.(type) {
+	case *types.AttributeValueMemberM:
+		if last.list {
+			return validationError("The document path provided in the update expression is invalid for update")
+		}
+		if value == nil {
+			delete(v.Value, last.name)
+		} else {
+			v.Value[last.name] = value
+		}
+	case *types.AttributeValueMemberL:
+		if !last.list {
+			return validationError("The document path provided in the update expression is invalid for update")
+		}
+		switch {
+		case value == nil && last.index < len(v.Value):
+			v.Value = append(v.Value[:last.index], v.Value[last.index+1:]...)
+		case value == nil:
+		case last.index < len(v.Value):
+			v.Value[last.index] = value
+		default:
+			v.Value = append(v.Value, value)
+		}
+	default:
+		return validationError("The document path provided in the update expression is invalid for update")
+	}
+	return nil
+}
+
+type operand interface {
+	evaluate(item map[string]types.AttributeValue) (types.AttributeValue, bool)
+}
+
+type pathOperand struct{ path path }
+
+func (o pathOperand) evaluate(item map[string]types.AttributeValue) (types.AttributeValue, bool) {
+	return resolvePath(item, o.path)
+}
+
+type valueOperand struct{ value types.AttributeValue }
+
+func (o valueOperand) evaluate(map[string]types.AttributeValue) (types.AttributeValue, bool) {
+	return o.value, true
+}
+
+type sizeOperand struct{ path path }
+
+func (o sizeOperand) evaluate(item map[string]types.AttributeValue) (types.AttributeValue, bool) {
+	value, ok := resolvePath(item, o.path)
+	if !ok {
+		return nil, false
+	}
+	size, ok := sizeOf(value)
+	if !ok {
+		return nil, false
+	}
+	return &types.AttributeValueMemberN{Value: strconv.Itoa(size)}, true
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	t := p.peek()
+	switch {
+	case t.kind == tokenValue:
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		return valueOperand{value: value}, nil
+	case t.kind == tokenName && strings.EqualFold(t.text, "size") && p.tokens[p.pos+1].text == "(":
+		p.pos += 2
+		pa, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return sizeOperand{path: pa}, nil
+	case t.kind == tokenName:
+		pa, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+		return pathOperand{path: pa}, nil
+	}
+	return nil, p.syntaxError()
+}
+
+type condition interface {
+	matches(item map[string]types.AttributeValue) bool
+}
+
+type andCondition struct{ left, right condition }
+
+func (c andCondition) matches(item map[string]types.AttributeValue) bool {
+	return c.left.matches(item) && c.right.matches(item)
+}
+
+type orCondition struct{ left, right condition }
+
+func (c orCondition) matches(item map[string]types.AttributeValue) bool {
+	return c.left.matches(item) || c.right.matches(item)
+}
+
+type notCondition struct{ inner condition }
+
+func (c notCondition) matches(item map[string]types.AttributeValue) bool {
+	return !c.inner.matches(item)
+}
+
+type comparison struct {
+	operator    string
+	left, right operand
+}
+
+func (c comparison) matches(item map[string]types.AttributeValue) bool {
+	left, lok := c.left.evaluate(item)
+	right, rok := c.right.evaluate(item)
+	switch c.operator {
+	case "=":
+		return lok && rok && equalValues(left, right)
+	case "<>":
+		return !(lok && rok && equalValues(left, right))
+	}
+	if !lok || !rok {
+		return false
+	}
+	result, ok := compareValues(left, right)
+	if !ok {
+		return false
+	}
+	switch c.operator {
+	case "<":
+		return result < 0
+	case "<=":
+		return result <= 0
+	case ">":
+		return result > 0
+	case ">=":
+		return result >= 0
+	}
+	return false
+}
+
+type between struct {
+	value, low, high operand
+}
+
+func (c between) matches(item map[string]types.AttributeValue) bool {
+	return comparison{operator: ">=", left: c.value, right: c.low}.matches(item) &&
+		comparison{operator: "<=", left: c.value, right: c.high}.matches(item)
+}
+
+type in struct {
+	value      operand
+	candidates []operand
+}
+
+func (c in) matches(item map[string]types.AttributeValue) bool {
+	for _, candidate := range c.candidates {
+		if (comparison{operator: "=", left: c.value, right: candidate}).matches(item) {
+			return true
+		}
+	}
+	return false
+}
+
+type function struct {
+	name     string
+	path     path
+	argument operand
+}
+
+func (c function) matches(item map[string]types.AttributeValue) bool {
+	value, exists := resolvePath(item, c.path)
+	switch c.name {
+	case "attribute_exists":
+		return exists
+	case "attribute_not_exists":
+		return !exists
+	}
+	if !exists {
+		return false
+	}
+	argument, ok := c.argument.evaluate(item)
+	if !ok {
+		return false
+	}
+
+	switch c.name {
+	case "attribute_type":
+		kind, ok := argument.(*types.AttributeValueMemberS)
+		return ok && typeOf(value) == kind.Value
+	case "begins_with":
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			prefix, ok := argument.(*types.AttributeValueMemberS)
+			return ok && strings.HasPrefix(v.Value, prefix.Value)
+		case *types.AttributeValueMemberB:
+			prefix, ok := argument.(*types.AttributeValueMemberB)
+			return ok && strings.HasPrefix(string(v.Value), string(prefix.Value))
+		}
+	case "contains":
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			sub, ok := argument.(*types.AttributeValueMemberS)
+			return ok && strings.Contains(v.Value, sub.Value)
+		case *types.AttributeValueMemberSS:
+			for _, member := range v.Value {
+				if equalValues(&types.AttributeValueMemberS{Value: member}, argument) {
+					return true
+				}
+			}
+		case *types.AttributeValueMemberNS:
+			for _, member := range v.Value {
+				if equalValues(&types.AttributeValueMemberN{Value: member}, argument) {
+					return true
+				}
+			}
+		case *types.AttributeValueMemberL:
+			for _, member := range v.Value {
+				if equalValues(member, argument) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+var conditionFunctions = map[string]bool{
+	"attribute_exists":     true,
+	"attribute_not_exists": true,
+	"attribute_type":       true,
+	"begins_with":          true,
+	"contains":             true,
+}
+
+func parseCondition(expression string, names map[string]string, values map[string]types.AttributeValue) (condition, *parser, error) {
+	p, err := newParser(expression, names, values)
+	if err != nil {
+		return nil, nil, err
+	}
+	c, err := p.parseOr()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := p.end(); err != nil {
+		return nil, nil, err
+	}
+	return c, p, nil
+}
+
+func (p *parser) parseOr() (condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orCondition{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = andCondition{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (condition, error) {
+	if p.keyword("NOT") {
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return notCondition{inner: inner}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (condition, error) {
+	if p.symbol("(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return inner, nil
+	}
+
+	t := p.peek()
+	if t.kind == tokenName && conditionFunctions[strings.ToLower(t.text)] && p.tokens[p.pos+1].text == "(" {
+		return p.parseFunction()
+	}
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.keyword("BETWEEN") {
+		low, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("AND") {
+			return nil, p.syntaxError()
+		}
+		high, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		return between{value: left, low: low, high: high}, nil
+	}
+
+	if p.keyword("IN") {
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		var candidates []operand
+		for {
+			candidate, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, candidate)
+			if !p.symbol(",") {
+				break
+			}
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return in{value: left, candidates: candidates}, nil
+	}
+
+	operator := p.next()
+	switch operator.text {
+	case "=", "<>", "<", "<=", ">", ">=":
+	default:
+		p.pos--
+		return nil, p.syntaxError()
+	}
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	return comparison{operator: operator.text, left: left, right: right}, nil
+}
+
+func (p *parser) parseFunction() (condition, error) {
+	name := strings.ToLower(p.next().text)
+	p.next()
+
+	pa, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	c := function{name: name, path: pa}
+	if name != "attribute_exists" && name != "attribute_not_exists" {
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		if c.argument, err = p.parseOperand(); err != nil {
+			return nil, err
+		}
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func parseProjection(expression string, names map[string]string) ([]path, *parser, error) {
+	p, err := newParser(expression, names, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var paths []path
+	for {
+		pa, err := p.parsePath()
+		if err != nil {
+			return nil, nil, err
+		}
+		paths = append(paths, pa)
+		if !p.symbol(",") {
+			break
+		}
+	}
+	if err := p.end(); err != nil {
+		return nil, nil, err
+	}
+	return paths, p, nil
+}
+
+func project(item map[string]types.AttributeValue, paths []path) map[string]types.AttributeValue {
+	projected := make(map[string]types.AttributeValue)
+	for _, pa := range paths {
+		value, ok := resolvePath(item, pa)
+		if !ok {
+			continue
+		}
+		// Nested projections keep only the requested branch of each map.
+		target := projected
+		for i, element := range pa[:len(pa)-1] {
+			if element.list || pa[i+1].list {
+				target[pa[0].name] = copyValue(item[pa[0].name])
+				target = nil
+				break
+			}
+			next, ok := target[element.name].(*types.AttributeValueMemberM)
+			if !ok {
+				next = &types.AttributeValueMemberM{Value: make(map[string]types.AttributeValue)}
+				target[element.name] = next
+			}
+			target = next.Value
+		}
+		if target != nil {
+			target[pa[len(pa)-1].name] = copyValue(value)
+		}
+	}
+	return projected
+}
+
+// checkUnused rejects placeholders that no expression of the request referenced, as DynamoDB does.
+func checkUnused(names map[string]string, values map[string]types.AttributeValue, parsers ...*parser) error {
+	usedNames := make(map[string]bool)
+	usedValues := make(map[string]bool)
+	for _, p := range parsers {
+		if p == nil {
+			continue
+		}
+		for name := range p.usedNames {
+			usedNames[name] = true
+		}
+		for value := range p.usedValues {
+			usedValues[value] = true
+		}
+	}
+
+	var unusedNames, unusedValues []string
+	for name := range names {
+		if !usedNames[name] {
+			unusedNames = append(unusedNames, name)
+		}
+	}
+	for value := range values {
+		if !usedValues[value] {
+			unusedValues = append(unusedValues, value)
+		}
+	}
+	sort.Strings(unusedNames)
+	sort.Strings(unusedValues)
+
+	if len(unusedNames) > 0 {
+		return validationError(fmt.Sprintf("Value provided in ExpressionAttributeNames unused in expressions: keys: {%s}", strings.Join(unusedNames, ", ")))
+	}
+	if len(unusedValues) > 0 {
+		return validationError(fmt.Sprintf("Value provided in ExpressionAttributeValues unused in expressions: keys: {%s}", strings.Join(unusedValues, ", ")))
+	}
+	return nil
+}
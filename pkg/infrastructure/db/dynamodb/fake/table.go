@@ -0,0 +1,385 @@
+package fake
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type keySchema struct {
+	hash string
+	rng  string
+}
+
+func newKeySchema(elements []types.KeySchemaElement) (keySchema, error) {
+	var key keySchema
+	for _, element := range elements {
+		switch element.KeyType {
+		case types.KeyTypeHash:
+			key.hash = aws.ToString(element.AttributeName)
+		case types.KeyTypeRange:
+			key.rng = aws.ToString(element.AttributeName)
+		}
+	}
+	if key.hash == "" {
+		return keySchema{}, validationError("KeySchema requires a HASH key")
+	}
+	return key, nil
+}
+
+func (k keySchema) names() []string {
+	if k.rng == "" {
+		return []string{k.hash}
+	}
+	return []string{k.hash, k.rng}
+}
+
+func (k keySchema) elements() []types.KeySchemaElement {
+	elements := []types.KeySchemaElement{{AttributeName: aws.String(k.hash), KeyType: types.KeyTypeHash}}
+	if k.rng != "" {
+		elements = append(elements, types.KeySchemaElement{AttributeName: aws.String(k.rng), KeyType: types.KeyTypeRange})
+	}
+	return elements
+}
+
+type table struct {
+	name       string
+	key        keySchema
+	attributes []types.AttributeDefinition
+	indexes    map[string]*index
+	items      map[string]map[string]types.AttributeValue
+}
+
+type index struct {
+	name       string
+	key        keySchema
+	projection types.Projection
+}
+
+func (t *table) index(name *string) (*index, error) {
+	if name == nil {
+		return nil, nil
+	}
+	idx, ok := t.indexes[*name]
+	if !ok {
+		return nil, validationError(fmt.Sprintf("The table does not have the specified index: %s", *name))
+	}
+	return idx, nil
+}
+
+func (t *table) accessKey(idx *index) keySchema {
+	if idx != nil {
+		return idx.key
+	}
+	return t.key
+}
+
+// keyOf encodes the primary key of item. With exact set, item must hold nothing but the key attributes.
+func (t *table) keyOf(item map[string]types.AttributeValue, exact bool) (string, error) {
+	if exact && len(item) != len(t.key.names()) {
+		return "", validationError("The provided key element does not match the schema")
+	}
+	hash, err := keyPart(item, t.key.hash)
+	if err != nil {
+		return "", err
+	}
+	if t.key.rng == "" {
+		return hash, nil
+	}
+	rng, err := keyPart(item, t.key.rng)
+	if err != nil {
+		return "", err
+	}
+	return hash + "\x00" + rng, nil
+}
+
+// ordering lists the attributes that sort items on an access path: the index key first, then the table key.
+func (t *table) ordering(idx *index) []string {
+	var names []string
+	seen := make(map[string]bool)
+	keys := t.key.names()
+	if idx != nil {
+		keys = append(idx.key.names(), keys...)
+	}
+	for _, name := range keys {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ordered returns the items visible on the access path, sorted the way DynamoDB pages through them.
+func (t *table) ordered(idx *index) []map[string]types.AttributeValue {
+	ordering := t.ordering(idx)
+	items := make([]map[string]types.AttributeValue, 0, len(t.items))
+	for _, item := range t.items {
+		// Sparse indexes only hold items that carry the index key attributes.
+		if idx != nil && !hasKey(item, idx.key) {
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return compareItems(items[i], items[j], ordering) < 0
+	})
+	return items
+}
+
+func hasKey(item map[string]types.AttributeValue, key keySchema) bool {
+	for _, name := range key.names() {
+		switch item[name].(type) {
+		case *types.AttributeValueMemberS, *types.AttributeValueMemberN, *types.AttributeValueMemberB:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func compareItems(a, b map[string]types.AttributeValue, ordering []string) int {
+	for _, name := range ordering {
+		av, bv := a[name], b[name]
+		if ta, tb := typeOf(av), typeOf(bv); ta != tb {
+			if ta < tb {
+				return -1
+			}
+			return 1
+		}
+		if result, ok := compareValues(av, bv); ok && result != 0 {
+			return result
+		}
+	}
+	return 0
+}
+
+func (t *table) projectIndex(item map[string]types.AttributeValue, idx *index) map[string]types.AttributeValue {
+	if idx == nil || idx.projection.ProjectionType == "" || idx.projection.ProjectionType == types.ProjectionTypeAll {
+		return copyItem(item)
+	}
+	names := t.ordering(idx)
+	if idx.projection.ProjectionType == types.ProjectionTypeInclude {
+		names = append(names, idx.projection.NonKeyAttributes...)
+	}
+	return pick(item, names)
+}
+
+func (t *table) describe() *types.TableDescription {
+	description := &types.TableDescription{
+		TableName:            aws.String(t.name),
+		TableStatus:          types.TableStatusActive,
+		KeySchema:            t.key.elements(),
+		AttributeDefinitions: t.attributes,
+		ItemCount:            aws.Int64(int64(len(t.items))),
+	}
+
+	names := make([]string, 0, len(t.indexes))
+	for name := range t.indexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		idx := t.indexes[name]
+		projection := idx.projection
+		description.GlobalSecondaryIndexes = append(description.GlobalSecondaryIndexes, types.GlobalSecondaryIndexDescription{
+			IndexName:   aws.String(idx.name),
+			IndexStatus: types.IndexStatusActive,
+			KeySchema:   idx.key.elements(),
+			Projection:  &projection,
+		})
+	}
+	return description
+}
+
+type readInput struct {
+	keyCondition      condition
+	filter            *string
+	projection        *string
+	names             map[string]string
+	values            map[string]types.AttributeValue
+	limit             *int32
+	exclusiveStartKey map[string]types.AttributeValue
+	descending        bool
+	countOnly         bool
+	parsers           []*parser
+}
+
+type readPage struct {
+	items            []map[string]types.AttributeValue
+	count            int32
+	scanned          int32
+	lastEvaluatedKey map[string]types.AttributeValue
+}
+
+func (c *Client) read(t *table, idx *index, input readInput) (readPage, error) {
+	parsers := input.parsers
+
+	var filter condition
+	if input.filter != nil {
+		var (
+			p   *parser
+			err error
+		)
+		filter, p, err = parseCondition(*input.filter, input.names, input.values)
+		if err != nil {
+			return readPage{}, err
+		}
+		parsers = append(parsers, p)
+	}
+
+	var projection []path
+	if input.projection != nil {
+		var (
+			p   *parser
+			err error
+		)
+		projection, p, err = parseProjection(*input.projection, input.names)
+		if err != nil {
+			return readPage{}, err
+		}
+		parsers = append(parsers, p)
+	}
+	if err := checkUnused(input.names, input.values, parsers...); err != nil {
+		return readPage{}, err
+	}
+
+	limit := c.maxPageSize
+	if input.limit != nil {
+		if *input.limit <= 0 {
+			return readPage{}, validationError("Limit must be greater than or equal to 1")
+		}
+		if limit == 0 || int(*input.limit) < limit {
+			limit = int(*input.limit)
+		}
+	}
+
+	ordering := t.ordering(idx)
+	candidates := t.ordered(idx)
+	if input.descending {
+		for i, j := 0, len(candidates)-1; i < j; i, j = i+1, j-1 {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		}
+	}
+
+	page := readPage{}
+	if !input.countOnly {
+		page.items = []map[string]types.AttributeValue{}
+	}
+	evaluated := 0
+	for i, item := range candidates {
+		if input.keyCondition != nil && !input.keyCondition.matches(item) {
+			continue
+		}
+		if input.exclusiveStartKey != nil {
+			result := compareItems(item, input.exclusiveStartKey, ordering)
+			if (!input.descending && result <= 0) || (input.descending && result >= 0) {
+				continue
+			}
+		}
+
+		evaluated++
+		page.scanned++
+		if filter == nil || filter.matches(item) {
+			page.count++
+			if !input.countOnly {
+				visible := t.projectIndex(item, idx)
+				if projection != nil {
+					visible = project(visible, projection)
+				}
+				page.items = append(page.items, visible)
+			}
+		}
+
+		if limit > 0 && evaluated == limit && c.hasMore(candidates[i+1:], input.keyCondition) {
+			page.lastEvaluatedKey = pick(item, ordering)
+			break
+		}
+	}
+	return page, nil
+}
+
+func (c *Client) hasMore(rest []map[string]types.AttributeValue, keyCondition condition) bool {
+	for _, item := range rest {
+		if keyCondition == nil || keyCondition.matches(item) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateKeyCondition(c condition, key keySchema) error {
+	hashMatched := false
+	var walk func(c condition) error
+	walk = func(c condition) error {
+		switch v := c.(type) {
+		case andCondition:
+			if err := walk(v.left); err != nil {
+				return err
+			}
+			return walk(v.right)
+		case comparison:
+			name, ok := keyOperandName(v.left)
+			if !ok {
+				return validationError("Invalid KeyConditionExpression: the left operand must be a key attribute")
+			}
+			if name == key.hash {
+				if v.operator != "=" {
+					return validationError("Query key condition not supported")
+				}
+				hashMatched = true
+				return nil
+			}
+			if name != key.rng || v.operator == "<>" {
+				return validationError("Query condition missed key schema element")
+			}
+			return nil
+		case between:
+			if name, ok := keyOperandName(v.value); !ok || name != key.rng {
+				return validationError("Query condition missed key schema element")
+			}
+			return nil
+		case function:
+			if v.name != "begins_with" || len(v.path) != 1 || v.path[0].name != key.rng {
+				return validationError("Invalid KeyConditionExpression: only begins_with on the sort key is supported")
+			}
+			return nil
+		}
+		return validationError("Invalid operator used in KeyConditionExpression")
+	}
+	if err := walk(c); err != nil {
+		return err
+	}
+	if !hashMatched {
+		return validationError("Query condition missed key schema element: " + key.hash)
+	}
+	return nil
+}
+
+func keyOperandName(o operand) (string, bool) {
+	p, ok := o.(pathOperand)
+	if !ok || len(p.path) != 1 {
+		return "", false
+	}
+	return p.path[0].name, true
+}
+
+func keyPart(item map[string]types.AttributeValue, name string) (string, error) {
+	switch v := item[name].(type) {
+	case *types.AttributeValueMemberS:
+		if v.Value == "" {
+			return "", validationError(fmt.Sprintf("One or more parameter values are not valid. The AttributeValue for a key attribute cannot contain an empty string value. Key: %s", name))
+		}
+		return "S:" + v.Value, nil
+	case *types.AttributeValueMemberN:
+		return "N:" + canonicalNumber(v.Value), nil
+	case *types.AttributeValueMemberB:
+		return "B:" + string(v.Value), nil
+	case nil:
+		return "", validationError(fmt.Sprintf("One of the required keys was not given a value: %s", name))
+	default:
+		return "", validationError(fmt.Sprintf("One or more parameter values were invalid: Type mismatch for key %s", name))
+	}
+}
@@ -0,0 +1,288 @@
+package fake
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+type updateAction struct {
+	clause  string
+	path    path
+	value   setValue
+	operand types.AttributeValue
+}
+
+type setValue interface {
+	evaluate(item map[string]types.AttributeValue) (types.AttributeValue, error)
+}
+
+type setOperand struct{ operand operand }
+
+func (v setOperand) evaluate(item map[string]types.AttributeValue) (types.AttributeValue, error) {
+	value, ok := v.operand.evaluate(item)
+	if !ok {
+		return nil, validationError("The provided expression refers to an attribute that does not exist in the item")
+	}
+	return value, nil
+}
+
+type ifNotExists struct {
+	path     path
+	fallback setValue
+}
+
+func (v ifNotExists) evaluate(item map[string]types.AttributeValue) (types.AttributeValue, error) {
+	if value, ok := resolvePath(item, v.path); ok {
+		return value, nil
+	}
+	return v.fallback.evaluate(item)
+}
+
+type listAppend struct{ left, right setValue }
+
+func (v listAppend) evaluate(item map[string]types.AttributeValue) (types.AttributeValue, error) {
+	left, err := v.left.evaluate(item)
+	if err != nil {
+		return nil, err
+	}
+	right, err := v.right.evaluate(item)
+	if err != nil {
+		return nil, err
+	}
+	l, lok := left.(*types.AttributeValueMemberL)
+	r, rok := right.(*types.AttributeValueMemberL)
+	if !lok || !rok {
+		return nil, validationError("An operand in the update expression has an incorrect data type")
+	}
+	values := append(append([]types.AttributeValue{}, l.Value...), r.Value...)
+	return &types.AttributeValueMemberL{Value: values}, nil
+}
+
+type arithmetic struct {
+	left, right setValue
+	subtract    bool
+}
+
+func (v arithmetic) evaluate(item map[string]types.AttributeValue) (types.AttributeValue, error) {
+	left, err := v.left.evaluate(item)
+	if err != nil {
+		return nil, err
+	}
+	right, err := v.right.evaluate(item)
+	if err != nil {
+		return nil, err
+	}
+	return addNumbers(left, right, v.subtract)
+}
+
+func parseUpdate(expression string, names map[string]string, values map[string]types.AttributeValue) ([]updateAction, *parser, error) {
+	p, err := newParser(expression, names, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var actions []updateAction
+	seen := make(map[string]bool)
+	for p.peek().kind != tokenEOF {
+		clause := strings.ToUpper(p.next().text)
+		if seen[clause] {
+			return nil, nil, validationError("Invalid UpdateExpression: The \"" + clause + "\" section can only be used once in an update expression")
+		}
+		seen[clause] = true
+
+		for {
+			action, err := p.parseUpdateAction(clause)
+			if err != nil {
+				return nil, nil, err
+			}
+			actions = append(actions, action)
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+	if len(actions) == 0 {
+		return nil, nil, validationError("Invalid UpdateExpression: The expression can not be empty")
+	}
+
+	// Two actions on overlapping paths are ambiguous and rejected by DynamoDB.
+	for i := range actions {
+		for j := i + 1; j < len(actions); j++ {
+			a, b := actions[i].path.String(), actions[j].path.String()
+			if a == b || strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".") || strings.HasPrefix(a, b+"[") || strings.HasPrefix(b, a+"[") {
+				return nil, nil, validationError("Invalid UpdateExpression: Two document paths overlap with each other; must remove or rewrite one of these paths; path one: [" + a + "], path two: [" + b + "]")
+			}
+		}
+	}
+
+	return actions, p, nil
+}
+
+func (p *parser) parseUpdateAction(clause string) (updateAction, error) {
+	pa, err := p.parsePath()
+	if err != nil {
+		return updateAction{}, err
+	}
+	action := updateAction{clause: clause, path: pa}
+
+	switch clause {
+	case "SET":
+		if err := p.expect("="); err != nil {
+			return updateAction{}, err
+		}
+		action.value, err = p.parseSetValue()
+		if err != nil {
+			return updateAction{}, err
+		}
+	case "REMOVE":
+	case "ADD", "DELETE":
+		if len(pa) != 1 {
+			return updateAction{}, validationError("Invalid UpdateExpression: " + clause + " only supports top-level attributes")
+		}
+		if p.peek().kind != tokenValue {
+			return updateAction{}, p.syntaxError()
+		}
+		action.operand, err = p.parseValue()
+		if err != nil {
+			return updateAction{}, err
+		}
+	default:
+		p.pos--
+		return updateAction{}, p.syntaxError()
+	}
+	return action, nil
+}
+
+func (p *parser) parseSetValue() (setValue, error) {
+	left, err := p.parseSetOperand()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.symbol("+"):
+		right, err := p.parseSetOperand()
+		if err != nil {
+			return nil, err
+		}
+		return arithmetic{left: left, right: right}, nil
+	case p.symbol("-"):
+		right, err := p.parseSetOperand()
+		if err != nil {
+			return nil, err
+		}
+		return arithmetic{left: left, right: right, subtract: true}, nil
+	}
+	return left, nil
+}
+
+func (p *parser) parseSetOperand() (setValue, error) {
+	t := p.peek()
+	if t.kind == tokenName && p.tokens[p.pos+1].text == "(" {
+		switch strings.ToLower(t.text) {
+		case "if_not_exists":
+			p.pos += 2
+			pa, err := p.parsePath()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+			fallback, err := p.parseSetOperand()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return ifNotExists{path: pa, fallback: fallback}, nil
+		case "list_append":
+			p.pos += 2
+			left, err := p.parseSetOperand()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+			right, err := p.parseSetOperand()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return listAppend{left: left, right: right}, nil
+		}
+	}
+
+	o, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := o.(sizeOperand); ok {
+		return nil, validationError("Invalid UpdateExpression: The function is not allowed in an update expression; function: size")
+	}
+	return setOperand{operand: o}, nil
+}
+
+// applyUpdate returns the updated copy of item and the top-level attributes the actions touched.
+func applyUpdate(item map[string]types.AttributeValue, actions []updateAction) (map[string]types.AttributeValue, []string, error) {
+	updated := copyItem(item)
+	if updated == nil {
+		updated = make(map[string]types.AttributeValue)
+	}
+
+	touched := make([]string, 0, len(actions))
+	for _, action := range actions {
+		touched = append(touched, action.path[0].name)
+
+		switch action.clause {
+		case "SET":
+			// Every right-hand side sees the item as it was before the update.
+			value, err := action.value.evaluate(item)
+			if err != nil {
+				return nil, nil, err
+			}
+			if err := setPath(updated, action.path, copyValue(value)); err != nil {
+				return nil, nil, err
+			}
+		case "REMOVE":
+			if _, ok := resolvePath(updated, action.path); ok {
+				if err := setPath(updated, action.path, nil); err != nil {
+					return nil, nil, err
+				}
+			}
+		case "ADD":
+			current, exists := updated[action.path[0].name]
+			var (
+				value types.AttributeValue
+				err   error
+			)
+			switch {
+			case typeOf(action.operand) == "N" && !exists:
+				value = copyValue(action.operand)
+			case typeOf(action.operand) == "N":
+				value, err = addNumbers(current, action.operand, false)
+			default:
+				value, err = addToSet(current, action.operand, false)
+			}
+			if err != nil {
+				return nil, nil, err
+			}
+			updated[action.path[0].name] = value
+		case "DELETE":
+			value, err := addToSet(updated[action.path[0].name], action.operand, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			if value == nil {
+				delete(updated, action.path[0].name)
+			} else {
+				updated[action.path[0].name] = value
+			}
+		}
+	}
+	return updated, touched, nil
+}
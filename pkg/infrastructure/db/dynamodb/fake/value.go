@@ -0,0 +1,257 @@
+package fake
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+func typeOf(value types.AttributeValue) string {
+	switch value.(type) {
+	case *types.AttributeValueMemberS:
+		return "S"
+	case *types.AttributeValueMemberN:
+		return "N"
+	case *types.AttributeValueMemberB:
+		return "B"
+	case *types.AttributeValueMemberBOOL:
+		return "BOOL"
+	case *types.AttributeValueMemberNULL:
+		return "NULL"
+	case *types.AttributeValueMemberSS:
+		return "SS"
+	case *types.AttributeValueMemberNS:
+		return "NS"
+	case *types.AttributeValueMemberBS:
+		return "BS"
+	case *types.AttributeValueMemberL:
+		return "L"
+	case *types.AttributeValueMemberM:
+		return "M"
+	}
+	return ""
+}
+
+func parseNumber(value string) (*big.Rat, error) {
+	number, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, validationError(fmt.Sprintf("The parameter cannot be converted to a numeric value: %s", value))
+	}
+	return number, nil
+}
+
+func formatNumber(number *big.Rat) string {
+	if number.IsInt() {
+		return number.Num().String()
+	}
+	formatted := number.FloatString(38)
+	formatted = strings.TrimRight(formatted, "0")
+	return strings.TrimSuffix(formatted, ".")
+}
+
+// compareValues orders two scalar values of the same type. ok is false when the values are not comparable.
+func compareValues(a, b types.AttributeValue) (result int, ok bool) {
+	switch av := a.(type) {
+	case *types.AttributeValueMemberS:
+		bv, isS := b.(*types.AttributeValueMemberS)
+		if !isS {
+			return 0, false
+		}
+		return strings.Compare(av.Value, bv.Value), true
+	case *types.AttributeValueMemberN:
+		bv, isN := b.(*types.AttributeValueMemberN)
+		if !isN {
+			return 0, false
+		}
+		an, err := parseNumber(av.Value)
+		if err != nil {
+			return 0, false
+		}
+		bn, err := parseNumber(bv.Value)
+		if err != nil {
+			return 0, false
+		}
+		return an.Cmp(bn), true
+	case *types.AttributeValueMemberB:
+		bv, isB := b.(*types.AttributeValueMemberB)
+		if !isB {
+			return 0, false
+		}
+		return bytes.Compare(av.Value, bv.Value), true
+	}
+	return 0, false
+}
+
+func equalValues(a, b types.AttributeValue) bool {
+	if typeOf(a) != typeOf(b) {
+		return false
+	}
+	switch av := a.(type) {
+	case *types.AttributeValueMemberS, *types.AttributeValueMemberN, *types.AttributeValueMemberB:
+		result, ok := compareValues(a, b)
+		return ok && result == 0
+	case *types.AttributeValueMemberBOOL:
+		return av.Value == b.(*types.AttributeValueMemberBOOL).Value
+	case *types.AttributeValueMemberNULL:
+		return true
+	case *types.AttributeValueMemberSS:
+		return equalSets(av.Value, b.(*types.AttributeValueMemberSS).Value, func(s string) string { return s })
+	case *types.AttributeValueMemberNS:
+		return equalSets(av.Value, b.(*types.AttributeValueMemberNS).Value, canonicalNumber)
+	case *types.AttributeValueMemberBS:
+		return equalSets(av.Value, b.(*types.AttributeValueMemberBS).Value, func(b []byte) string { return string(b) })
+	case *types.AttributeValueMemberL:
+		bv := b.(*types.AttributeValueMemberL).Value
+		if len(av.Value) != len(bv) {
+			return false
+		}
+		for i := range av.Value {
+			if !equalValues(av.Value[i], bv[i]) {
+				return false
+			}
+		}
+		return true
+	case *types.AttributeValueMemberM:
+		bv := b.(*types.AttributeValueMemberM).Value
+		if len(av.Value) != len(bv) {
+			return false
+		}
+		for name, value := range av.Value {
+			other, ok := bv[name]
+			if !ok || !equalValues(value, other) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func equalSets[T any](a, b []T, key func(T) string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := make(map[string]bool, len(a))
+	for _, v := range a {
+		keys[key(v)] = true
+	}
+	for _, v := range b {
+		if !keys[key(v)] {
+			return false
+		}
+	}
+	return true
+}
+
+func canonicalNumber(value string) string {
+	number, err := parseNumber(value)
+	if err != nil {
+		return value
+	}
+	return formatNumber(number)
+}
+
+func addNumbers(a, b types.AttributeValue, subtract bool) (types.AttributeValue, error) {
+	an, aok := a.(*types.AttributeValueMemberN)
+	bn, bok := b.(*types.AttributeValueMemberN)
+	if !aok || !bok {
+		return nil, validationError("An operand in the update expression has an incorrect data type")
+	}
+	x, err := parseNumber(an.Value)
+	if err != nil {
+		return nil, err
+	}
+	y, err := parseNumber(bn.Value)
+	if err != nil {
+		return nil, err
+	}
+	if subtract {
+		return &types.AttributeValueMemberN{Value: formatNumber(new(big.Rat).Sub(x, y))}, nil
+	}
+	return &types.AttributeValueMemberN{Value: formatNumber(new(big.Rat).Add(x, y))}, nil
+}
+
+// addToSet implements ADD and DELETE on string, number and binary sets.
+func addToSet(current, operand types.AttributeValue, remove bool) (types.AttributeValue, error) {
+	if current == nil {
+		if remove {
+			return nil, nil
+		}
+		return copyValue(operand), nil
+	}
+	if typeOf(current) != typeOf(operand) {
+		return nil, validationError("An operand in the update expression has an incorrect data type")
+	}
+
+	switch cv := current.(type) {
+	case *types.AttributeValueMemberSS:
+		values := mergeSet(cv.Value, operand.(*types.AttributeValueMemberSS).Value, func(s string) string { return s }, remove)
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberSS{Value: values}, nil
+	case *types.AttributeValueMemberNS:
+		values := mergeSet(cv.Value, operand.(*types.AttributeValueMemberNS).Value, canonicalNumber, remove)
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberNS{Value: values}, nil
+	case *types.AttributeValueMemberBS:
+		values := mergeSet(cv.Value, operand.(*types.AttributeValueMemberBS).Value, func(b []byte) string { return string(b) }, remove)
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return &types.AttributeValueMemberBS{Value: values}, nil
+	}
+	return nil, validationError("An operand in the update expression has an incorrect data type")
+}
+
+func mergeSet[T any](current, operand []T, key func(T) string, remove bool) []T {
+	index := make(map[string]T, len(current))
+	for _, v := range current {
+		index[key(v)] = v
+	}
+	for _, v := range operand {
+		if remove {
+			delete(index, key(v))
+		} else {
+			index[key(v)] = v
+		}
+	}
+
+	keys := make([]string, 0, len(index))
+	for k := range index {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	values := make([]T, 0, len(keys))
+	for _, k := range keys {
+		values = append(values, index[k])
+	}
+	return values
+}
+
+func sizeOf(value types.AttributeValue) (int, bool) {
+	switch v := value.(type) {
+	case *types.AttributeValueMemberS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberB:
+		return len(v.Value), true
+	case *types.AttributeValueMemberSS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberNS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberBS:
+		return len(v.Value), true
+	case *types.AttributeValueMemberL:
+		return len(v.Value), true
+	case *types.AttributeValueMemberM:
+		return len(v.Value), true
+	}
+	return 0, false
+}
@@ -0,0 +1,124 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidMigration = errors.New("invalid migration")
+
+// Migration is one versioned schema change with the SQL that applies it and
+// the SQL that reverts it.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load reads the migrations in dir of fsys. Every migration is a pair of
+// files named <version>_<name>.up.sql and <version>_<name>.down.sql; the
+// result is sorted by version.
+func Load(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: %s does not match <version>_<name>.(up|down).sql", ErrInvalidMigration, entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("%w: %s has an invalid version", ErrInvalidMigration, entry.Name())
+		}
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("%w: version %d is used by %s and %s", ErrInvalidMigration, version, migration.Name, match[2])
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if strings.TrimSpace(migration.Up) == "" {
+			return nil, fmt.Errorf("%w: %04d_%s has no up migration", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("%w: %04d_%s has no down migration", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+var nameSeparators = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Create writes an empty up/down pair for name into every directory in dirs,
+// numbered one past the highest version found in any of them, and returns
+// the paths it wrote. Passing one directory per dialect keeps the versions
+// of all dialects aligned.
+func Create(name string, dirs ...string) ([]string, error) {
+	name = strings.Trim(nameSeparators.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("%w: empty name", ErrInvalidMigration)
+	}
+
+	var latest int64
+	for _, dir := range dirs {
+		migrations, err := Load(os.DirFS(dir), ".")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		for _, migration := range migrations {
+			latest = max(latest, migration.Version)
+		}
+	}
+
+	var paths []string
+	for _, dir := range dirs {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", latest+1, name, direction))
+			content := fmt.Sprintf("-- %s: %s\n", name, direction)
+			if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+				return nil, err
+			}
+			paths = append(paths, file)
+		}
+	}
+	return paths, nil
+}
@@ -0,0 +1,100 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []Migration
+		wantErr string
+	}{
+		{
+			name: "Sorted pairs",
+			files: fstest.MapFS{
+				"sql/0002_add_sku.up.sql":          {Data: []byte("ALTER TABLE t ADD sku TEXT")},
+				"sql/0002_add_sku.down.sql":        {Data: []byte("ALTER TABLE t DROP sku")},
+				"sql/0001_create_table.up.sql":     {Data: []byte("CREATE TABLE t (id TEXT)")},
+				"sql/0001_create_table.down.sql":   {Data: []byte("DROP TABLE t")},
+				"sql/README.md":                    {Data: []byte("ignored")},
+				"sql/nested/0003_ignored.up.sql":   {Data: []byte("ignored")},
+				"sql/nested/0003_ignored.down.sql": {Data: []byte("ignored")},
+			},
+			want: []Migration{
+				{Version: 1, Name: "create_table", Up: "CREATE TABLE t (id TEXT)", Down: "DROP TABLE t"},
+				{Version: 2, Name: "add_sku", Up: "ALTER TABLE t ADD sku TEXT", Down: "ALTER TABLE t DROP sku"},
+			},
+		},
+		{
+			name:    "Invalid file name",
+			files:   fstest.MapFS{"sql/create_table.up.sql": {Data: []byte("CREATE TABLE t (id TEXT)")}},
+			wantErr: "invalid migration: create_table.up.sql does not match <version>_<name>.(up|down).sql",
+		},
+		{
+			name: "Missing down",
+			files: fstest.MapFS{
+				"sql/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (id TEXT)")},
+			},
+			wantErr: "invalid migration: 0001_create_table has no down migration",
+		},
+		{
+			name: "Duplicate version",
+			files: fstest.MapFS{
+				"sql/0001_create_table.up.sql": {Data: []byte("CREATE TABLE t (id TEXT)")},
+				"sql/0001_create_other.up.sql": {Data: []byte("CREATE TABLE o (id TEXT)")},
+			},
+			wantErr: "invalid migration: version 1 is used by create_other and create_table",
+		},
+		{
+			name:    "Zero version",
+			files:   fstest.MapFS{"sql/0000_create_table.up.sql": {Data: []byte("CREATE TABLE t (id TEXT)")}},
+			wantErr: "invalid migration: 0000_create_table.up.sql has an invalid version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migrations, err := Load(tt.files, "sql")
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, ErrInvalidMigration)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, migrations)
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	root := t.TempDir()
+	sqliteDir := filepath.Join(root, "sqlite")
+	postgresDir := filepath.Join(root, "postgres")
+	require.NoError(t, os.MkdirAll(sqliteDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sqliteDir, "0003_create_table.up.sql"), []byte("CREATE TABLE t (id TEXT)"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(sqliteDir, "0003_create_table.down.sql"), []byte("DROP TABLE t"), 0o644))
+
+	paths, err := Create("Add product SKU!", sqliteDir, postgresDir)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(sqliteDir, "0004_add_product_sku.up.sql"),
+		filepath.Join(sqliteDir, "0004_add_product_sku.down.sql"),
+		filepath.Join(postgresDir, "0004_add_product_sku.up.sql"),
+		filepath.Join(postgresDir, "0004_add_product_sku.down.sql"),
+	}, paths)
+
+	migrations, err := Load(os.DirFS(postgresDir), ".")
+	assert.NoError(t, err)
+	assert.Equal(t, []Migration{{Version: 4, Name: "add_product_sku", Up: "-- add_product_sku: up\n", Down: "-- add_product_sku: down\n"}}, migrations)
+
+	_, err = Create("!!!", sqliteDir)
+	assert.ErrorIs(t, err, ErrInvalidMigration)
+}
@@ -0,0 +1,369 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"time"
+)
+
+const (
+	DialectSQLite   = "sqlite"
+	DialectPostgres = "postgres"
+)
+
+var (
+	ErrUnsupportedDialect = errors.New("unsupported migration dialect")
+	ErrLocked             = errors.New("migrations are locked")
+	ErrSchemaOutdated     = errors.New("schema is out of date")
+	ErrNoMigration        = errors.New("no migration to roll back")
+)
+
+// Status describes a migration known to the source, the database or both.
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	// Missing is set for versions recorded in the database that no longer
+	// have a migration in the source.
+	Missing bool
+}
+
+type dialect struct {
+	placeholder func(n int) string
+	tableExists string
+	lock        func(ctx context.Context, conn *sql.Conn, m *Migrator) (bool, error)
+	unlock      func(ctx context.Context, conn *sql.Conn, m *Migrator) error
+}
+
+var dialects = map[string]dialect{
+	DialectSQLite: {
+		placeholder: func(int) string { return "?" },
+		tableExists: "SELECT COUNT(*) > 0 FROM sqlite_master WHERE type = 'table' AND name = ?",
+		// SQLite has no advisory locks, so a single-row lock table stands in.
+		// A row left behind by a crashed run is taken over once it is older
+		// than the stale lock age.
+		lock: func(ctx context.Context, conn *sql.Conn, m *Migrator) (bool, error) {
+			if _, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS "+m.lockTable()+" (id INTEGER PRIMARY KEY, locked_at TIMESTAMP NOT NULL)"); err != nil {
+				return false, err
+			}
+			if _, err := conn.ExecContext(ctx, "DELETE FROM "+m.lockTable()+" WHERE id = 1 AND locked_at < ?", time.Now().UTC().Add(-m.staleLockAge)); err != nil {
+				return false, err
+			}
+			result, err := conn.ExecContext(ctx, "INSERT OR IGNORE INTO "+m.lockTable()+" (id, locked_at) VALUES (1, ?)", time.Now().UTC())
+			if err != nil {
+				return false, err
+			}
+			rows, err := result.RowsAffected()
+			return rows == 1, err
+		},
+		unlock: func(ctx context.Context, conn *sql.Conn, m *Migrator) error {
+			_, err := conn.ExecContext(ctx, "DELETE FROM "+m.lockTable()+" WHERE id = 1")
+			return err
+		},
+	},
+	DialectPostgres: {
+		placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+		tableExists: "SELECT to_regclass($1) IS NOT NULL",
+		// Advisory locks are released with the session, so a crashed run
+		// never leaves one behind.
+		lock: func(ctx context.Context, conn *sql.Conn, m *Migrator) (bool, error) {
+			var locked bool
+			err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", m.lockKey()).Scan(&locked)
+			return locked, err
+		},
+		unlock: func(ctx context.Context, conn *sql.Conn, m *Migrator) error {
+			_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", m.lockKey())
+			return err
+		},
+	},
+}
+
+type Option func(*Migrator)
+
+var identifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// WithTable changes the table that records applied versions. It defaults to
+// schema_migrations.
+func WithTable(table string) Option {
+	return func(m *Migrator) {
+		m.table = table
+	}
+}
+
+// WithLockTimeout bounds how long a Migrator waits for another one to
+// release the migration lock.
+func WithLockTimeout(timeout time.Duration) Option {
+	return func(m *Migrator) {
+		m.lockTimeout = timeout
+	}
+}
+
+// WithStaleLockAge sets how old the SQLite lock row must be before another
+// Migrator takes it over, which recovers from runs that crashed while
+// holding it. It defaults to 15 minutes.
+func WithStaleLockAge(age time.Duration) Option {
+	return func(m *Migrator) {
+		m.staleLockAge = age
+	}
+}
+
+// Migrator applies and reverts migrations on db, recording the applied
+// versions in a table. Up and Down hold a lock so that concurrent instances
+// cannot migrate the same database at once.
+type Migrator struct {
+	db           *sql.DB
+	dialect      dialect
+	migrations   []Migration
+	table        string
+	lockTimeout  time.Duration
+	staleLockAge time.Duration
+}
+
+func New(db *sql.DB, dialectName string, migrations []Migration, opts ...Option) (*Migrator, error) {
+	d, exists := dialects[dialectName]
+	if !exists {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedDialect, dialectName)
+	}
+
+	m := &Migrator{db: db, dialect: d, migrations: migrations, table: "schema_migrations", lockTimeout: 15 * time.Second, staleLockAge: 15 * time.Minute}
+	for _, opt := range opts {
+		opt(m)
+	}
+	if !identifier.MatchString(m.table) {
+		return nil, fmt.Errorf("%w: invalid table name %q", ErrInvalidMigration, m.table)
+	}
+	for i := 1; i < len(m.migrations); i++ {
+		if m.migrations[i].Version <= m.migrations[i-1].Version {
+			return nil, fmt.Errorf("%w: migrations must be sorted by unique version", ErrInvalidMigration)
+		}
+	}
+	return m, nil
+}
+
+// Up applies pending migrations in version order, at most steps of them
+// when steps is positive, and returns the ones it applied.
+func (m *Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	var applied []Migration
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[int64]appliedVersion) error {
+		for _, migration := range m.migrations {
+			if steps > 0 && len(applied) == steps {
+				break
+			}
+			if _, done := versions[migration.Version]; done {
+				continue
+			}
+			insert := fmt.Sprintf("INSERT INTO %s (version, name, applied_at) VALUES (%s, %s, %s)",
+				m.table, m.dialect.placeholder(1), m.dialect.placeholder(2), m.dialect.placeholder(3))
+			if err := m.apply(ctx, conn, migration, migration.Up, insert, migration.Version, migration.Name, time.Now().UTC()); err != nil {
+				return err
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down reverts the latest applied migrations, steps of them (at least one),
+// and returns the ones it reverted.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	steps = max(steps, 1)
+
+	var reverted []Migration
+	err := m.locked(ctx, func(conn *sql.Conn, versions map[int64]appliedVersion) error {
+		for i := len(m.migrations) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := m.migrations[i]
+			if _, done := versions[migration.Version]; !done {
+				continue
+			}
+			remove := fmt.Sprintf("DELETE FROM %s WHERE version = %s", m.table, m.dialect.placeholder(1))
+			if err := m.apply(ctx, conn, migration, migration.Down, remove, migration.Version); err != nil {
+				return err
+			}
+			reverted = append(reverted, migration)
+		}
+		if len(reverted) == 0 {
+			return ErrNoMigration
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Status lists every migration in version order with whether it is applied.
+// It only reads the versions table, so it neither waits for nor blocks a
+// running migration.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, m.dialect.tableExists, m.table).Scan(&exists); err != nil {
+		return nil, err
+	}
+	versions := map[int64]appliedVersion{}
+	if exists {
+		if versions, err = m.versions(ctx, conn); err != nil {
+			return nil, err
+		}
+	}
+
+	var statuses []Status
+	known := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		status := Status{Version: migration.Version, Name: migration.Name}
+		if v, done := versions[migration.Version]; done {
+			status.Applied, status.AppliedAt = true, v.appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for version, v := range versions {
+		if !known[version] {
+			statuses = append(statuses, Status{Version: version, Name: v.name, Applied: true, AppliedAt: v.appliedAt, Missing: true})
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, nil
+}
+
+// Unlock releases the SQLite lock row left behind by a run that crashed
+// while holding it. Make sure no migration is running before calling it.
+// PostgreSQL advisory locks need no unlocking.
+func (m *Migrator) Unlock(ctx context.Context) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var exists bool
+	if err := conn.QueryRowContext(ctx, m.dialect.tableExists, m.lockTable()).Scan(&exists); err != nil || !exists {
+		return err
+	}
+	_, err = conn.ExecContext(ctx, "DELETE FROM "+m.lockTable())
+	return err
+}
+
+// Check returns ErrSchemaOutdated when migrations are pending.
+func (m *Migrator) Check(ctx context.Context) error {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	var pending []string
+	for _, status := range statuses {
+		if !status.Applied {
+			pending = append(pending, fmt.Sprintf("%04d_%s", status.Version, status.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %d pending migrations %v", ErrSchemaOutdated, len(pending), pending)
+	}
+	return nil
+}
+
+type appliedVersion struct {
+	name      string
+	appliedAt time.Time
+}
+
+func (m *Migrator) locked(ctx context.Context, fn func(conn *sql.Conn, versions map[int64]appliedVersion) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if err := m.lock(ctx, conn); err != nil {
+		return err
+	}
+	defer m.dialect.unlock(context.WithoutCancel(ctx), conn, m)
+
+	create := "CREATE TABLE IF NOT EXISTS " + m.table + " (version BIGINT PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at TIMESTAMP NOT NULL)"
+	if _, err := conn.ExecContext(ctx, create); err != nil {
+		return err
+	}
+
+	versions, err := m.versions(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, versions)
+}
+
+func (m *Migrator) versions(ctx context.Context, conn *sql.Conn) (map[int64]appliedVersion, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, applied_at FROM "+m.table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	versions := make(map[int64]appliedVersion)
+	for rows.Next() {
+		var version int64
+		var v appliedVersion
+		if err := rows.Scan(&version, &v.name, &v.appliedAt); err != nil {
+			return nil, err
+		}
+		versions[version] = v
+	}
+	return versions, rows.Err()
+}
+
+func (m *Migrator) lock(ctx context.Context, conn *sql.Conn) error {
+	deadline := time.Now().Add(m.lockTimeout)
+	for {
+		locked, err := m.dialect.lock(ctx, conn, m)
+		if err != nil {
+			return err
+		}
+		if locked {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: another migration holds the lock on %s", ErrLocked, m.table)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// apply runs the migration SQL and the version bookkeeping statement in one
+// transaction, so a failed migration leaves no trace.
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, migration Migration, script, bookkeeping string, args ...interface{}) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, script); err != nil {
+		return errors.Join(fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err), tx.Rollback())
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return errors.Join(err, tx.Rollback())
+	}
+	return tx.Commit()
+}
+
+func (m *Migrator) lockTable() string {
+	return m.table + "_lock"
+}
+
+func (m *Migrator) lockKey() int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(m.table))
+	return int64(hash.Sum64())
+}
@@ -0,0 +1,185 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMigrations = []Migration{
+	{Version: 1, Name: "create_products", Up: "CREATE TABLE products (id TEXT PRIMARY KEY)", Down: "DROP TABLE products"},
+	{Version: 2, Name: "add_name", Up: "ALTER TABLE products ADD COLUMN name TEXT NOT NULL DEFAULT ''", Down: "ALTER TABLE products DROP COLUMN name"},
+	{Version: 3, Name: "backfill_name", Up: "UPDATE products SET name = 'product ' || id; CREATE INDEX products_name ON products (name)", Down: "DROP INDEX products_name; UPDATE products SET name = ''"},
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", strings.NewReplacer("/", "_", " ", "_").Replace(t.Name()))
+	db, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func newTestMigrator(t *testing.T, db *sql.DB, migrations []Migration, opts ...Option) *Migrator {
+	m, err := New(db, DialectSQLite, migrations, opts...)
+	require.NoError(t, err)
+	return m
+}
+
+func versions(migrations []Migration) []int64 {
+	var result []int64
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+	return result
+}
+
+func TestMigrator_UpAndDown(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	m := newTestMigrator(t, db, testMigrations)
+
+	assert.ErrorIs(t, m.Check(ctx), ErrSchemaOutdated)
+
+	applied, err := m.Up(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, versions(applied))
+
+	_, err = db.Exec("INSERT INTO products (id) VALUES ('1')")
+	require.NoError(t, err)
+
+	applied, err = m.Up(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 3}, versions(applied))
+	assert.NoError(t, m.Check(ctx))
+
+	var name string
+	require.NoError(t, db.QueryRow("SELECT name FROM products WHERE id = '1'").Scan(&name))
+	assert.Equal(t, "product 1", name)
+
+	applied, err = m.Up(ctx, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, applied)
+
+	reverted, err := m.Down(ctx, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, versions(reverted))
+
+	statuses, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, statuses, 3)
+	assert.True(t, statuses[0].Applied)
+	assert.False(t, statuses[0].AppliedAt.IsZero())
+	assert.False(t, statuses[1].Applied)
+	assert.False(t, statuses[2].Applied)
+
+	reverted, err = m.Down(ctx, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, versions(reverted))
+
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, ErrNoMigration)
+}
+
+func TestMigrator_FailedMigrationRollsBack(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	m := newTestMigrator(t, db, []Migration{
+		testMigrations[0],
+		{Version: 2, Name: "broken", Up: "ALTER TABLE products ADD COLUMN sku TEXT; ALTER TABLE missing ADD COLUMN sku TEXT", Down: "SELECT 1"},
+	})
+
+	applied, err := m.Up(ctx, 0)
+	assert.ErrorContains(t, err, "migration 0002_broken: no such table: missing")
+	assert.Equal(t, []int64{1}, versions(applied))
+
+	_, err = db.Exec("SELECT sku FROM products")
+	assert.ErrorContains(t, err, "no such column: sku")
+
+	statuses, err := m.Status(ctx)
+	assert.NoError(t, err)
+	assert.False(t, statuses[1].Applied)
+}
+
+func TestMigrator_StatusReportsMissingVersions(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	_, err := newTestMigrator(t, db, testMigrations).Up(ctx, 0)
+	require.NoError(t, err)
+
+	statuses, err := newTestMigrator(t, db, testMigrations[:1]).Status(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []bool{false, true, true}, []bool{statuses[0].Missing, statuses[1].Missing, statuses[2].Missing})
+	assert.Equal(t, "backfill_name", statuses[2].Name)
+}
+
+func TestMigrator_Lock(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	m := newTestMigrator(t, db, testMigrations, WithLockTimeout(150*time.Millisecond))
+
+	_, err := db.Exec("CREATE TABLE schema_migrations_lock (id INTEGER PRIMARY KEY, locked_at TIMESTAMP NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO schema_migrations_lock (id, locked_at) VALUES (1, ?)", time.Now().UTC())
+	require.NoError(t, err)
+
+	_, err = m.Up(ctx, 0)
+	assert.ErrorIs(t, err, ErrLocked)
+
+	statuses, err := m.Status(ctx)
+	assert.NoError(t, err, "status reads without the lock")
+	assert.Len(t, statuses, 3)
+	assert.ErrorIs(t, m.Check(ctx), ErrSchemaOutdated)
+
+	require.NoError(t, m.Unlock(ctx))
+
+	_, err = m.Up(ctx, 0)
+	assert.NoError(t, err)
+
+	var locks int
+	require.NoError(t, db.QueryRow("SELECT COUNT(*) FROM schema_migrations_lock").Scan(&locks))
+	assert.Zero(t, locks)
+}
+
+func TestMigrator_TakesOverStaleLock(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	m := newTestMigrator(t, db, testMigrations, WithLockTimeout(150*time.Millisecond), WithStaleLockAge(time.Minute))
+
+	_, err := db.Exec("CREATE TABLE schema_migrations_lock (id INTEGER PRIMARY KEY, locked_at TIMESTAMP NOT NULL)")
+	require.NoError(t, err)
+	_, err = db.Exec("INSERT INTO schema_migrations_lock (id, locked_at) VALUES (1, ?)", time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx, 0)
+	assert.NoError(t, err)
+	assert.Len(t, applied, 3)
+}
+
+func TestMigrator_UnlockWithoutLockTable(t *testing.T) {
+	assert.NoError(t, newTestMigrator(t, openTestDB(t), testMigrations).Unlock(context.Background()))
+}
+
+func TestNew(t *testing.T) {
+	db := openTestDB(t)
+
+	_, err := New(db, "mysql", testMigrations)
+	assert.ErrorIs(t, err, ErrUnsupportedDialect)
+
+	_, err = New(db, DialectSQLite, testMigrations, WithTable("schema; DROP TABLE products"))
+	assert.ErrorIs(t, err, ErrInvalidMigration)
+
+	_, err = New(db, DialectSQLite, []Migration{testMigrations[1], testMigrations[0]})
+	assert.ErrorIs(t, err, ErrInvalidMigration)
+
+	m, err := New(db, DialectPostgres, testMigrations, WithTable("catalog_migrations"))
+	assert.NoError(t, err)
+	assert.Equal(t, "catalog_migrations", m.table)
+}
@@ -6,6 +6,11 @@ import (
 
 var (
 	ErrHttpMethodNotAllowed = errors.New("method not allowed")
+	ErrHttpNotFound         = errors.New("not found")
 	ErrHttpInvalidJSON      = errors.New("invalid JSON")
 	ErrServiceError         = errors.New("some service error")
+
+	ErrHttpValidation           = errors.New("validation failed")
+	ErrHttpBodyTooLarge         = errors.New("request body too large")
+	ErrHttpUnsupportedMediaType = errors.New("unsupported media type")
 )
@@ -0,0 +1,39 @@
+package adapter
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// NewLivenessHandler answers 200 while the process can serve HTTP at all.
+// It probes no dependency, so an outage of the database does not get the
+// process restarted.
+func NewLivenessHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteHealthReport(w, application.HealthReport{Status: application.HealthUp, Checks: []application.CheckResult{}})
+	}
+}
+
+// NewReadinessHandler runs every check of checker and answers 200 when all
+// of them are up and 503 otherwise, with the report as the body.
+func NewReadinessHandler(checker application.HealthChecker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		WriteHealthReport(w, checker.Check(r.Context()))
+	}
+}
+
+func WriteHealthReport(w http.ResponseWriter, report application.HealthReport) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(HealthStatusCode(report))
+	json.NewEncoder(w).Encode(report)
+}
+
+func HealthStatusCode(report application.HealthReport) int {
+	if report.Healthy() {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
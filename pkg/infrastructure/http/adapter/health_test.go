@@ -0,0 +1,51 @@
+package adapter
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+func TestLivenessHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	NewLivenessHandler()(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"status":"up","checks":[]}`, rec.Body.String())
+}
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedHealth string
+	}{
+		{name: "Ready", expectedStatus: http.StatusOK, expectedHealth: "up"},
+		{name: "Not ready", err: errors.New("connection refused"), expectedStatus: http.StatusServiceUnavailable, expectedHealth: "down"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := application.NewHealthChecker()
+			require.NoError(t, checker.Register(application.NewHealthCheck("database", func(context.Context) error { return tt.err })))
+
+			rec := httptest.NewRecorder()
+			NewReadinessHandler(checker)(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+			assert.Contains(t, rec.Body.String(), `"status":"`+tt.expectedHealth+`"`)
+			assert.Contains(t, rec.Body.String(), `"name":"database"`)
+			assert.Contains(t, rec.Body.String(), `"latency_ms"`)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package emulator
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+var anyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+type Emulator struct {
+	router *lambda.Router
+	stage  string
+}
+
+type EmulatorOption func(*Emulator) error
+
+func WithStage(stage string) EmulatorOption {
+	return func(e *Emulator) error {
+		if stage == "" {
+			return fmt.Errorf("stage must not be empty")
+		}
+		e.stage = stage
+		return nil
+	}
+}
+
+func NewEmulator(config *Serverless, handlers map[string]lambda.Handler, opts ...EmulatorOption) (*Emulator, error) {
+	if config == nil {
+		return nil, fmt.Errorf("missing serverless config")
+	}
+
+	e := &Emulator{router: lambda.NewRouter(), stage: "local"}
+	for _, opt := range opts {
+		if err := opt(e); err != nil {
+			return nil, err
+		}
+	}
+
+	names := make([]string, 0, len(config.Functions))
+	for name := range config.Functions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, event := range config.Functions[name].Events {
+			if event.HTTP == nil {
+				continue
+			}
+			handler, ok := handlers[name]
+			if !ok {
+				return nil, fmt.Errorf("no handler registered for function %s", name)
+			}
+			e.register(*event.HTTP, handler)
+		}
+	}
+
+	return e, nil
+}
+
+func (e *Emulator) register(event HTTPEvent, handler lambda.Handler) {
+	methods := []string{strings.ToUpper(event.Method)}
+	if event.Method == "*" || strings.EqualFold(event.Method, "any") {
+		methods = anyMethods
+	}
+
+	if event.CORS {
+		handler = withCORS(handler)
+		e.router.HandleFunc(http.MethodOptions, event.Resource(), preflight)
+	}
+	for _, method := range methods {
+		e.router.HandleFunc(method, event.Resource(), handler)
+	}
+}
+
+func (e *Emulator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	event, err := e.newEvent(r)
+	if err != nil {
+		writeResponse(w, lambda.ErrorResponse(http.StatusBadRequest, err))
+		return
+	}
+
+	response, err := e.router.Handle(r.Context(), event)
+	if err != nil {
+		// API Gateway hides handler failures behind a generic 502.
+		writeResponse(w, events.APIGatewayProxyResponse{
+			StatusCode: http.StatusBadGateway,
+			Headers:    map[string]string{"Content-Type": "application/json"},
+			Body:       `{"message":"Internal server error"}`,
+		})
+		return
+	}
+	writeResponse(w, response)
+}
+
+func (e *Emulator) newEvent(r *http.Request) (events.APIGatewayProxyRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return events.APIGatewayProxyRequest{}, err
+	}
+
+	headers := r.Header.Clone()
+	headers.Set("Host", r.Host)
+
+	request := lambda.Request{
+		Method:          r.Method,
+		Path:            r.URL.Path,
+		QueryParameters: r.URL.Query(),
+		Headers:         headers,
+		Body:            string(body),
+		RequestID:       newRequestID(),
+		SourceIP:        sourceIP(r.RemoteAddr),
+	}
+	if !utf8.Valid(body) {
+		request.Body = base64.StdEncoding.EncodeToString(body)
+		request.IsBase64Encoded = true
+	}
+
+	event := request.ToAPIGatewayProxyRequest()
+	event.RequestContext.Stage = e.stage
+	event.RequestContext.Path = "/" + e.stage + r.URL.Path
+	return event, nil
+}
+
+func writeResponse(w http.ResponseWriter, event events.APIGatewayProxyResponse) {
+	response := lambda.FromAPIGatewayProxyResponse(event)
+	for name, values := range response.Headers {
+		for _, value := range values {
+			w.Header().Add(name, value)
+		}
+	}
+
+	body := []byte(response.Body)
+	if response.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(response.Body)
+		if err != nil {
+			http.Error(w, "invalid base64 response body", http.StatusBadGateway)
+			return
+		}
+		body = decoded
+	}
+
+	statusCode := response.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+func withCORS(handler lambda.Handler) lambda.Handler {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		response, err := handler(ctx, request)
+		if err != nil {
+			return response, err
+		}
+		if response.Headers == nil {
+			response.Headers = make(map[string]string)
+		}
+		if _, ok := response.Headers["Access-Control-Allow-Origin"]; !ok {
+			response.Headers["Access-Control-Allow-Origin"] = "*"
+		}
+		return response, nil
+	}
+}
+
+func preflight(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return events.APIGatewayProxyResponse{
+		StatusCode: http.StatusOK,
+		Headers: map[string]string{
+			"Access-Control-Allow-Origin":  "*",
+			"Access-Control-Allow-Methods": strings.Join(anyMethods, ","),
+			"Access-Control-Allow-Headers": "Content-Type,X-Amz-Date,Authorization,X-Api-Key,X-Amz-Security-Token,X-Amz-User-Agent",
+		},
+	}, nil
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+func sourceIP(remoteAddr string) string {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return remoteAddr
+	}
+	return host
+}
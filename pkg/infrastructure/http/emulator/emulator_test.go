@@ -0,0 +1,138 @@
+package emulator
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+const testConfig = `
+functions:
+  getProduct:
+    events:
+      - http:
+          path: products/{id}
+          method: get
+          cors: true
+  addProduct:
+    events:
+      - http: POST products
+  failing:
+    events:
+      - http: GET failing
+  router:
+    events:
+      - http:
+          path: /{proxy+}
+          method: any
+`
+
+func TestEmulator_ServeHTTP(t *testing.T) {
+	config, err := Load([]byte(testConfig))
+	assert.NoError(t, err)
+
+	var captured events.APIGatewayProxyRequest
+	capture := func(response events.APIGatewayProxyResponse) lambda.Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			captured = request
+			return response, nil
+		}
+	}
+
+	emulator, err := NewEmulator(config, map[string]lambda.Handler{
+		"getProduct": capture(events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: `{"id":"42"}`, Headers: map[string]string{"Content-Type": "application/json"}}),
+		"addProduct": capture(events.APIGatewayProxyResponse{StatusCode: http.StatusCreated, MultiValueHeaders: map[string][]string{"Set-Cookie": {"a=1", "b=2"}}}),
+		"failing": func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			return events.APIGatewayProxyResponse{}, errors.New("boom")
+		},
+		"router": capture(events.APIGatewayProxyResponse{Body: base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe}), IsBase64Encoded: true}),
+	}, WithStage("dev"))
+	assert.NoError(t, err)
+
+	t.Run("Path parameters, query and CORS", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/products/42?fields=name&fields=price", nil)
+		req.Header.Set("Accept", "application/json")
+		rec := httptest.NewRecorder()
+
+		emulator.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, `{"id":"42"}`, rec.Body.String())
+		assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+		assert.Equal(t, http.MethodGet, captured.HTTPMethod)
+		assert.Equal(t, "/products/{id}", captured.Resource)
+		assert.Equal(t, "/products/42", captured.Path)
+		assert.Equal(t, map[string]string{"id": "42"}, captured.PathParameters)
+		assert.Equal(t, []string{"name", "price"}, captured.MultiValueQueryStringParameters["fields"])
+		assert.Equal(t, "price", captured.QueryStringParameters["fields"])
+		assert.Equal(t, "application/json", captured.Headers["Accept"])
+		assert.Equal(t, "example.com", captured.Headers["Host"])
+		assert.Equal(t, "dev", captured.RequestContext.Stage)
+		assert.Equal(t, "/dev/products/42", captured.RequestContext.Path)
+		assert.Equal(t, "192.0.2.1", captured.RequestContext.Identity.SourceIP)
+		assert.Len(t, captured.RequestContext.RequestID, 32)
+	})
+
+	t.Run("Preflight", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		emulator.ServeHTTP(rec, httptest.NewRequest(http.MethodOptions, "/products/42", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+		assert.NotEmpty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+	})
+
+	t.Run("Body and multi-value response headers", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		emulator.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(`{"name":"Product"}`)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, []string{"a=1", "b=2"}, rec.Header().Values("Set-Cookie"))
+		assert.Equal(t, `{"name":"Product"}`, captured.Body)
+		assert.False(t, captured.IsBase64Encoded)
+	})
+
+	t.Run("Binary bodies are base64 encoded both ways", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		emulator.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/orders/1/items", strings.NewReader("\xff\xfe")))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []byte{0xff, 0xfe}, rec.Body.Bytes())
+		assert.True(t, captured.IsBase64Encoded)
+		assert.Equal(t, base64.StdEncoding.EncodeToString([]byte{0xff, 0xfe}), captured.Body)
+		assert.Equal(t, map[string]string{"proxy": "orders/1/items"}, captured.PathParameters)
+	})
+
+	t.Run("Handler error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		emulator.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/failing", nil))
+
+		assert.Equal(t, http.StatusBadGateway, rec.Code)
+		assert.JSONEq(t, `{"message":"Internal server error"}`, rec.Body.String())
+	})
+}
+
+func TestNewEmulator(t *testing.T) {
+	config, err := Load([]byte(testConfig))
+	assert.NoError(t, err)
+
+	_, err = NewEmulator(nil, nil)
+	assert.EqualError(t, err, "missing serverless config")
+
+	_, err = NewEmulator(config, map[string]lambda.Handler{})
+	assert.EqualError(t, err, "no handler registered for function addProduct")
+
+	_, err = NewEmulator(config, nil, WithStage(""))
+	assert.EqualError(t, err, "stage must not be empty")
+}
@@ -0,0 +1,88 @@
+package emulator
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type Serverless struct {
+	Service   string              `yaml:"service"`
+	Functions map[string]Function `yaml:"functions"`
+}
+
+type Function struct {
+	Handler string  `yaml:"handler"`
+	Events  []Event `yaml:"events"`
+}
+
+type Event struct {
+	HTTP *HTTPEvent `yaml:"http"`
+}
+
+type HTTPEvent struct {
+	Path   string
+	Method string
+	CORS   bool
+}
+
+func Load(data []byte) (*Serverless, error) {
+	var config Serverless
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("invalid serverless config: %w", err)
+	}
+	if len(config.Functions) == 0 {
+		return nil, fmt.Errorf("invalid serverless config: no functions defined")
+	}
+	return &config, nil
+}
+
+func LoadFile(path string) (*Serverless, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(data)
+}
+
+func (e *HTTPEvent) UnmarshalYAML(node *yaml.Node) error {
+	// Shorthand form: "http: GET products/{id}".
+	if node.Kind == yaml.ScalarNode {
+		fields := strings.Fields(node.Value)
+		if len(fields) != 2 {
+			return fmt.Errorf("line %d: invalid http event %q", node.Line, node.Value)
+		}
+		e.Method, e.Path = fields[0], fields[1]
+		return nil
+	}
+
+	var event struct {
+		Path   string    `yaml:"path"`
+		Method string    `yaml:"method"`
+		CORS   yaml.Node `yaml:"cors"`
+	}
+	if err := node.Decode(&event); err != nil {
+		return err
+	}
+	if event.Path == "" || event.Method == "" {
+		return fmt.Errorf("line %d: http event requires path and method", node.Line)
+	}
+	e.Path, e.Method = event.Path, event.Method
+
+	// cors accepts either a boolean or an object with custom settings.
+	switch event.CORS.Kind {
+	case yaml.ScalarNode:
+		if err := event.CORS.Decode(&e.CORS); err != nil {
+			return err
+		}
+	case yaml.MappingNode:
+		e.CORS = true
+	}
+	return nil
+}
+
+func (e HTTPEvent) Resource() string {
+	return "/" + strings.Trim(e.Path, "/")
+}
@@ -0,0 +1,103 @@
+package emulator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        string
+		expected      map[string]Function
+		expectedError string
+	}{
+		{
+			name: "Long and shorthand http events",
+			config: `
+service: catalog
+functions:
+  getProduct:
+    handler: cmd/get_product/main.go
+    events:
+      - http:
+          path: products/{id}
+          method: get
+          cors: true
+  addProduct:
+    handler: cmd/add_product/main.go
+    events:
+      - http: POST products
+      - sqs: arn:aws:sqs:us-east-1:000000000000:queue
+  router:
+    handler: cmd/router/main.go
+    events:
+      - http:
+          path: /{proxy+}
+          method: any
+          cors:
+            origin: '*'
+`,
+			expected: map[string]Function{
+				"getProduct": {Handler: "cmd/get_product/main.go", Events: []Event{{HTTP: &HTTPEvent{Path: "products/{id}", Method: "get", CORS: true}}}},
+				"addProduct": {Handler: "cmd/add_product/main.go", Events: []Event{{HTTP: &HTTPEvent{Path: "products", Method: "POST"}}, {}}},
+				"router":     {Handler: "cmd/router/main.go", Events: []Event{{HTTP: &HTTPEvent{Path: "/{proxy+}", Method: "any", CORS: true}}}},
+			},
+		},
+		{
+			name:          "No functions",
+			config:        "service: catalog\n",
+			expectedError: "invalid serverless config: no functions defined",
+		},
+		{
+			name: "Invalid shorthand event",
+			config: `
+functions:
+  addProduct:
+    events:
+      - http: products
+`,
+			expectedError: `invalid serverless config: line 5: invalid http event "products"`,
+		},
+		{
+			name: "Missing method",
+			config: `
+functions:
+  addProduct:
+    events:
+      - http:
+          path: products
+`,
+			expectedError: "invalid serverless config: line 6: http event requires path and method",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := Load([]byte(tt.config))
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, config.Functions)
+		})
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	config, err := LoadFile("../../../../serverless.yml")
+	assert.NoError(t, err)
+	assert.Equal(t, "go-sls-marketplace", config.Service)
+	assert.Len(t, config.Functions, 6)
+	assert.Equal(t, &HTTPEvent{Path: "products/{id}", Method: "delete", CORS: true}, config.Functions["deleteProduct"].Events[0].HTTP)
+
+	_, err = LoadFile("missing.yml")
+	assert.Error(t, err)
+}
+
+func TestHTTPEvent_Resource(t *testing.T) {
+	assert.Equal(t, "/products/{id}", HTTPEvent{Path: "products/{id}/"}.Resource())
+	assert.Equal(t, "/products", HTTPEvent{Path: "/products"}.Resource())
+}
@@ -0,0 +1,77 @@
+package lambda
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type ALBTargetGroupHandler func(ctx context.Context, request events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error)
+
+func FromALBTargetGroupRequest(event events.ALBTargetGroupRequest) Request {
+	// ALB forwards query strings exactly as received, still percent-encoded.
+	query := make(map[string][]string)
+	for name, values := range toQuery(event.QueryStringParameters, event.MultiValueQueryStringParameters) {
+		decoded := make([]string, len(values))
+		for i, value := range values {
+			decoded[i] = unescape(value)
+		}
+		query[unescape(name)] = decoded
+	}
+	headers := toHeader(event.Headers, event.MultiValueHeaders)
+
+	return Request{
+		Method:          event.HTTPMethod,
+		Path:            event.Path,
+		QueryParameters: query,
+		Headers:         headers,
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+		RequestID:       headers.Get("X-Amzn-Trace-Id"),
+	}
+}
+
+func (r Response) ToALBTargetGroupResponse(multiValue bool) events.ALBTargetGroupResponse {
+	response := events.ALBTargetGroupResponse{
+		StatusCode:        r.StatusCode,
+		StatusDescription: fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		Body:              r.Body,
+		IsBase64Encoded:   r.IsBase64Encoded,
+	}
+	// The target group only accepts the header shape it was configured to send.
+	if multiValue {
+		response.MultiValueHeaders = multiValueHeaders(r.Headers)
+	} else {
+		response.Headers = singleValueHeaders(r.Headers)
+	}
+	return response
+}
+
+func NewALBTargetGroupHandler(handler RequestHandler, resources ...string) ALBTargetGroupHandler {
+	return func(ctx context.Context, event events.ALBTargetGroupRequest) (events.ALBTargetGroupResponse, error) {
+		request := FromALBTargetGroupRequest(event)
+		for _, resource := range resources {
+			if matched := request.WithResource(resource); matched.Resource != "" {
+				request = matched
+				break
+			}
+		}
+
+		response, err := handler(ctx, request)
+		if err != nil {
+			return events.ALBTargetGroupResponse{}, err
+		}
+		return response.ToALBTargetGroupResponse(len(event.MultiValueHeaders) > 0), nil
+	}
+}
+
+func unescape(value string) string {
+	decoded, err := url.QueryUnescape(value)
+	if err != nil {
+		return value
+	}
+	return decoded
+}
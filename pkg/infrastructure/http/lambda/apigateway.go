@@ -0,0 +1,91 @@
+package lambda
+
+import (
+	"context"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+func FromAPIGatewayProxyRequest(event events.APIGatewayProxyRequest) Request {
+	return Request{
+		Method:          event.HTTPMethod,
+		Path:            event.Path,
+		Resource:        event.Resource,
+		PathParameters:  event.PathParameters,
+		QueryParameters: toQuery(event.QueryStringParameters, event.MultiValueQueryStringParameters),
+		Headers:         toHeader(event.Headers, event.MultiValueHeaders),
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+		RequestID:       event.RequestContext.RequestID,
+		SourceIP:        event.RequestContext.Identity.SourceIP,
+	}
+}
+
+func (r Request) ToAPIGatewayProxyRequest() events.APIGatewayProxyRequest {
+	query := make(map[string]string, len(r.QueryParameters))
+	for name, values := range r.QueryParameters {
+		if len(values) > 0 {
+			query[name] = values[len(values)-1]
+		}
+	}
+
+	event := events.APIGatewayProxyRequest{
+		Resource:                        r.Resource,
+		Path:                            r.Path,
+		HTTPMethod:                      r.Method,
+		Headers:                         singleValueHeaders(r.Headers),
+		MultiValueHeaders:               multiValueHeaders(r.Headers),
+		QueryStringParameters:           query,
+		MultiValueQueryStringParameters: r.QueryParameters,
+		PathParameters:                  r.PathParameters,
+		Body:                            r.Body,
+		IsBase64Encoded:                 r.IsBase64Encoded,
+	}
+	event.RequestContext.RequestID = r.RequestID
+	event.RequestContext.Identity.SourceIP = r.SourceIP
+	event.RequestContext.ResourcePath = r.Resource
+	event.RequestContext.HTTPMethod = r.Method
+	return event
+}
+
+func FromAPIGatewayProxyResponse(response events.APIGatewayProxyResponse) Response {
+	return Response{
+		StatusCode:      response.StatusCode,
+		Headers:         toHeader(response.Headers, response.MultiValueHeaders),
+		Body:            response.Body,
+		IsBase64Encoded: response.IsBase64Encoded,
+	}
+}
+
+func (r Response) ToAPIGatewayProxyResponse() events.APIGatewayProxyResponse {
+	// API Gateway merges both maps, so a header with several values, such
+	// as Set-Cookie, only goes in MultiValueHeaders or it is sent twice.
+	headers, multi := splitHeaders(r.Headers)
+	return events.APIGatewayProxyResponse{
+		StatusCode:        r.StatusCode,
+		Headers:           headers,
+		MultiValueHeaders: multi,
+		Body:              r.Body,
+		IsBase64Encoded:   r.IsBase64Encoded,
+	}
+}
+
+func NewAPIGatewayProxyHandler(handler RequestHandler) Handler {
+	return func(ctx context.Context, event events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		response, err := handler(ctx, FromAPIGatewayProxyRequest(event))
+		if err != nil {
+			return events.APIGatewayProxyResponse{}, err
+		}
+		return response.ToAPIGatewayProxyResponse(), nil
+	}
+}
+
+func FromHandler(handler Handler) RequestHandler {
+	return func(ctx context.Context, request Request) (Response, error) {
+		response, err := handler(ctx, request.ToAPIGatewayProxyRequest())
+		if err != nil {
+			return Response{}, err
+		}
+		return FromAPIGatewayProxyResponse(response), nil
+	}
+}
@@ -0,0 +1,83 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type APIGatewayV2HTTPHandler func(ctx context.Context, request events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error)
+
+func FromAPIGatewayV2HTTPRequest(event events.APIGatewayV2HTTPRequest) Request {
+	query, err := url.ParseQuery(event.RawQueryString)
+	if err != nil || event.RawQueryString == "" {
+		query = make(url.Values, len(event.QueryStringParameters))
+		for name, value := range event.QueryStringParameters {
+			query[name] = strings.Split(value, ",")
+		}
+	}
+
+	headers := make(http.Header, len(event.Headers))
+	for name, value := range event.Headers {
+		headers.Set(name, value)
+	}
+	if len(event.Cookies) > 0 {
+		headers.Set("Cookie", strings.Join(event.Cookies, "; "))
+	}
+
+	method := event.RequestContext.HTTP.Method
+	path := event.RawPath
+	if path == "" {
+		path = event.RequestContext.HTTP.Path
+	}
+
+	return Request{
+		Method:          method,
+		Path:            path,
+		Resource:        routeKeyResource(event.RouteKey),
+		PathParameters:  event.PathParameters,
+		QueryParameters: query,
+		Headers:         headers,
+		Body:            event.Body,
+		IsBase64Encoded: event.IsBase64Encoded,
+		RequestID:       event.RequestContext.RequestID,
+		SourceIP:        event.RequestContext.HTTP.SourceIP,
+	}
+}
+
+func (r Response) ToAPIGatewayV2HTTPResponse() events.APIGatewayV2HTTPResponse {
+	headers := r.Headers.Clone()
+	cookies := headers.Values("Set-Cookie")
+	headers.Del("Set-Cookie")
+
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode:      r.StatusCode,
+		Headers:         singleValueHeaders(headers),
+		Body:            r.Body,
+		IsBase64Encoded: r.IsBase64Encoded,
+		Cookies:         cookies,
+	}
+}
+
+func NewAPIGatewayV2HTTPHandler(handler RequestHandler) APIGatewayV2HTTPHandler {
+	return func(ctx context.Context, event events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+		response, err := handler(ctx, FromAPIGatewayV2HTTPRequest(event))
+		if err != nil {
+			return events.APIGatewayV2HTTPResponse{}, err
+		}
+		return response.ToAPIGatewayV2HTTPResponse(), nil
+	}
+}
+
+func routeKeyResource(routeKey string) string {
+	if routeKey == "" || routeKey == "$default" {
+		return ""
+	}
+	if _, resource, ok := strings.Cut(routeKey, " "); ok {
+		return resource
+	}
+	return routeKey
+}
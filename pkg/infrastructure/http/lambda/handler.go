@@ -0,0 +1,19 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+type Handler func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+func ErrorResponse(statusCode int, err error) events.APIGatewayProxyResponse {
+	body, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return events.APIGatewayProxyResponse{
+		StatusCode: statusCode,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}
+}
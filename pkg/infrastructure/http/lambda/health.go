@@ -0,0 +1,29 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	pkghttp "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+// NewReadinessHandler runs every check of checker on each invocation, which
+// makes it suitable as the target of a synthetic canary. It answers 200 when
+// all checks are up and 503 otherwise, with the report as the body.
+func NewReadinessHandler(checker application.HealthChecker) Handler {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		report := checker.Check(ctx)
+		body, err := json.Marshal(report)
+		if err != nil {
+			return events.APIGatewayProxyResponse{}, err
+		}
+		return events.APIGatewayProxyResponse{
+			StatusCode: pkghttp.HealthStatusCode(report),
+			Headers:    map[string]string{"Content-Type": "application/json", "Cache-Control": "no-store"},
+			Body:       string(body),
+		}, nil
+	}
+}
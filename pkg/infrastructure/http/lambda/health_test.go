@@ -0,0 +1,51 @@
+package lambda
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+func TestReadinessHandler(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedHealth application.HealthStatus
+	}{
+		{name: "Ready", expectedStatus: http.StatusOK, expectedHealth: application.HealthUp},
+		{name: "Not ready", err: errors.New("ResourceNotFoundException"), expectedStatus: http.StatusServiceUnavailable, expectedHealth: application.HealthDown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := application.NewHealthChecker()
+			require.NoError(t, checker.Register(application.NewHealthCheck("dynamodb", func(context.Context) error { return tt.err })))
+
+			response, err := NewReadinessHandler(checker)(context.Background(), events.APIGatewayProxyRequest{})
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			assert.Equal(t, "application/json", response.Headers["Content-Type"])
+
+			var report struct {
+				Status application.HealthStatus `json:"status"`
+				Checks []struct {
+					Name   string `json:"name"`
+					Status string `json:"status"`
+				} `json:"checks"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(response.Body), &report))
+			assert.Equal(t, tt.expectedHealth, report.Status)
+			assert.Len(t, report.Checks, 1)
+			assert.Equal(t, "dynamodb", report.Checks[0].Name)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+)
+
+// Metrics records every invocation under the same series as the net/http
+// middleware, using the API Gateway resource as the route. Metrics that
+// buffer observations, such as the EMF registry, are flushed after each
+// invocation because the execution environment may be frozen right after.
+func Metrics(metrics application.Metrics) Middleware {
+	requestMetrics := middleware.NewRequestMetrics(metrics)
+	flusher, _ := metrics.(interface{ Flush() error })
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			done := requestMetrics.Start()
+			response, err := next(ctx, request)
+
+			status := response.StatusCode
+			if err != nil {
+				status = http.StatusInternalServerError
+			}
+			done(request.Resource, request.HTTPMethod, status)
+			if flusher != nil {
+				flusher.Flush()
+			}
+			return response, err
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/emf"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        Handler
+		expectedStatus string
+	}{
+		{name: "Response status", handler: respond(http.StatusNotFound, "{}"), expectedStatus: "404"},
+		{
+			name: "Error",
+			handler: func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				return events.APIGatewayProxyResponse{}, assert.AnError
+			},
+			expectedStatus: "500",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			handler := Metrics(emf.NewRegistry(&out, "Catalog"))(tt.handler)
+
+			handler(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Resource: "/products/{id}", Path: "/products/1"})
+
+			var requests map[string]interface{}
+			for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+				var document map[string]interface{}
+				require.NoError(t, json.Unmarshal([]byte(line), &document))
+				if _, ok := document["http_requests_total"]; ok {
+					requests = document
+				}
+			}
+			require.NotNil(t, requests, "the invocation was not flushed")
+			assert.Equal(t, "/products/{id}", requests["route"])
+			assert.Equal(t, http.MethodGet, requests["method"])
+			assert.Equal(t, tt.expectedStatus, requests["status"])
+			assert.Equal(t, float64(1), requests["http_requests_total"])
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+)
+
+// DefaultTimeoutMargin is the time Timeout keeps in reserve before the
+// Lambda deadline to return a response of its own.
+const DefaultTimeoutMargin = 500 * time.Millisecond
+
+var (
+	ErrInternal = errors.New("internal server error")
+	ErrTimeout  = errors.New("request timed out")
+)
+
+// Middleware decorates a Handler with cross-cutting behaviour.
+type Middleware func(Handler) Handler
+
+// Chain composes middlewares so that the first one is the outermost.
+func Chain(middlewares ...Middleware) Middleware {
+	return func(next Handler) Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+// Default is the chain every API Gateway function runs behind, letting
+// browsers on allowedOrigins read the responses. The headers are set outside
+// Recover and Timeout so their responses carry them too.
+func Default(logger application.Logger, allowedOrigins []string) Middleware {
+	return Chain(
+		RequestID(),
+		CORS(allowedOrigins),
+		JSONContentType(),
+		Logging(logger),
+		Timeout(DefaultTimeoutMargin),
+		Recover(logger),
+	)
+}
+
+// RequestID takes the request ID from the API Gateway request context, then
+// the X-Request-ID header, or generates one. It is stored in the context for
+// middleware.RequestIDFromContext and echoed in the response.
+func RequestID() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			requestID := request.RequestContext.RequestID
+			if requestID == "" {
+				requestID = headerValue(request.Headers, middleware.RequestIDHeader)
+			}
+			if requestID == "" {
+				requestID = application.NewCorrelationID()
+			}
+
+			response, err := next(middleware.ContextWithRequestID(ctx, requestID), request)
+			setHeader(&response, middleware.RequestIDHeader, requestID)
+			return response, err
+		}
+	}
+}
+
+// Logging logs the start and the end of every invocation, the latter with
+// the status and duration. Handlers find a logger tagged with the request ID
+// through application.FromContext.
+func Logging(logger application.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			logger := logger.With("request_id", middleware.RequestIDFromContext(ctx))
+			logger.Info("Lambda invocation started",
+				"method", request.HTTPMethod,
+				"path", request.Path,
+				"resource", request.Resource,
+			)
+
+			start := time.Now()
+			response, err := next(application.IntoContext(ctx, logger), request)
+			keysAndValues := []interface{}{
+				"method", request.HTTPMethod,
+				"path", request.Path,
+				"status", response.StatusCode,
+				"duration", time.Since(start).String(),
+			}
+			if err != nil {
+				logger.Error("Lambda invocation failed", err, keysAndValues...)
+			} else {
+				logger.Info("Lambda invocation finished", keysAndValues...)
+			}
+			return response, err
+		}
+	}
+}
+
+// Recover turns a panic in the handler into a JSON 500 response and logs it
+// with the stack, so one bad request does not crash the execution
+// environment.
+func Recover(logger application.Logger) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (response events.APIGatewayProxyResponse, err error) {
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					logger.Error("Recovered from panic", fmt.Errorf("panic: %v", recovered),
+						"method", request.HTTPMethod,
+						"path", request.Path,
+						"request_id", middleware.RequestIDFromContext(ctx),
+						"stack", string(debug.Stack()),
+					)
+					response, err = ErrorResponse(http.StatusInternalServerError, ErrInternal), nil
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// Timeout answers 504 margin before the Lambda deadline when the handler has
+// not returned yet, instead of letting the runtime kill the invocation. The
+// handler's context is cancelled at that point. Without a deadline on ctx
+// the handler runs unbounded.
+func Timeout(margin time.Duration) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			deadline, ok := ctx.Deadline()
+			if !ok {
+				return next(ctx, request)
+			}
+
+			ctx, cancel := context.WithDeadline(ctx, deadline.Add(-margin))
+			defer cancel()
+
+			type result struct {
+				response events.APIGatewayProxyResponse
+				err      error
+			}
+			done := make(chan result, 1)
+			go func() {
+				response, err := next(ctx, request)
+				done <- result{response, err}
+			}()
+
+			select {
+			case r := <-done:
+				return r.response, r.err
+			case <-ctx.Done():
+				return ErrorResponse(http.StatusGatewayTimeout, ErrTimeout), nil
+			}
+		}
+	}
+}
+
+// ResponseHeaders adds headers to every response that does not set them.
+func ResponseHeaders(headers map[string]string) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			response, err := next(ctx, request)
+			for name, value := range headers {
+				if !hasHeader(response, name) {
+					setHeader(&response, name, value)
+				}
+			}
+			return response, err
+		}
+	}
+}
+
+// CORS lets browsers on allowedOrigins, or any origin with "*", read the
+// responses, including the request ID header, like middleware.CORS does for
+// the HTTP server. Responses to other origins carry no CORS headers.
+func CORS(allowedOrigins []string) Middleware {
+	anyOrigin := false
+	for _, origin := range allowedOrigins {
+		anyOrigin = anyOrigin || origin == "*"
+	}
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			response, err := next(ctx, request)
+			if hasHeader(response, "Access-Control-Allow-Origin") {
+				return response, err
+			}
+
+			origin := "*"
+			if !anyOrigin {
+				origin = headerValue(request.Headers, "Origin")
+				vary := "Origin"
+				if existing := headerValue(response.Headers, "Vary"); existing != "" {
+					vary = existing + ", Origin"
+				}
+				setHeader(&response, "Vary", vary)
+				if origin == "" || !slices.ContainsFunc(allowedOrigins, func(allowed string) bool { return strings.EqualFold(origin, allowed) }) {
+					return response, err
+				}
+			}
+			setHeader(&response, "Access-Control-Allow-Origin", origin)
+			setHeader(&response, "Access-Control-Expose-Headers", middleware.RequestIDHeader)
+			return response, err
+		}
+	}
+}
+
+// JSONContentType marks responses with a body and no Content-Type as JSON,
+// which is what every catalog handler returns.
+func JSONContentType() Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			response, err := next(ctx, request)
+			if response.Body != "" && !hasHeader(response, "Content-Type") {
+				setHeader(&response, "Content-Type", "application/json")
+			}
+			return response, err
+		}
+	}
+}
+
+func hasHeader(response events.APIGatewayProxyResponse, name string) bool {
+	if headerValue(response.Headers, name) != "" {
+		return true
+	}
+	for key := range response.MultiValueHeaders {
+		if strings.EqualFold(key, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func setHeader(response *events.APIGatewayProxyResponse, name, value string) {
+	if response.Headers == nil {
+		response.Headers = map[string]string{}
+	}
+	response.Headers[name] = value
+}
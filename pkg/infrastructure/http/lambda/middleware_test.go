@@ -0,0 +1,340 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+)
+
+type logEntry struct {
+	msg    string
+	err    error
+	fields map[string]interface{}
+}
+
+type recordingLogger struct {
+	entries []logEntry
+	root    *recordingLogger
+	values  []interface{}
+}
+
+func (l *recordingLogger) Debug(string, ...interface{}) {}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.record(logEntry{msg: msg, fields: toFields(append(l.values, keysAndValues...))})
+}
+
+func (l *recordingLogger) Warn(string, ...interface{}) {}
+
+func (l *recordingLogger) Error(msg string, err error, keysAndValues ...interface{}) {
+	l.record(logEntry{msg: msg, err: err, fields: toFields(append(l.values, keysAndValues...))})
+}
+
+func (l *recordingLogger) With(keysAndValues ...interface{}) application.Logger {
+	values := append(append([]interface{}{}, l.values...), keysAndValues...)
+	return &recordingLogger{root: l.rootLogger(), values: values}
+}
+
+func (l *recordingLogger) record(entry logEntry) {
+	root := l.rootLogger()
+	root.entries = append(root.entries, entry)
+}
+
+func (l *recordingLogger) rootLogger() *recordingLogger {
+	if l.root != nil {
+		return l.root
+	}
+	return l
+}
+
+func toFields(keysAndValues []interface{}) map[string]interface{} {
+	fields := make(map[string]interface{})
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	return fields
+}
+
+func respond(statusCode int, body string) Handler {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: statusCode, Body: body}, nil
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				order = append(order, "before "+name)
+				response, err := next(ctx, request)
+				order = append(order, "after "+name)
+				return response, err
+			}
+		}
+	}
+
+	_, err := Chain(tag("outer"), tag("inner"))(respond(http.StatusOK, ""))(context.Background(), events.APIGatewayProxyRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"before outer", "before inner", "after inner", "after outer"}, order)
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  events.APIGatewayProxyRequest
+		expected string
+	}{
+		{
+			name: "From the request context",
+			request: events.APIGatewayProxyRequest{
+				Headers:        map[string]string{"X-Request-ID": "header-id"},
+				RequestContext: events.APIGatewayProxyRequestContext{RequestID: "context-id"},
+			},
+			expected: "context-id",
+		},
+		{
+			name:     "From the header",
+			request:  events.APIGatewayProxyRequest{Headers: map[string]string{"x-request-id": "header-id"}},
+			expected: "header-id",
+		},
+		{
+			name: "Generated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestID()(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				seen = middleware.RequestIDFromContext(ctx)
+				return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+			})
+
+			response, err := handler(context.Background(), tt.request)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, seen)
+			assert.Equal(t, seen, response.Headers[middleware.RequestIDHeader])
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, seen)
+			}
+		})
+	}
+}
+
+func TestLogging(t *testing.T) {
+	tests := []struct {
+		name        string
+		handler     Handler
+		expectedMsg string
+		expectedErr error
+	}{
+		{name: "Success", handler: respond(http.StatusCreated, "{}"), expectedMsg: "Lambda invocation finished"},
+		{
+			name: "Error",
+			handler: func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				return events.APIGatewayProxyResponse{}, assert.AnError
+			},
+			expectedMsg: "Lambda invocation failed",
+			expectedErr: assert.AnError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+			handler := Chain(RequestID(), Logging(logger))(tt.handler)
+
+			_, err := handler(context.Background(), events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPost,
+				Path:           "/products",
+				RequestContext: events.APIGatewayProxyRequestContext{RequestID: "abc"},
+			})
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			require.Len(t, logger.entries, 2)
+			assert.Equal(t, "Lambda invocation started", logger.entries[0].msg)
+			assert.Equal(t, "abc", logger.entries[0].fields["request_id"])
+			assert.Equal(t, tt.expectedMsg, logger.entries[1].msg)
+			assert.Equal(t, tt.expectedErr, logger.entries[1].err)
+			assert.Equal(t, "/products", logger.entries[1].fields["path"])
+			assert.NotEmpty(t, logger.entries[1].fields["duration"])
+		})
+	}
+}
+
+func TestLogging_ContextLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := Chain(RequestID(), Logging(logger))(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		application.FromContext(ctx).Info("Handling request")
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+	})
+
+	_, err := handler(context.Background(), events.APIGatewayProxyRequest{
+		RequestContext: events.APIGatewayProxyRequestContext{RequestID: "abc"},
+	})
+
+	assert.NoError(t, err)
+	require.Len(t, logger.entries, 3)
+	assert.Equal(t, "Handling request", logger.entries[1].msg)
+	assert.Equal(t, "abc", logger.entries[1].fields["request_id"])
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := Recover(logger)(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		panic("boom")
+	})
+
+	response, err := handler(context.Background(), events.APIGatewayProxyRequest{Path: "/products"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, "application/json", response.Headers["Content-Type"])
+	assert.JSONEq(t, `{"error":"internal server error"}`, response.Body)
+	require.Len(t, logger.entries, 1)
+	assert.EqualError(t, logger.entries[0].err, "panic: boom")
+	assert.Contains(t, logger.entries[0].fields["stack"], "runtime/debug.Stack")
+}
+
+func TestTimeout(t *testing.T) {
+	slow := func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+		}
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK}, nil
+	}
+
+	tests := []struct {
+		name           string
+		handler        Handler
+		deadline       time.Duration
+		expectedStatus int
+	}{
+		{name: "Returns before the deadline", handler: respond(http.StatusOK, ""), deadline: time.Second, expectedStatus: http.StatusOK},
+		{name: "Times out before the deadline", handler: slow, deadline: 100 * time.Millisecond, expectedStatus: http.StatusGatewayTimeout},
+		{name: "No deadline", handler: respond(http.StatusOK, ""), expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.deadline > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.deadline)
+				defer cancel()
+			}
+
+			start := time.Now()
+			response, err := Timeout(50*time.Millisecond)(tt.handler)(ctx, events.APIGatewayProxyRequest{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, response.StatusCode)
+			if tt.expectedStatus == http.StatusGatewayTimeout {
+				assert.Less(t, time.Since(start), 100*time.Millisecond)
+				assert.JSONEq(t, `{"error":"request timed out"}`, response.Body)
+			}
+		})
+	}
+}
+
+func TestResponseHeaders(t *testing.T) {
+	handler := Chain(CORS([]string{"https://shop.example.com"}), JSONContentType())(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		switch request.Path {
+		case "/custom":
+			return events.APIGatewayProxyResponse{
+				StatusCode:        http.StatusOK,
+				Headers:           map[string]string{"access-control-allow-origin": "*"},
+				MultiValueHeaders: map[string][]string{"Content-Type": {"text/plain"}},
+				Body:              "hello",
+			}, nil
+		case "/empty":
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusNoContent}, nil
+		default:
+			return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: "{}"}, nil
+		}
+	})
+
+	response, err := handler(context.Background(), events.APIGatewayProxyRequest{Path: "/products", Headers: map[string]string{"origin": "https://shop.example.com"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "application/json", response.Headers["Content-Type"])
+	assert.Equal(t, "https://shop.example.com", response.Headers["Access-Control-Allow-Origin"])
+	assert.Equal(t, "X-Request-ID", response.Headers["Access-Control-Expose-Headers"])
+
+	response, err = handler(context.Background(), events.APIGatewayProxyRequest{Path: "/custom"})
+	assert.NoError(t, err)
+	assert.NotContains(t, response.Headers, "Content-Type")
+	assert.NotContains(t, response.Headers, "Access-Control-Allow-Origin")
+	assert.Equal(t, "*", response.Headers["access-control-allow-origin"])
+
+	response, err = handler(context.Background(), events.APIGatewayProxyRequest{Path: "/empty"})
+	assert.NoError(t, err)
+	assert.NotContains(t, response.Headers, "Content-Type")
+}
+
+func TestCORS(t *testing.T) {
+	ok := func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Headers: map[string]string{"Vary": "Accept-Encoding"}}, nil
+	}
+
+	tests := []struct {
+		name           string
+		allowedOrigins []string
+		origin         string
+		expectedOrigin string
+		expectedVary   string
+	}{
+		{name: "Any origin", allowedOrigins: []string{"*"}, origin: "https://shop.example.com", expectedOrigin: "*", expectedVary: "Accept-Encoding"},
+		{name: "Allowed origin", allowedOrigins: []string{"https://admin.example.com", "https://shop.example.com"}, origin: "https://Shop.example.com", expectedOrigin: "https://Shop.example.com", expectedVary: "Accept-Encoding, Origin"},
+		{name: "Other origin", allowedOrigins: []string{"https://shop.example.com"}, origin: "https://evil.example.com", expectedVary: "Accept-Encoding, Origin"},
+		{name: "No origin", allowedOrigins: []string{"https://shop.example.com"}, expectedVary: "Accept-Encoding, Origin"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := events.APIGatewayProxyRequest{Headers: map[string]string{}}
+			if tt.origin != "" {
+				request.Headers["Origin"] = tt.origin
+			}
+
+			response, err := CORS(tt.allowedOrigins)(ok)(context.Background(), request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOrigin, response.Headers["Access-Control-Allow-Origin"])
+			assert.Equal(t, tt.expectedVary, response.Headers["Vary"])
+			if tt.expectedOrigin == "" {
+				assert.NotContains(t, response.Headers, "Access-Control-Expose-Headers")
+			}
+		})
+	}
+}
+
+func TestDefault(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := Default(logger, []string{"*"})(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		panic(errors.New("boom"))
+	})
+
+	response, err := handler(context.Background(), events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{RequestID: "abc"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+	assert.Equal(t, "abc", response.Headers[middleware.RequestIDHeader])
+	assert.Equal(t, "*", response.Headers["Access-Control-Allow-Origin"])
+	assert.Equal(t, "application/json", response.Headers["Content-Type"])
+	require.Len(t, logger.entries, 3)
+	assert.Equal(t, "Lambda invocation finished", logger.entries[2].msg)
+	assert.Equal(t, http.StatusInternalServerError, logger.entries[2].fields["status"])
+}
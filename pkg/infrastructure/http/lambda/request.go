@@ -0,0 +1,135 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+type Request struct {
+	Method          string
+	Path            string
+	Resource        string
+	PathParameters  map[string]string
+	QueryParameters map[string][]string
+	Headers         http.Header
+	Body            string
+	IsBase64Encoded bool
+	RequestID       string
+	SourceIP        string
+}
+
+type Response struct {
+	StatusCode      int
+	Headers         http.Header
+	Body            string
+	IsBase64Encoded bool
+}
+
+type RequestHandler func(ctx context.Context, request Request) (Response, error)
+
+func (r Request) Header(name string) string {
+	return r.Headers.Get(name)
+}
+
+func (r Request) DecodedBody() ([]byte, error) {
+	if !r.IsBase64Encoded {
+		return []byte(r.Body), nil
+	}
+	return base64.StdEncoding.DecodeString(r.Body)
+}
+
+func (r Request) WithResource(resource string) Request {
+	params, ok := (&route{segments: splitPath(resource)}).extract(splitPath(r.Path))
+	if !ok {
+		return r
+	}
+	r.Resource = resource
+	pathParameters := make(map[string]string, len(r.PathParameters)+len(params))
+	for name, value := range r.PathParameters {
+		pathParameters[name] = value
+	}
+	for name, value := range params {
+		pathParameters[name] = value
+	}
+	r.PathParameters = pathParameters
+	return r
+}
+
+// singleValueHeaders joins repeated headers with commas, except Set-Cookie:
+// cookie values contain commas themselves, so only the last one is kept.
+func singleValueHeaders(headers http.Header) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	single := make(map[string]string, len(headers))
+	for name, values := range headers {
+		if http.CanonicalHeaderKey(name) == "Set-Cookie" && len(values) > 0 {
+			single[name] = values[len(values)-1]
+			continue
+		}
+		single[name] = strings.Join(values, ",")
+	}
+	return single
+}
+
+func multiValueHeaders(headers http.Header) map[string][]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	multi := make(map[string][]string, len(headers))
+	for name, values := range headers {
+		multi[name] = append([]string(nil), values...)
+	}
+	return multi
+}
+
+// splitHeaders returns the headers with a single value and those with
+// several, each in its own map; either is nil when empty.
+func splitHeaders(headers http.Header) (map[string]string, map[string][]string) {
+	var single map[string]string
+	var multi map[string][]string
+	for name, values := range headers {
+		if len(values) > 1 {
+			if multi == nil {
+				multi = make(map[string][]string)
+			}
+			multi[name] = append([]string(nil), values...)
+			continue
+		}
+		if single == nil {
+			single = make(map[string]string, len(headers))
+		}
+		single[name] = strings.Join(values, ",")
+	}
+	return single, multi
+}
+
+func toHeader(single map[string]string, multi map[string][]string) http.Header {
+	headers := make(http.Header, len(single)+len(multi))
+	for name, values := range multi {
+		for _, value := range values {
+			headers.Add(name, value)
+		}
+	}
+	for name, value := range single {
+		if _, ok := headers[http.CanonicalHeaderKey(name)]; !ok {
+			headers.Set(name, value)
+		}
+	}
+	return headers
+}
+
+func toQuery(single map[string]string, multi map[string][]string) map[string][]string {
+	query := make(map[string][]string, len(single)+len(multi))
+	for name, values := range multi {
+		query[name] = append([]string(nil), values...)
+	}
+	for name, value := range single {
+		if _, ok := query[name]; !ok {
+			query[name] = []string{value}
+		}
+	}
+	return query
+}
@@ -0,0 +1,214 @@
+package lambda
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func captureHandler(captured *Request, response Response) RequestHandler {
+	return func(ctx context.Context, request Request) (Response, error) {
+		*captured = request
+		return response, nil
+	}
+}
+
+func TestRequest_DecodedBody(t *testing.T) {
+	body, err := Request{Body: base64.StdEncoding.EncodeToString([]byte("hello")), IsBase64Encoded: true}.DecodedBody()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("hello"), body)
+
+	body, err = Request{Body: "plain"}.DecodedBody()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("plain"), body)
+
+	_, err = Request{Body: "%%%", IsBase64Encoded: true}.DecodedBody()
+	assert.Error(t, err)
+}
+
+func TestRequest_WithResource(t *testing.T) {
+	request := Request{Path: "/products/42", PathParameters: map[string]string{"proxy": "products/42"}}
+
+	matched := request.WithResource("/products/{id}")
+	assert.Equal(t, "/products/{id}", matched.Resource)
+	assert.Equal(t, map[string]string{"proxy": "products/42", "id": "42"}, matched.PathParameters)
+
+	unmatched := request.WithResource("/orders/{id}")
+	assert.Equal(t, request, unmatched)
+}
+
+func TestNewAPIGatewayProxyHandler(t *testing.T) {
+	var captured Request
+	handler := NewAPIGatewayProxyHandler(captureHandler(&captured, Response{
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Set-Cookie": {"a=1", "b=2"}, "Content-Type": {"application/json"}},
+		Body:       "{}",
+	}))
+
+	resp, err := handler(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod:                      http.MethodGet,
+		Resource:                        "/products/{id}",
+		Path:                            "/products/1",
+		PathParameters:                  map[string]string{"id": "1"},
+		Headers:                         map[string]string{"accept": "application/json"},
+		MultiValueQueryStringParameters: map[string][]string{"tag": {"a", "b"}},
+		RequestContext:                  events.APIGatewayProxyRequestContext{RequestID: "req-1"},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.MethodGet, captured.Method)
+	assert.Equal(t, "/products/{id}", captured.Resource)
+	assert.Equal(t, "application/json", captured.Header("Accept"))
+	assert.Equal(t, []string{"a", "b"}, captured.QueryParameters["tag"])
+	assert.Equal(t, "req-1", captured.RequestID)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Headers["Content-Type"])
+	assert.Equal(t, []string{"a=1", "b=2"}, resp.MultiValueHeaders["Set-Cookie"])
+}
+
+func TestResponse_ToAPIGatewayProxyResponse(t *testing.T) {
+	resp := Response{
+		StatusCode: http.StatusOK,
+		Headers:    http.Header{"Set-Cookie": {"session=abc; HttpOnly", "theme=dark"}, "Content-Type": {"application/json"}},
+	}.ToAPIGatewayProxyResponse()
+
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, resp.Headers, "API Gateway would send a joined Set-Cookie too")
+	assert.Equal(t, map[string][]string{"Set-Cookie": {"session=abc; HttpOnly", "theme=dark"}}, resp.MultiValueHeaders)
+
+	resp = Response{StatusCode: http.StatusOK, Headers: http.Header{"Content-Type": {"application/json"}}}.ToAPIGatewayProxyResponse()
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, resp.Headers)
+	assert.Nil(t, resp.MultiValueHeaders)
+}
+
+func TestFromHandler(t *testing.T) {
+	handler := FromHandler(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		assert.Equal(t, "/products", request.Resource)
+		assert.Equal(t, "b", request.QueryStringParameters["tag"])
+		assert.Equal(t, []string{"a", "b"}, request.MultiValueQueryStringParameters["tag"])
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusAccepted, Headers: map[string]string{"X-Test": "1"}}, nil
+	})
+
+	resp, err := handler(context.Background(), Request{
+		Method:          http.MethodGet,
+		Resource:        "/products",
+		Path:            "/products",
+		QueryParameters: map[string][]string{"tag": {"a", "b"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "1", resp.Headers.Get("X-Test"))
+}
+
+func TestNewAPIGatewayV2HTTPHandler(t *testing.T) {
+	var captured Request
+	handler := NewAPIGatewayV2HTTPHandler(captureHandler(&captured, Response{
+		StatusCode: http.StatusCreated,
+		Headers:    http.Header{"Set-Cookie": {"session=1"}, "Content-Type": {"application/json"}},
+		Body:       "{}",
+	}))
+
+	event := events.APIGatewayV2HTTPRequest{
+		RouteKey:       "GET /products/{id}",
+		RawPath:        "/products/1",
+		RawQueryString: "tag=a&tag=b",
+		Cookies:        []string{"a=1", "b=2"},
+		Headers:        map[string]string{"content-type": "application/json"},
+		PathParameters: map[string]string{"id": "1"},
+	}
+	event.RequestContext.HTTP.Method = http.MethodGet
+	event.RequestContext.RequestID = "req-2"
+
+	resp, err := handler(context.Background(), event)
+	assert.NoError(t, err)
+
+	assert.Equal(t, http.MethodGet, captured.Method)
+	assert.Equal(t, "/products/1", captured.Path)
+	assert.Equal(t, "/products/{id}", captured.Resource)
+	assert.Equal(t, "1", captured.PathParameters["id"])
+	assert.Equal(t, []string{"a", "b"}, captured.QueryParameters["tag"])
+	assert.Equal(t, "a=1; b=2", captured.Header("Cookie"))
+	assert.Equal(t, "req-2", captured.RequestID)
+
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, []string{"session=1"}, resp.Cookies)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, resp.Headers)
+}
+
+func TestRouteKeyResource(t *testing.T) {
+	assert.Equal(t, "", routeKeyResource("$default"))
+	assert.Equal(t, "/products", routeKeyResource("POST /products"))
+	assert.Equal(t, "/products", routeKeyResource("/products"))
+}
+
+func TestNewALBTargetGroupHandler(t *testing.T) {
+	t.Run("Single value headers", func(t *testing.T) {
+		var captured Request
+		handler := NewALBTargetGroupHandler(captureHandler(&captured, Response{
+			StatusCode: http.StatusNotFound,
+			Headers:    http.Header{"Content-Type": {"application/json"}},
+		}), "/products/{id}")
+
+		resp, err := handler(context.Background(), events.ALBTargetGroupRequest{
+			HTTPMethod:            http.MethodGet,
+			Path:                  "/products/1",
+			QueryStringParameters: map[string]string{"q": "red%20shoe"},
+			Headers:               map[string]string{"x-amzn-trace-id": "Root=1-abc"},
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "/products/{id}", captured.Resource)
+		assert.Equal(t, "1", captured.PathParameters["id"])
+		assert.Equal(t, []string{"red shoe"}, captured.QueryParameters["q"])
+		assert.Equal(t, "Root=1-abc", captured.RequestID)
+
+		assert.Equal(t, "404 Not Found", resp.StatusDescription)
+		assert.Equal(t, map[string]string{"Content-Type": "application/json"}, resp.Headers)
+		assert.Nil(t, resp.MultiValueHeaders)
+	})
+
+	t.Run("Multi value headers", func(t *testing.T) {
+		var captured Request
+		handler := NewALBTargetGroupHandler(captureHandler(&captured, Response{
+			StatusCode: http.StatusOK,
+			Headers:    http.Header{"Set-Cookie": {"a=1", "b=2"}},
+		}))
+
+		resp, err := handler(context.Background(), events.ALBTargetGroupRequest{
+			HTTPMethod:                      http.MethodGet,
+			Path:                            "/products",
+			MultiValueQueryStringParameters: map[string][]string{"tag": {"a%2Fb", "c"}},
+			MultiValueHeaders:               map[string][]string{"accept": {"text/html", "application/json"}},
+		})
+		assert.NoError(t, err)
+
+		assert.Empty(t, captured.Resource)
+		assert.Equal(t, []string{"a/b", "c"}, captured.QueryParameters["tag"])
+		assert.Equal(t, []string{"text/html", "application/json"}, captured.Headers.Values("Accept"))
+
+		assert.Nil(t, resp.Headers)
+		assert.Equal(t, []string{"a=1", "b=2"}, resp.MultiValueHeaders["Set-Cookie"])
+	})
+
+	t.Run("Single value cookies", func(t *testing.T) {
+		var captured Request
+		handler := NewALBTargetGroupHandler(captureHandler(&captured, Response{
+			StatusCode: http.StatusOK,
+			Headers: http.Header{
+				"Set-Cookie": {"a=1; Expires=Wed, 21 Oct 2026 07:28:00 GMT", "b=2; Expires=Thu, 22 Oct 2026 07:28:00 GMT"},
+				"Vary":       {"Origin", "Accept-Encoding"},
+			},
+		}))
+
+		resp, err := handler(context.Background(), events.ALBTargetGroupRequest{HTTPMethod: http.MethodGet, Path: "/products"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, "b=2; Expires=Thu, 22 Oct 2026 07:28:00 GMT", resp.Headers["Set-Cookie"])
+		assert.Equal(t, "Origin,Accept-Encoding", resp.Headers["Vary"])
+		assert.Nil(t, resp.MultiValueHeaders)
+	})
+}
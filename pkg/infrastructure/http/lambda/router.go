@@ -0,0 +1,154 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+type Router struct {
+	routes []*route
+}
+
+type route struct {
+	resource string
+	segments []string
+	params   int
+	greedy   bool
+	handlers map[string]Handler
+}
+
+func NewRouter() *Router {
+	return &Router{}
+}
+
+func (r *Router) HandleFunc(method, resource string, handler Handler) *Router {
+	rt := r.find(resource)
+	if rt == nil {
+		rt = &route{
+			resource: resource,
+			segments: splitPath(resource),
+			handlers: make(map[string]Handler),
+		}
+		for _, segment := range rt.segments {
+			if isParam(segment) {
+				rt.params++
+			}
+		}
+		if n := len(rt.segments); n > 0 && isGreedyParam(rt.segments[n-1]) {
+			rt.greedy = true
+		}
+		r.routes = append(r.routes, rt)
+		// Greedy {proxy+} resources only catch what no other route matches.
+		sort.SliceStable(r.routes, func(i, j int) bool {
+			if r.routes[i].greedy != r.routes[j].greedy {
+				return !r.routes[i].greedy
+			}
+			return r.routes[i].params < r.routes[j].params
+		})
+	}
+	rt.handlers[strings.ToUpper(method)] = handler
+	return r
+}
+
+func (r *Router) Handle(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	rt, params := r.match(request)
+	if rt == nil {
+		return ErrorResponse(http.StatusNotFound, adapter.ErrHttpNotFound), nil
+	}
+
+	handler, ok := rt.handlers[strings.ToUpper(request.HTTPMethod)]
+	if !ok {
+		response := ErrorResponse(http.StatusMethodNotAllowed, adapter.ErrHttpMethodNotAllowed)
+		response.Headers["Allow"] = strings.Join(rt.allowedMethods(), ", ")
+		return response, nil
+	}
+
+	request.Resource = rt.resource
+	if len(params) > 0 {
+		pathParameters := make(map[string]string, len(request.PathParameters)+len(params))
+		for name, value := range request.PathParameters {
+			pathParameters[name] = value
+		}
+		for name, value := range params {
+			pathParameters[name] = value
+		}
+		request.PathParameters = pathParameters
+	}
+
+	return handler(ctx, request)
+}
+
+func (r *Router) find(resource string) *route {
+	for _, rt := range r.routes {
+		if rt.resource == resource {
+			return rt
+		}
+	}
+	return nil
+}
+
+func (r *Router) match(request events.APIGatewayProxyRequest) (*route, map[string]string) {
+	if rt := r.find(request.Resource); rt != nil {
+		return rt, nil
+	}
+
+	segments := splitPath(request.Path)
+	for _, rt := range r.routes {
+		if params, ok := rt.extract(segments); ok {
+			return rt, params
+		}
+	}
+	return nil, nil
+}
+
+func (rt *route) extract(segments []string) (map[string]string, bool) {
+	if len(segments) != len(rt.segments) && !(rt.greedy && len(segments) >= len(rt.segments)) {
+		return nil, false
+	}
+	params := make(map[string]string, rt.params)
+	for i, segment := range rt.segments {
+		if rt.greedy && i == len(rt.segments)-1 {
+			params[strings.Trim(segment, "{+}")] = strings.Join(segments[i:], "/")
+			continue
+		}
+		if isParam(segment) {
+			params[strings.Trim(segment, "{}")] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func (rt *route) allowedMethods() []string {
+	methods := make([]string, 0, len(rt.handlers))
+	for method := range rt.handlers {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+func isGreedyParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "+}")
+}
@@ -0,0 +1,128 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouter_Handle(t *testing.T) {
+	echo := func(name string) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			return events.APIGatewayProxyResponse{
+				StatusCode: http.StatusOK,
+				Body:       name + ":" + request.Resource + ":" + request.PathParameters["id"],
+			}, nil
+		}
+	}
+
+	router := NewRouter()
+	router.HandleFunc(http.MethodGet, "/products", echo("list"))
+	router.HandleFunc(http.MethodPost, "/products", echo("add"))
+	router.HandleFunc(http.MethodGet, "/products/{id}", echo("get"))
+	router.HandleFunc(http.MethodDelete, "/products/{id}", echo("delete"))
+	router.HandleFunc(http.MethodGet, "/products/featured", echo("featured"))
+
+	tests := []struct {
+		name            string
+		request         events.APIGatewayProxyRequest
+		expectedStatus  int
+		expectedBody    string
+		expectedHeaders map[string]string
+	}{
+		{
+			name:           "Dispatch on resource",
+			request:        events.APIGatewayProxyRequest{HTTPMethod: http.MethodPost, Resource: "/products", Path: "/products"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "add:/products:",
+		},
+		{
+			name: "Dispatch on resource keeps path parameters",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				Resource:       "/products/{id}",
+				Path:           "/products/42",
+				PathParameters: map[string]string{"id": "42"},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "get:/products/{id}:42",
+		},
+		{
+			name: "Dispatch proxy resource on path",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodDelete,
+				Resource:       "/{proxy+}",
+				Path:           "/products/42",
+				PathParameters: map[string]string{"proxy": "products/42"},
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "delete:/products/{id}:42",
+		},
+		{
+			name:           "Static route wins over templated route",
+			request:        events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/products/featured"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "featured:/products/featured:",
+		},
+		{
+			name:            "Not found",
+			request:         events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/orders"},
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    `{"error":"not found"}`,
+			expectedHeaders: map[string]string{"Content-Type": "application/json"},
+		},
+		{
+			name:            "Method not allowed",
+			request:         events.APIGatewayProxyRequest{HTTPMethod: http.MethodPut, Path: "/products/42"},
+			expectedStatus:  http.StatusMethodNotAllowed,
+			expectedBody:    `{"error":"method not allowed"}`,
+			expectedHeaders: map[string]string{"Content-Type": "application/json", "Allow": "DELETE, GET"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := router.Handle(context.Background(), tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedBody, resp.Body)
+			if tt.expectedHeaders != nil {
+				assert.Equal(t, tt.expectedHeaders, resp.Headers)
+			}
+		})
+	}
+}
+
+func TestRouter_HandleGreedyResource(t *testing.T) {
+	router := NewRouter()
+	router.HandleFunc(http.MethodGet, "/products/{id}", func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: "get:" + request.PathParameters["id"]}, nil
+	})
+	router.HandleFunc(http.MethodGet, "/{proxy+}", func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: "proxy:" + request.PathParameters["proxy"]}, nil
+	})
+
+	tests := []struct {
+		path         string
+		expectedBody string
+	}{
+		{path: "/products/42", expectedBody: "get:42"},
+		{path: "/orders/1/items", expectedBody: "proxy:orders/1/items"},
+		{path: "/orders", expectedBody: "proxy:orders"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := router.Handle(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: tt.path})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedBody, resp.Body)
+		})
+	}
+
+	resp, err := router.Handle(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/"})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
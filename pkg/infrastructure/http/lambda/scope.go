@@ -0,0 +1,54 @@
+package lambda
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+)
+
+// Scope is WithScope as a middleware.
+func Scope(factory application.Factory, logger application.Logger) Middleware {
+	return func(next Handler) Handler {
+		return WithScope(factory, logger, next)
+	}
+}
+
+// WithScope opens a request scope for every invocation and closes it once
+// next returns. The request ID set by RequestID is the scope's correlation
+// ID, then the API Gateway request ID, or one is generated.
+func WithScope(factory application.Factory, logger application.Logger, next Handler) Handler {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		correlationID := middleware.RequestIDFromContext(ctx)
+		if correlationID == "" {
+			correlationID = request.RequestContext.RequestID
+		}
+		if correlationID == "" {
+			correlationID = application.NewCorrelationID()
+		}
+
+		scope := application.NewRequestScope(factory, logger, correlationID)
+		defer func() {
+			if err := scope.Close(); err != nil {
+				logger.Error("Error closing request scope", err, application.CorrelationIDKey, correlationID)
+			}
+		}()
+
+		return next(application.ContextWithScope(ctx, scope), request)
+	}
+}
+
+func headerValue(headers map[string]string, name string) string {
+	if value, ok := headers[name]; ok {
+		return value
+	}
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
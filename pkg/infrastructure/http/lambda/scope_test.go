@@ -0,0 +1,75 @@
+package lambda
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/middleware"
+)
+
+func TestScope(t *testing.T) {
+	factory := application.NewFactory(application.NewSimpleServiceLocator())
+
+	handler := Chain(RequestID(), Scope(factory, application.NopLogger()))(func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		scope, ok := application.ScopeFromContext(ctx)
+		assert.True(t, ok)
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Body:       application.MustCreate[string](scope, application.CorrelationIDKey),
+		}, nil
+	})
+
+	response, err := handler(context.Background(), events.APIGatewayProxyRequest{Headers: map[string]string{middleware.RequestIDHeader: "abc"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", response.Body)
+}
+
+func TestWithScope(t *testing.T) {
+	factory := application.NewFactory(application.NewSimpleServiceLocator())
+
+	handler := WithScope(factory, application.NopLogger(), func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		scope, ok := application.ScopeFromContext(ctx)
+		assert.True(t, ok)
+		return events.APIGatewayProxyResponse{
+			StatusCode: http.StatusOK,
+			Body:       application.MustCreate[string](scope, application.CorrelationIDKey),
+		}, nil
+	})
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		request  events.APIGatewayProxyRequest
+		expected string
+	}{
+		{
+			name:     "Request ID middleware",
+			ctx:      middleware.ContextWithRequestID(context.Background(), "abc"),
+			request:  events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{RequestID: "req-1"}},
+			expected: "abc",
+		},
+		{
+			name:     "API Gateway request ID",
+			ctx:      context.Background(),
+			request:  events.APIGatewayProxyRequest{RequestContext: events.APIGatewayProxyRequestContext{RequestID: "req-1"}},
+			expected: "req-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := handler(tt.ctx, tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, response.Body)
+		})
+	}
+
+	response, err := handler(context.Background(), events.APIGatewayProxyRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, response.Body, 32)
+}
@@ -0,0 +1,56 @@
+package lambda
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+)
+
+// Tracing starts a span for every invocation, continuing the trace of the
+// caller when the API Gateway event carries a W3C traceparent header. The
+// span has the same name and attributes as the net/http middleware's, with
+// the API Gateway resource as the route. An error returned by the handler
+// or a server error response marks the span as failed. Lambda freezes the
+// process between invocations, so a tracer exporting in the background is
+// flushed before the response is returned.
+func Tracing(tracer application.Tracer) Middleware {
+	flusher, _ := tracer.(interface {
+		ForceFlush(ctx context.Context) error
+	})
+
+	return func(next Handler) Handler {
+		return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+			ctx = tracing.Extract(ctx, headerValue(request.Headers, tracing.TraceparentHeader))
+			ctx, span := tracer.Start(ctx, request.HTTPMethod+" "+request.Resource,
+				"http.request.method", request.HTTPMethod,
+				"http.route", request.Resource,
+				"url.path", request.Path,
+				"faas.invocation_id", request.RequestContext.RequestID,
+			)
+			defer func() {
+				span.End()
+				if flusher != nil {
+					// Export failures are logged by the tracer.
+					_ = flusher.ForceFlush(ctx)
+				}
+			}()
+
+			response, err := next(ctx, request)
+
+			status := response.StatusCode
+			if err != nil {
+				status = http.StatusInternalServerError
+				span.RecordError(err)
+			} else if status >= http.StatusInternalServerError {
+				span.RecordError(errors.New(http.StatusText(status)))
+			}
+			span.SetAttributes("http.response.status_code", status)
+			return response, err
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package lambda
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		handler        Handler
+		expectedStatus float64
+		expectedError  interface{}
+		expectedTrace  interface{}
+	}{
+		{name: "New trace", handler: respond(http.StatusOK, "{}"), expectedStatus: 200},
+		{
+			name:           "Traceparent from the event headers",
+			headers:        map[string]string{"Traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"},
+			handler:        respond(http.StatusNotFound, "{}"),
+			expectedStatus: 404,
+			expectedTrace:  "4bf92f3577b34da6a3ce929d0e0e4736",
+		},
+		{name: "Server error", handler: respond(http.StatusInternalServerError, "{}"), expectedStatus: 500, expectedError: "Internal Server Error"},
+		{
+			name: "Error",
+			handler: func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				return events.APIGatewayProxyResponse{}, assert.AnError
+			},
+			expectedStatus: 500,
+			expectedError:  assert.AnError.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			// The batch never fills or times out: the middleware's flush exports it.
+			tracer := tracing.NewTracer(tracing.NewStdoutExporter(&out), tracing.WithBatch(512, time.Hour))
+			defer tracer.Shutdown(context.Background())
+			handler := Tracing(tracer)(tt.handler)
+
+			handler(context.Background(), events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodGet,
+				Resource:       "/products/{id}",
+				Path:           "/products/1",
+				Headers:        tt.headers,
+				RequestContext: events.APIGatewayProxyRequestContext{RequestID: "request-1"},
+			})
+
+			var span map[string]interface{}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &span))
+			assert.Equal(t, "GET /products/{id}", span["name"])
+			attributes := span["attributes"].(map[string]interface{})
+			assert.Equal(t, "/products/{id}", attributes["http.route"])
+			assert.Equal(t, "request-1", attributes["faas.invocation_id"])
+			assert.Equal(t, tt.expectedStatus, attributes["http.response.status_code"])
+			assert.Equal(t, tt.expectedError, span["error"])
+			if tt.expectedTrace != nil {
+				assert.Equal(t, tt.expectedTrace, span["trace_id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// AccessLog logs one entry per request once the handler returns, with the
+// status, response size and duration. Handlers find a logger tagged with the
+// request ID through application.FromContext.
+func AccessLog(logger application.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := newResponseRecorder(w)
+			logger := logger.With("request_id", RequestIDFromContext(r.Context()))
+
+			next.ServeHTTP(recorder, r.WithContext(application.IntoContext(r.Context(), logger)))
+
+			logger.Info("HTTP request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", recorder.status,
+				"bytes", recorder.bytes,
+				"duration", time.Since(start).String(),
+				"remote_addr", r.RemoteAddr,
+			)
+		})
+	}
+}
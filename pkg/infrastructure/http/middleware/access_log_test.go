@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+func TestAccessLog(t *testing.T) {
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		expectedStatus int
+		expectedBytes  int64
+	}{
+		{
+			name:           "Implicit status",
+			handler:        func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("hello")) },
+			expectedStatus: http.StatusOK,
+			expectedBytes:  5,
+		},
+		{
+			name: "Explicit status",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusCreated)
+				w.WriteHeader(http.StatusTeapot)
+				w.Write([]byte("{}"))
+			},
+			expectedStatus: http.StatusCreated,
+			expectedBytes:  2,
+		},
+		{
+			name:           "No body",
+			handler:        func(w http.ResponseWriter, r *http.Request) {},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := &recordingLogger{}
+			handler := Chain(RequestID(), AccessLog(logger))(tt.handler)
+
+			req := httptest.NewRequest(http.MethodPost, "/products", nil)
+			req.Header.Set(RequestIDHeader, "abc")
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.Len(t, logger.infos, 1)
+			entry := logger.infos[0]
+			assert.Equal(t, "HTTP request", entry["msg"])
+			assert.Equal(t, http.MethodPost, entry["method"])
+			assert.Equal(t, "/products", entry["path"])
+			assert.Equal(t, tt.expectedStatus, entry["status"])
+			assert.Equal(t, tt.expectedBytes, entry["bytes"])
+			assert.Equal(t, "abc", entry["request_id"])
+			assert.NotEmpty(t, entry["duration"])
+		})
+	}
+}
+
+func TestAccessLog_ContextLogger(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := Chain(RequestID(), AccessLog(logger))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		application.FromContext(r.Context()).Info("Handling request")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	require.Len(t, logger.infos, 2)
+	assert.Equal(t, "Handling request", logger.infos[0]["msg"])
+	assert.Equal(t, "abc", logger.infos[0]["request_id"])
+}
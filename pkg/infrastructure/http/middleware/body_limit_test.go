@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBodyLimit(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			assert.True(t, errors.As(err, &maxBytesErr))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	tests := []struct {
+		name           string
+		limit          int64
+		body           string
+		unknownLength  bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{name: "Within the limit", limit: 8, body: "small", expectedStatus: http.StatusNoContent},
+		{name: "Declared length over the limit", limit: 8, body: "way too large", expectedStatus: http.StatusRequestEntityTooLarge, expectedType: ProblemContentType},
+		{name: "Streamed body over the limit", limit: 8, body: "way too large", unknownLength: true, expectedStatus: http.StatusBadRequest},
+		{name: "Disabled", limit: 0, body: "way too large", expectedStatus: http.StatusNoContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/products", strings.NewReader(tt.body))
+			if tt.unknownLength {
+				req.ContentLength = -1
+			}
+			rec := httptest.NewRecorder()
+			BodyLimit(tt.limit)(http.HandlerFunc(handler)).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedType != "" {
+				assert.Equal(t, tt.expectedType, rec.Header().Get("Content-Type"))
+				assert.Contains(t, rec.Body.String(), `"detail":"request body exceeds 8 bytes"`)
+			}
+		})
+	}
+}
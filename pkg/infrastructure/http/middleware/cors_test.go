@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORS(t *testing.T) {
+	restricted := CORSOptions{
+		AllowedOrigins:   []string{"https://shop.example.com"},
+		AllowedMethods:   []string{http.MethodGet, http.MethodPost},
+		AllowedHeaders:   []string{"Content-Type"},
+		ExposedHeaders:   []string{RequestIDHeader},
+		AllowCredentials: true,
+		MaxAge:           time.Minute,
+	}
+
+	tests := []struct {
+		name            string
+		opts            CORSOptions
+		method          string
+		headers         map[string]string
+		expectedStatus  int
+		expectedHeaders map[string]string
+	}{
+		{
+			name:            "Same-origin request",
+			opts:            DefaultCORSOptions(),
+			method:          http.MethodGet,
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:           "Any origin",
+			opts:           DefaultCORSOptions(),
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://other.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":   "*",
+				"Access-Control-Expose-Headers": "X-Request-ID",
+				"Vary":                          "Origin",
+			},
+		},
+		{
+			name:           "Allowed origin with credentials",
+			opts:           restricted,
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://shop.example.com"},
+			expectedStatus: http.StatusOK,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://shop.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+		{
+			name:            "Disallowed origin",
+			opts:            restricted,
+			method:          http.MethodGet,
+			headers:         map[string]string{"Origin": "https://evil.example.com"},
+			expectedStatus:  http.StatusOK,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:   "Preflight",
+			opts:   restricted,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://shop.example.com",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+			expectedStatus: http.StatusNoContent,
+			expectedHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://shop.example.com",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Content-Type",
+				"Access-Control-Max-Age":       "60",
+			},
+		},
+		{
+			name:   "Preflight from disallowed origin",
+			opts:   restricted,
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.example.com",
+				"Access-Control-Request-Method": http.MethodPost,
+			},
+			expectedStatus:  http.StatusNoContent,
+			expectedHeaders: map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CORS(tt.opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, "/products", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			for key, value := range tt.expectedHeaders {
+				assert.Equal(t, value, rec.Header().Get(key), key)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGzip(t *testing.T) {
+	body := strings.Repeat(`{"id":"1","name":"Product"}`, 20)
+
+	tests := []struct {
+		name           string
+		method         string
+		acceptEncoding string
+		handler        http.HandlerFunc
+		expectGzip     bool
+		expectedStatus int
+		expectedType   string
+	}{
+		{
+			name:           "Compresses when accepted",
+			method:         http.MethodGet,
+			acceptEncoding: "deflate, gzip;q=0.8",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			},
+			expectGzip:     true,
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+		},
+		{
+			name:           "Sniffs the uncompressed content type",
+			method:         http.MethodGet,
+			acceptEncoding: "gzip",
+			handler:        func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(body)) },
+			expectGzip:     true,
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/plain; charset=utf-8",
+		},
+		{
+			name:           "Not accepted",
+			method:         http.MethodGet,
+			acceptEncoding: "br",
+			handler:        func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(body)) },
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/plain; charset=utf-8",
+		},
+		{
+			name:           "Refused with q=0",
+			method:         http.MethodGet,
+			acceptEncoding: "gzip;q=0",
+			handler:        func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(body)) },
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/plain; charset=utf-8",
+		},
+		{
+			name:           "No content",
+			method:         http.MethodDelete,
+			acceptEncoding: "gzip",
+			handler:        func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) },
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "Already encoded",
+			method:         http.MethodGet,
+			acceptEncoding: "gzip",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Encoding", "identity")
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(body))
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/products", nil)
+			req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			rec := httptest.NewRecorder()
+			Gzip()(tt.handler).ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, "Accept-Encoding", rec.Header().Get("Vary"))
+			assert.Equal(t, tt.expectedType, rec.Header().Get("Content-Type"))
+			if !tt.expectGzip {
+				assert.NotEqual(t, "gzip", rec.Header().Get("Content-Encoding"))
+				if tt.expectedStatus == http.StatusOK {
+					assert.Equal(t, body, rec.Body.String())
+				}
+				return
+			}
+
+			assert.Equal(t, "gzip", rec.Header().Get("Content-Encoding"))
+			assert.Less(t, rec.Body.Len(), len(body))
+			reader, err := gzip.NewReader(rec.Body)
+			require.NoError(t, err)
+			decoded, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, body, string(decoded))
+		})
+	}
+}
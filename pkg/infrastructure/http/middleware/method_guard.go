@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+// MethodGuard answers 405 with a problem response for the methods guard does
+// not allow, so handlers no longer need to check r.Method themselves.
+func MethodGuard(guard adapter.HttpMethodGuard) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !guard.IsMethodAllowed(r.Method) {
+				WriteProblem(w, r, http.StatusMethodNotAllowed, adapter.ErrHttpMethodNotAllowed.Error())
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// MethodNotAllowed answers 405 with a problem response and lists the methods
+// allowed returns for the request's resource in the Allow header. Routers use
+// it once a path matched but its method did not.
+func MethodNotAllowed(allowed func(r *http.Request) []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if methods := allowed(r); len(methods) > 0 {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+		}
+		WriteProblem(w, r, http.StatusMethodNotAllowed, adapter.ErrHttpMethodNotAllowed.Error())
+	})
+}
@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+func TestMethodGuard(t *testing.T) {
+	handler := MethodGuard(adapter.NewHttpMethodGuard([]string{http.MethodPost}))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	tests := []struct {
+		method         string
+		expectedStatus int
+	}{
+		{method: http.MethodPost, expectedStatus: http.StatusCreated},
+		{method: http.MethodGet, expectedStatus: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(tt.method, "/products", nil))
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedStatus == http.StatusMethodNotAllowed {
+				assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+				assert.Contains(t, rec.Body.String(), adapter.ErrHttpMethodNotAllowed.Error())
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	tests := []struct {
+		name          string
+		allowed       []string
+		expectedAllow string
+	}{
+		{name: "Allowed methods", allowed: []string{http.MethodGet, http.MethodPut}, expectedAllow: "GET, PUT"},
+		{name: "No allowed method", expectedAllow: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := MethodNotAllowed(func(r *http.Request) []string { return tt.allowed })
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPatch, "/products/1", nil))
+
+			assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+			assert.Equal(t, tt.expectedAllow, rec.Header().Get("Allow"))
+			assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+			assert.Contains(t, rec.Body.String(), adapter.ErrHttpMethodNotAllowed.Error())
+		})
+	}
+}
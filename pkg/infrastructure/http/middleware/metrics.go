@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// RequestMetrics are the HTTP instruments shared by the net/http and the
+// Lambda metrics middleware, so both report the same series.
+type RequestMetrics struct {
+	requests application.Counter
+	duration application.Histogram
+	inFlight application.Gauge
+}
+
+func NewRequestMetrics(metrics application.Metrics) *RequestMetrics {
+	return &RequestMetrics{
+		requests: metrics.Counter("http_requests_total", "HTTP requests by route, method and status.", "route", "method", "status"),
+		duration: metrics.Histogram("http_request_duration_seconds", "HTTP request latency.", nil, "route", "method", "status"),
+		inFlight: metrics.Gauge("http_requests_in_flight", "HTTP requests being served."),
+	}
+}
+
+// Start counts a request in flight and returns the function that records
+// its outcome once the response is known.
+func (m *RequestMetrics) Start() func(route, method string, status int) {
+	start := time.Now()
+	m.inFlight.Add(1)
+	return func(route, method string, status int) {
+		m.inFlight.Add(-1)
+		code := strconv.Itoa(status)
+		m.requests.Inc(route, method, code)
+		m.duration.Observe(time.Since(start).Seconds(), route, method, code)
+	}
+}
+
+// Metrics records the count and latency of every request. route returns the
+// route template that matched, such as /products/{id}, so that IDs in paths
+// do not create a series each. A panicking handler is recorded as a 500.
+func Metrics(metrics application.Metrics, route func(r *http.Request) string) Middleware {
+	requestMetrics := NewRequestMetrics(metrics)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			done := requestMetrics.Start()
+			recorder := newResponseRecorder(w)
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					done(route(r), r.Method, http.StatusInternalServerError)
+					panic(recovered)
+				}
+				done(route(r), r.Method, recorder.status)
+			}()
+
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/metrics/prometheus"
+)
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	route := func(r *http.Request) string { return "/products/{id}" }
+	handler := Metrics(registry, route)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/products/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/products/panic":
+			panic("boom")
+		default:
+			w.Write([]byte("{}"))
+		}
+	}))
+
+	for _, path := range []string{"/products/1", "/products/2", "/products/missing"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	assert.PanicsWithValue(t, "boom", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/products/panic", nil))
+	})
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	for _, line := range []string{
+		`http_requests_total{route="/products/{id}",method="GET",status="200"} 2`,
+		`http_requests_total{route="/products/{id}",method="GET",status="404"} 1`,
+		`http_requests_total{route="/products/{id}",method="GET",status="500"} 1`,
+		`http_request_duration_seconds_count{route="/products/{id}",method="GET",status="200"} 2`,
+		`http_requests_in_flight 0`,
+	} {
+		assert.Contains(t, out.String(), line+"\n")
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+type recordingLogger struct {
+	infos  []map[string]interface{}
+	errors []error
+	root   *recordingLogger
+	values []interface{}
+}
+
+func (l *recordingLogger) Debug(string, ...interface{}) {}
+
+func (l *recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.rootLogger().infos = append(l.rootLogger().infos, fields(msg, append(l.values, keysAndValues...)))
+}
+
+func (l *recordingLogger) Warn(string, ...interface{}) {}
+
+func (l *recordingLogger) Error(msg string, err error, keysAndValues ...interface{}) {
+	l.rootLogger().errors = append(l.rootLogger().errors, err)
+}
+
+func (l *recordingLogger) With(keysAndValues ...interface{}) application.Logger {
+	values := append(append([]interface{}{}, l.values...), keysAndValues...)
+	return &recordingLogger{root: l.rootLogger(), values: values}
+}
+
+func (l *recordingLogger) rootLogger() *recordingLogger {
+	if l.root != nil {
+		return l.root
+	}
+	return l
+}
+
+func fields(msg string, keysAndValues []interface{}) map[string]interface{} {
+	entry := map[string]interface{}{"msg": msg}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		entry[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	return entry
+}
+
+func tag(name string, order *[]string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*order = append(*order, "before "+name)
+			next.ServeHTTP(w, r)
+			*order = append(*order, "after "+name)
+		})
+	}
+}
+
+func TestChain(t *testing.T) {
+	var order []string
+	handler := Chain(tag("outer", &order), tag("inner", &order))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		order = append(order, "handler")
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, []string{"before outer", "before inner", "handler", "after inner", "after outer"}, order)
+}
+
+func TestChain_Empty(t *testing.T) {
+	rec := httptest.NewRecorder()
+	Chain()(http.NotFoundHandler()).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
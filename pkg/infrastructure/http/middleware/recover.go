@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// Recover turns a panic in the handler into a 500 problem response, unless
+// the handler already started writing one, and logs it with the stack.
+// http.ErrAbortHandler is re-raised so the server aborts the connection.
+func Recover(logger application.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			recorder := newResponseRecorder(w)
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(recovered)
+				}
+
+				logger.Error("Recovered from panic", fmt.Errorf("panic: %v", recovered),
+					"method", r.Method,
+					"path", r.URL.Path,
+					"request_id", RequestIDFromContext(r.Context()),
+					"stack", string(debug.Stack()),
+				)
+				if !recorder.wroteHeader {
+					WriteProblem(recorder, r, http.StatusInternalServerError, "")
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
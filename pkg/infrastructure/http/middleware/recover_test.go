@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecover(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := Chain(RequestID(), AccessLog(logger), Recover(logger))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/products/1", nil)
+	req.Header.Set(RequestIDHeader, "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, Problem{
+		Type:      "about:blank",
+		Title:     "Internal Server Error",
+		Status:    http.StatusInternalServerError,
+		Instance:  "/products/1",
+		RequestID: "abc",
+	}, problem)
+
+	require.Len(t, logger.errors, 1)
+	assert.EqualError(t, logger.errors[0], "panic: boom")
+	require.Len(t, logger.infos, 1)
+	assert.Equal(t, http.StatusInternalServerError, logger.infos[0]["status"])
+}
+
+func TestRecover_AfterWriteHeader(t *testing.T) {
+	logger := &recordingLogger{}
+	handler := Recover(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte("partial"))
+		panic("boom")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+	assert.Len(t, logger.errors, 1)
+}
+
+func TestRecover_ReraisesAbortHandler(t *testing.T) {
+	handler := Recover(&recordingLogger{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected string
+	}{
+		{name: "Propagates the incoming request ID", header: "abc-123", expected: "abc-123"},
+		{name: "Generates a request ID"},
+		{name: "Replaces an oversized request ID", header: strings.Repeat("a", 129)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			handler := RequestID()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.NotEmpty(t, seen)
+			assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, seen)
+			} else {
+				assert.NotEqual(t, tt.header, seen)
+			}
+		})
+	}
+}
+
+func TestRequestIDFromContext_Missing(t *testing.T) {
+	assert.Empty(t, RequestIDFromContext(context.Background()))
+}
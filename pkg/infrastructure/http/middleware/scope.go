@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// Scope opens a request scope for every request, exposes it through the
+// request context and closes it once the handler returns. The request ID set
+// by RequestID is the scope's correlation ID, so the access log and the
+// scoped logger tag the same value; without it one is generated. A scope
+// the context already carries, such as the one of the Lambda invocation
+// serving the request, is kept.
+func Scope(factory application.Factory, logger application.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := application.ScopeFromContext(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			correlationID := RequestIDFromContext(r.Context())
+			if correlationID == "" {
+				correlationID = application.NewCorrelationID()
+			}
+
+			scope := application.NewRequestScope(factory, logger, correlationID)
+			defer func() {
+				if err := scope.Close(); err != nil {
+					logger.Error("Error closing request scope", err, application.CorrelationIDKey, correlationID)
+				}
+			}()
+
+			next.ServeHTTP(w, r.WithContext(application.ContextWithScope(r.Context(), scope)))
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+type closeFunc func() error
+
+func (f closeFunc) Close() error { return f() }
+
+func TestScope(t *testing.T) {
+	closed := 0
+	factory := application.NewFactory(application.NewSimpleServiceLocator())
+	factory.RegisterRecipe("Session", application.Recipe{
+		Lifetime: application.Scoped,
+		Factory: func(map[string]interface{}) (interface{}, error) {
+			return closeFunc(func() error { closed++; return nil }), nil
+		},
+	})
+
+	var correlationID string
+	scoped := Scope(factory, application.NopLogger())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := application.ScopeFromContext(r.Context())
+		assert.True(t, ok)
+
+		correlationID = application.MustCreate[string](scope, application.CorrelationIDKey)
+		_, err := scope.Create("Session")
+		assert.NoError(t, err)
+	}))
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		header  string
+	}{
+		{name: "Reuses the request ID", handler: RequestID()(scoped), header: "abc"},
+		{name: "Reuses the generated request ID", handler: RequestID()(scoped)},
+		{name: "Generates a correlation ID without a request ID", handler: scoped},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/products", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			tt.handler.ServeHTTP(rec, req)
+
+			switch {
+			case tt.header != "":
+				assert.Equal(t, tt.header, correlationID)
+			case rec.Header().Get(RequestIDHeader) != "":
+				assert.Equal(t, rec.Header().Get(RequestIDHeader), correlationID)
+			default:
+				assert.Len(t, correlationID, 32)
+			}
+			assert.Equal(t, i+1, closed)
+		})
+	}
+}
+
+func TestScope_KeepsTheContextScope(t *testing.T) {
+	factory := application.NewFactory(application.NewSimpleServiceLocator())
+	outer := application.NewRequestScope(factory, application.NopLogger(), "outer")
+	defer outer.Close()
+
+	handler := Scope(factory, application.NopLogger())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope, ok := application.ScopeFromContext(r.Context())
+		assert.True(t, ok)
+		assert.Equal(t, outer, scope)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(application.ContextWithScope(req.Context(), outer)))
+}
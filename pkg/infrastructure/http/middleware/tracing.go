@@ -0,0 +1,45 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+)
+
+// Tracing starts a span for every request, continuing the trace of the
+// caller when the request carries a W3C traceparent header. The span is
+// named after the method and the route template, like the metrics, and
+// handlers reach it through the request context. Server errors and panics
+// mark the span as failed.
+func Tracing(tracer application.Tracer, route func(r *http.Request) string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := tracing.Extract(r.Context(), r.Header.Get(tracing.TraceparentHeader))
+			template := route(r)
+			ctx, span := tracer.Start(ctx, r.Method+" "+template,
+				"http.request.method", r.Method,
+				"http.route", template,
+				"url.path", r.URL.Path,
+			)
+			recorder := newResponseRecorder(w)
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					span.SetAttributes("http.response.status_code", http.StatusInternalServerError)
+					span.RecordError(fmt.Errorf("panic: %v", recovered))
+					span.End()
+					panic(recovered)
+				}
+				span.SetAttributes("http.response.status_code", recorder.status)
+				if recorder.status >= http.StatusInternalServerError {
+					span.RecordError(errors.New(http.StatusText(recorder.status)))
+				}
+				span.End()
+			}()
+
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		traceparent    string
+		expectedStatus float64
+		expectedError  string
+	}{
+		{name: "New trace", path: "/products/1", expectedStatus: 200},
+		{name: "Remote parent", path: "/products/1", traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", expectedStatus: 200},
+		{name: "Server error", path: "/products/broken", expectedStatus: 503, expectedError: "Service Unavailable"},
+		{name: "Panic", path: "/products/panic", expectedStatus: 500, expectedError: "panic: boom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			var handlerTraceparent string
+			route := func(r *http.Request) string { return "/products/{id}" }
+			handler := Tracing(tracing.NewTracer(tracing.NewStdoutExporter(&out), tracing.WithSyncExport()), route)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				handlerTraceparent = tracing.Traceparent(r.Context())
+				switch r.URL.Path {
+				case "/products/broken":
+					w.WriteHeader(http.StatusServiceUnavailable)
+				case "/products/panic":
+					panic("boom")
+				}
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.traceparent != "" {
+				req.Header.Set(tracing.TraceparentHeader, tt.traceparent)
+			}
+			func() {
+				defer func() { recover() }()
+				handler.ServeHTTP(httptest.NewRecorder(), req)
+			}()
+
+			var span map[string]interface{}
+			require.NoError(t, json.Unmarshal(out.Bytes(), &span))
+			assert.Equal(t, "GET /products/{id}", span["name"])
+			assert.Equal(t, "server", span["kind"])
+			attributes := span["attributes"].(map[string]interface{})
+			assert.Equal(t, "/products/{id}", attributes["http.route"])
+			assert.Equal(t, tt.path, attributes["url.path"])
+			assert.Equal(t, tt.expectedStatus, attributes["http.response.status_code"])
+			if tt.expectedError != "" {
+				assert.Equal(t, tt.expectedError, span["error"])
+			} else {
+				assert.Nil(t, span["error"])
+			}
+			assert.Equal(t, "00-"+span["trace_id"].(string)+"-"+span["span_id"].(string)+"-01", handlerTraceparent)
+			if tt.traceparent != "" {
+				assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span["trace_id"])
+				assert.Equal(t, "00f067aa0ba902b7", span["parent_span_id"])
+			} else {
+				assert.Nil(t, span["parent_span_id"])
+			}
+		})
+	}
+}
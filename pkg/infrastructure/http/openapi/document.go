@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type PathItem struct {
+	Parameters []Parameter `json:"parameters,omitempty"`
+	Get        *Operation  `json:"get,omitempty"`
+	Post       *Operation  `json:"post,omitempty"`
+	Put        *Operation  `json:"put,omitempty"`
+	Patch      *Operation  `json:"patch,omitempty"`
+	Delete     *Operation  `json:"delete,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                     `json:"operationId,omitempty"`
+	Parameters  []Parameter                `json:"parameters,omitempty"`
+	RequestBody *RequestBody               `json:"requestBody,omitempty"`
+	Responses   map[string]json.RawMessage `json:"responses,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema,omitempty"`
+}
+
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+func Load(data []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	if len(doc.Paths) == 0 {
+		return nil, fmt.Errorf("invalid OpenAPI document: no paths defined")
+	}
+	if err := doc.resolveRefs(); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func (p PathItem) Operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case http.MethodGet:
+		return p.Get
+	case http.MethodPost:
+		return p.Post
+	case http.MethodPut:
+		return p.Put
+	case http.MethodPatch:
+		return p.Patch
+	case http.MethodDelete:
+		return p.Delete
+	}
+	return nil
+}
+
+func (d *Document) resolveRefs() error {
+	resolving := make(map[*Schema]bool)
+	for _, schema := range d.Components.Schemas {
+		if err := d.resolveSchema(schema, resolving); err != nil {
+			return err
+		}
+	}
+	for _, item := range d.Paths {
+		for _, param := range item.Parameters {
+			if err := d.resolveSchema(param.Schema, resolving); err != nil {
+				return err
+			}
+		}
+		for _, op := range []*Operation{item.Get, item.Post, item.Put, item.Patch, item.Delete} {
+			if op == nil {
+				continue
+			}
+			for _, param := range op.Parameters {
+				if err := d.resolveSchema(param.Schema, resolving); err != nil {
+					return err
+				}
+			}
+			if op.RequestBody == nil {
+				continue
+			}
+			for _, media := range op.RequestBody.Content {
+				if err := d.resolveSchema(media.Schema, resolving); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (d *Document) resolveSchema(schema *Schema, resolving map[*Schema]bool) error {
+	if schema == nil || resolving[schema] {
+		return nil
+	}
+	resolving[schema] = true
+
+	if schema.Ref != "" && schema.resolved == nil {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		target, ok := d.Components.Schemas[name]
+		if !ok || name == schema.Ref {
+			return fmt.Errorf("invalid OpenAPI document: unresolved reference %s", schema.Ref)
+		}
+		schema.resolved = target
+		if err := d.resolveSchema(target, resolving); err != nil {
+			return err
+		}
+	}
+	if schema.Pattern != "" {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid OpenAPI document: invalid pattern %s: %w", schema.Pattern, err)
+		}
+		schema.pattern = pattern
+	}
+	for _, property := range schema.Properties {
+		if err := d.resolveSchema(property, resolving); err != nil {
+			return err
+		}
+	}
+	return d.resolveSchema(schema.Items, resolving)
+}
@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+func (v *Validator) WrapLambda(next lambda.Handler) lambda.Handler {
+	return func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		body := []byte(request.Body)
+		if request.IsBase64Encoded {
+			decoded, err := base64.StdEncoding.DecodeString(request.Body)
+			if err != nil {
+				return validationErrorResponse(&ValidationError{Status: http.StatusBadRequest, Err: adapter.ErrHttpInvalidJSON}), nil
+			}
+			body = decoded
+		}
+
+		err := v.Validate(Request{
+			Method:         request.HTTPMethod,
+			Path:           request.Path,
+			Resource:       request.Resource,
+			PathParameters: request.PathParameters,
+			Query:          lambdaQuery(request),
+			ContentType:    headerValue(request.Headers, "Content-Type"),
+			Body:           body,
+		})
+		if err != nil {
+			return validationErrorResponse(err), nil
+		}
+
+		return next(ctx, request)
+	}
+}
+
+func validationErrorResponse(err error) events.APIGatewayProxyResponse {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		validationErr = &ValidationError{Status: http.StatusBadRequest, Err: err}
+	}
+	body, _ := json.Marshal(validationErr)
+	return events.APIGatewayProxyResponse{
+		StatusCode: validationErr.Status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(body),
+	}
+}
+
+func lambdaQuery(request events.APIGatewayProxyRequest) map[string][]string {
+	if len(request.MultiValueQueryStringParameters) > 0 {
+		return request.MultiValueQueryStringParameters
+	}
+	query := make(map[string][]string, len(request.QueryStringParameters))
+	for key, value := range request.QueryStringParameters {
+		query[key] = []string{value}
+	}
+	return query
+}
+
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidator_WrapLambda(t *testing.T) {
+	validator := newTestValidator(t)
+
+	tests := []struct {
+		name           string
+		request        events.APIGatewayProxyRequest
+		expectedStatus int
+		expectedBody   string
+		expectNext     bool
+	}{
+		{
+			name: "Valid request reaches handler",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:     http.MethodPut,
+				Resource:       "/items/{id}",
+				Path:           "/items/ab",
+				PathParameters: map[string]string{"id": "ab"},
+				Body:           `{"name":"a"}`,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{}`,
+			expectNext:     true,
+		},
+		{
+			name: "Base64 encoded body is decoded",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:      http.MethodPost,
+				Resource:        "/items",
+				Path:            "/items",
+				Headers:         map[string]string{"content-type": "application/json"},
+				Body:            base64.StdEncoding.EncodeToString([]byte(`{"name":"a"}`)),
+				IsBase64Encoded: true,
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{}`,
+			expectNext:     true,
+		},
+		{
+			name: "Invalid base64 body",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:      http.MethodPost,
+				Resource:        "/items",
+				Path:            "/items",
+				Body:            "%%%",
+				IsBase64Encoded: true,
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"invalid JSON"}`,
+		},
+		{
+			name: "Missing required property",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod: http.MethodPost,
+				Resource:   "/items",
+				Path:       "/items",
+				Body:       `{}`,
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"validation failed","details":[{"field":"name","message":"is required"}]}`,
+		},
+		{
+			name: "Query string parameters",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				Resource:              "/items",
+				Path:                  "/items",
+				QueryStringParameters: map[string]string{"limit": "abc"},
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"validation failed","details":[{"field":"query.limit","message":"must be of type integer, got string"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+				called = true
+				return events.APIGatewayProxyResponse{StatusCode: http.StatusOK, Body: `{}`}, nil
+			}
+
+			resp, err := validator.WrapLambda(next)(context.Background(), tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectNext, called)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.JSONEq(t, tt.expectedBody, resp.Body)
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(io.LimitReader(r.Body, v.maxBodyBytes+1))
+			r.Body.Close()
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				writeValidationError(w, &ValidationError{Status: http.StatusRequestEntityTooLarge, Err: adapter.ErrHttpBodyTooLarge})
+				return
+			}
+			if err != nil {
+				writeValidationError(w, &ValidationError{Status: http.StatusBadRequest, Err: adapter.ErrHttpInvalidJSON})
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		err := v.Validate(Request{
+			Method:      r.Method,
+			Path:        r.URL.Path,
+			Query:       r.URL.Query(),
+			ContentType: r.Header.Get("Content-Type"),
+			Body:        body,
+		})
+		if err != nil {
+			writeValidationError(w, err)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func writeValidationError(w http.ResponseWriter, err error) {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		validationErr = &ValidationError{Status: http.StatusBadRequest, Err: err}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(validationErr.Status)
+	json.NewEncoder(w).Encode(validationErr)
+}
@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidator_Middleware(t *testing.T) {
+	validator := newTestValidator(t, WithMaxBodyBytes(32))
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		maxBytes       int64
+		expectedStatus int
+		expectedBody   string
+		expectNext     bool
+	}{
+		{
+			name:           "Valid request reaches handler with body intact",
+			method:         http.MethodPost,
+			path:           "/items",
+			body:           `{"name":"a"}`,
+			expectedStatus: http.StatusCreated,
+			expectedBody:   `{"name":"a"}`,
+			expectNext:     true,
+		},
+		{
+			name:           "Invalid request is rejected",
+			method:         http.MethodPost,
+			path:           "/items",
+			body:           `{"name":1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"validation failed","details":[{"field":"name","message":"must be of type string, got number"}]}`,
+		},
+		{
+			name:           "Oversized request is rejected",
+			method:         http.MethodPost,
+			path:           "/items",
+			body:           `{"name":"` + strings.Repeat("a", 64) + `"}`,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"request body too large"}`,
+		},
+		{
+			name:           "Chunked request over the server limit is rejected",
+			method:         http.MethodPost,
+			path:           "/items",
+			body:           `{"name":"abcdefghijklmnop"}`,
+			maxBytes:       16,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedBody:   `{"error":"request body too large"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				body, _ := io.ReadAll(r.Body)
+				w.WriteHeader(http.StatusCreated)
+				w.Write(body)
+			})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rr := httptest.NewRecorder()
+			if tt.maxBytes > 0 {
+				req.ContentLength = -1
+				req.Body = http.MaxBytesReader(rr, req.Body, tt.maxBytes)
+			}
+			validator.Middleware(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.expectNext, called)
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.JSONEq(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
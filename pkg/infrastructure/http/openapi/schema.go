@@ -0,0 +1,222 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"unicode/utf8"
+)
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+
+	resolved *Schema
+	// pattern is Pattern compiled by Load.
+	pattern *regexp.Regexp
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (s *Schema) target() *Schema {
+	for s != nil && s.resolved != nil {
+		s = s.resolved
+	}
+	return s
+}
+
+func (s *Schema) Validate(value interface{}, field string) []FieldError {
+	s = s.target()
+	if s == nil {
+		return nil
+	}
+
+	if value == nil {
+		if s.Nullable || s.Type == "" {
+			return nil
+		}
+		return []FieldError{{Field: field, Message: "must not be null"}}
+	}
+
+	var errs []FieldError
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return []FieldError{typeMismatch(field, s.Type, value)}
+		}
+		errs = append(errs, s.validateObject(object, field)...)
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return []FieldError{typeMismatch(field, s.Type, value)}
+		}
+		for i, item := range items {
+			errs = append(errs, s.Items.Validate(item, fmt.Sprintf("%s[%d]", field, i))...)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return []FieldError{typeMismatch(field, s.Type, value)}
+		}
+		errs = append(errs, s.validateString(str, field)...)
+	case "number", "integer":
+		number, ok := toFloat(value)
+		if !ok {
+			return []FieldError{typeMismatch(field, s.Type, value)}
+		}
+		if s.Type == "integer" && number != math.Trunc(number) {
+			return []FieldError{typeMismatch(field, s.Type, value)}
+		}
+		errs = append(errs, s.validateNumber(number, field)...)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return []FieldError{typeMismatch(field, s.Type, value)}
+		}
+	}
+
+	if len(s.Enum) > 0 && !s.matchesEnum(value) {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be one of %v", s.Enum)})
+	}
+
+	return errs
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, field string) []FieldError {
+	var errs []FieldError
+	for _, name := range s.Required {
+		if _, ok := object[name]; !ok {
+			errs = append(errs, FieldError{Field: joinField(field, name), Message: "is required"})
+		}
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := s.Properties[name]
+		if !ok {
+			if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				errs = append(errs, FieldError{Field: joinField(field, name), Message: "is not allowed"})
+			}
+			continue
+		}
+		errs = append(errs, property.Validate(object[name], joinField(field, name))...)
+	}
+	return errs
+}
+
+func (s *Schema) validateString(str, field string) []FieldError {
+	var errs []FieldError
+	length := utf8.RuneCountInString(str)
+	if s.MinLength != nil && length < *s.MinLength {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be at least %d characters long", *s.MinLength)})
+	}
+	if s.MaxLength != nil && length > *s.MaxLength {
+		errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be at most %d characters long", *s.MaxLength)})
+	}
+	if s.Pattern != "" {
+		pattern, err := s.compiledPattern()
+		if err != nil || !pattern.MatchString(str) {
+			errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must match pattern %s", s.Pattern)})
+		}
+	}
+	return errs
+}
+
+// compiledPattern returns the pattern compiled by Load, compiling it for
+// schemas built without a document.
+func (s *Schema) compiledPattern() (*regexp.Regexp, error) {
+	if s.pattern != nil {
+		return s.pattern, nil
+	}
+	return regexp.Compile(s.Pattern)
+}
+
+func (s *Schema) validateNumber(number float64, field string) []FieldError {
+	var errs []FieldError
+	if s.Minimum != nil {
+		if s.ExclusiveMinimum && number <= *s.Minimum {
+			errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be greater than %v", *s.Minimum)})
+		} else if number < *s.Minimum {
+			errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be greater than or equal to %v", *s.Minimum)})
+		}
+	}
+	if s.Maximum != nil {
+		if s.ExclusiveMaximum && number >= *s.Maximum {
+			errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be less than %v", *s.Maximum)})
+		} else if number > *s.Maximum {
+			errs = append(errs, FieldError{Field: field, Message: fmt.Sprintf("must be less than or equal to %v", *s.Maximum)})
+		}
+	}
+	return errs
+}
+
+func (s *Schema) matchesEnum(value interface{}) bool {
+	for _, candidate := range s.Enum {
+		if fmt.Sprint(candidate) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func typeMismatch(field, expected string, value interface{}) FieldError {
+	return FieldError{Field: field, Message: fmt.Sprintf("must be of type %s, got %s", expected, jsonType(value))}
+}
+
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
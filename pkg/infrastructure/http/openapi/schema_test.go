@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(i int) *int {
+	return &i
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+func TestSchema_Validate(t *testing.T) {
+	productSchema := &Schema{
+		Type:                 "object",
+		AdditionalProperties: boolPtr(false),
+		Required:             []string{"name", "price"},
+		Properties: map[string]*Schema{
+			"name":  {Type: "string", MinLength: intPtr(1), MaxLength: intPtr(5)},
+			"price": {Type: "number", Minimum: float64Ptr(0), ExclusiveMinimum: true},
+			"stock": {Type: "integer", Minimum: float64Ptr(0)},
+			"tags":  {Type: "array", Items: &Schema{Type: "string", Pattern: "^[a-z]+$"}},
+			"kind":  {Type: "string", Enum: []interface{}{"physical", "digital"}},
+			"owner": {Type: "string", Nullable: true},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		payload  string
+		expected []FieldError
+	}{
+		{
+			name:    "Valid payload",
+			payload: `{"name":"Shoe","price":10.5,"stock":3,"tags":["sport"],"kind":"physical","owner":null}`,
+		},
+		{
+			name:    "Missing required properties",
+			payload: `{}`,
+			expected: []FieldError{
+				{Field: "name", Message: "is required"},
+				{Field: "price", Message: "is required"},
+			},
+		},
+		{
+			name:    "Unknown property",
+			payload: `{"name":"Shoe","price":1,"color":"red"}`,
+			expected: []FieldError{
+				{Field: "color", Message: "is not allowed"},
+			},
+		},
+		{
+			name:    "Wrong types",
+			payload: `{"name":10,"price":"10"}`,
+			expected: []FieldError{
+				{Field: "name", Message: "must be of type string, got number"},
+				{Field: "price", Message: "must be of type number, got string"},
+			},
+		},
+		{
+			name:    "Null on non nullable property",
+			payload: `{"name":null,"price":1}`,
+			expected: []FieldError{
+				{Field: "name", Message: "must not be null"},
+			},
+		},
+		{
+			name:    "Constraint violations",
+			payload: `{"name":"Sneakers","price":0,"stock":1.5,"tags":["ok","NOT"],"kind":"service"}`,
+			expected: []FieldError{
+				{Field: "kind", Message: "must be one of [physical digital]"},
+				{Field: "name", Message: "must be at most 5 characters long"},
+				{Field: "price", Message: "must be greater than 0"},
+				{Field: "stock", Message: "must be of type integer, got number"},
+				{Field: "tags[1]", Message: "must match pattern ^[a-z]+$"},
+			},
+		},
+		{
+			name:    "Not an object",
+			payload: `[]`,
+			expected: []FieldError{
+				{Field: "", Message: "must be of type object, got array"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value interface{}
+			decoder := json.NewDecoder(strings.NewReader(tt.payload))
+			decoder.UseNumber()
+			assert.NoError(t, decoder.Decode(&value))
+
+			errs := productSchema.Validate(value, "")
+			assert.Equal(t, tt.expected, errs)
+		})
+	}
+}
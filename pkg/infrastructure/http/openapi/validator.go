@@ -0,0 +1,290 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+type Request struct {
+	Method         string
+	Path           string
+	Resource       string
+	PathParameters map[string]string
+	Query          map[string][]string
+	ContentType    string
+	Body           []byte
+}
+
+type ValidationError struct {
+	Status  int
+	Err     error
+	Details []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+func (e *ValidationError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Error   string       `json:"error"`
+		Details []FieldError `json:"details,omitempty"`
+	}{
+		Error:   e.Err.Error(),
+		Details: e.Details,
+	})
+}
+
+type Validator struct {
+	routes       []route
+	maxBodyBytes int64
+}
+
+type route struct {
+	template string
+	segments []string
+	params   int
+	item     PathItem
+}
+
+type ValidatorOption func(*Validator) error
+
+func WithMaxBodyBytes(maxBodyBytes int64) ValidatorOption {
+	return func(v *Validator) error {
+		if maxBodyBytes <= 0 {
+			return fmt.Errorf("max body bytes must be positive, got %d", maxBodyBytes)
+		}
+		v.maxBodyBytes = maxBodyBytes
+		return nil
+	}
+}
+
+func NewValidator(doc *Document, opts ...ValidatorOption) (*Validator, error) {
+	if doc == nil {
+		return nil, errors.New("missing OpenAPI document")
+	}
+
+	v := &Validator{maxBodyBytes: DefaultMaxBodyBytes}
+	for _, opt := range opts {
+		if err := opt(v); err != nil {
+			return nil, err
+		}
+	}
+
+	for template, item := range doc.Paths {
+		segments := splitPath(template)
+		params := 0
+		for _, segment := range segments {
+			if isParam(segment) {
+				params++
+			}
+		}
+		v.routes = append(v.routes, route{template: template, segments: segments, params: params, item: item})
+	}
+	// Static segments win over templated ones, e.g. /products/search before /products/{id}.
+	sort.Slice(v.routes, func(i, j int) bool {
+		if v.routes[i].params != v.routes[j].params {
+			return v.routes[i].params < v.routes[j].params
+		}
+		return v.routes[i].template < v.routes[j].template
+	})
+
+	return v, nil
+}
+
+func (v *Validator) MaxBodyBytes() int64 {
+	return v.maxBodyBytes
+}
+
+func (v *Validator) Validate(req Request) error {
+	if int64(len(req.Body)) > v.maxBodyBytes {
+		return &ValidationError{Status: http.StatusRequestEntityTooLarge, Err: adapter.ErrHttpBodyTooLarge}
+	}
+
+	item, params, ok := v.match(req)
+	if !ok {
+		return nil
+	}
+	op := item.Operation(req.Method)
+	if op == nil {
+		return nil
+	}
+
+	var details []FieldError
+	details = append(details, validateParameters(item.Parameters, params, req.Query)...)
+	details = append(details, validateParameters(op.Parameters, params, req.Query)...)
+
+	if op.RequestBody != nil {
+		bodyDetails, err := validateBody(op.RequestBody, req.ContentType, req.Body)
+		if err != nil {
+			return err
+		}
+		details = append(details, bodyDetails...)
+	}
+
+	if len(details) > 0 {
+		return &ValidationError{Status: http.StatusBadRequest, Err: adapter.ErrHttpValidation, Details: details}
+	}
+	return nil
+}
+
+func (v *Validator) match(req Request) (PathItem, map[string]string, bool) {
+	if req.Resource != "" {
+		for _, r := range v.routes {
+			if r.template == req.Resource {
+				params := req.PathParameters
+				if params == nil {
+					params, _ = r.extract(splitPath(req.Path))
+				}
+				return r.item, params, true
+			}
+		}
+	}
+
+	segments := splitPath(req.Path)
+	for _, r := range v.routes {
+		if params, ok := r.extract(segments); ok {
+			return r.item, params, true
+		}
+	}
+	return PathItem{}, nil, false
+}
+
+func (r route) extract(segments []string) (map[string]string, bool) {
+	if len(segments) != len(r.segments) {
+		return nil, false
+	}
+	params := make(map[string]string, r.params)
+	for i, segment := range r.segments {
+		if isParam(segment) {
+			params[strings.Trim(segment, "{}")] = segments[i]
+			continue
+		}
+		if segment != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+func validateParameters(parameters []Parameter, path map[string]string, query map[string][]string) []FieldError {
+	var details []FieldError
+	for _, param := range parameters {
+		var (
+			value   string
+			present bool
+		)
+		switch param.In {
+		case "path":
+			value, present = path[param.Name]
+			present = present && value != ""
+		case "query":
+			var values []string
+			values, present = query[param.Name]
+			if present && len(values) > 0 {
+				value = values[0]
+			}
+		default:
+			continue
+		}
+
+		field := param.In + "." + param.Name
+		if !present {
+			if param.Required {
+				details = append(details, FieldError{Field: field, Message: "is required"})
+			}
+			continue
+		}
+		details = append(details, param.Schema.Validate(parameterValue(param.Schema, value), field)...)
+	}
+	return details
+}
+
+func parameterValue(schema *Schema, raw string) interface{} {
+	schema = schema.target()
+	if schema == nil {
+		return raw
+	}
+	switch schema.Type {
+	case "number", "integer":
+		number := json.Number(raw)
+		if _, err := number.Float64(); err == nil {
+			return number
+		}
+	case "boolean":
+		switch raw {
+		case "true":
+			return true
+		case "false":
+			return false
+		}
+	}
+	return raw
+}
+
+func validateBody(body *RequestBody, contentType string, payload []byte) ([]FieldError, error) {
+	if len(bytes.TrimSpace(payload)) == 0 {
+		if body.Required {
+			return []FieldError{{Field: "body", Message: "is required"}}, nil
+		}
+		return nil, nil
+	}
+
+	mediaType := "application/json"
+	if contentType != "" {
+		parsed, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			return nil, &ValidationError{Status: http.StatusUnsupportedMediaType, Err: adapter.ErrHttpUnsupportedMediaType}
+		}
+		mediaType = parsed
+	}
+	media, ok := body.Content[mediaType]
+	if !ok {
+		return nil, &ValidationError{Status: http.StatusUnsupportedMediaType, Err: adapter.ErrHttpUnsupportedMediaType}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, &ValidationError{Status: http.StatusBadRequest, Err: adapter.ErrHttpInvalidJSON}
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, &ValidationError{Status: http.StatusBadRequest, Err: adapter.ErrHttpInvalidJSON}
+	}
+
+	details := media.Schema.Validate(value, "")
+	for i := range details {
+		if details[i].Field == "" {
+			details[i].Field = "body"
+		}
+	}
+	return details, nil
+}
+
+func splitPath(path string) []string {
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, "/")
+}
+
+func isParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
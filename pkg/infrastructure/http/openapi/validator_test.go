@@ -0,0 +1,218 @@
+package openapi
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/adapter"
+)
+
+const testSpec = `{
+  "openapi": "3.0.3",
+  "info": {"title": "test", "version": "1.0.0"},
+  "paths": {
+    "/items": {
+      "get": {
+        "parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer", "minimum": 1}}]
+      },
+      "post": {
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}
+        }
+      }
+    },
+    "/items/search": {
+      "get": {}
+    },
+    "/items/{id}": {
+      "parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string", "minLength": 2}}],
+      "put": {
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Item"}}}
+        }
+      }
+    }
+  },
+  "components": {
+    "schemas": {
+      "Item": {
+        "type": "object",
+        "additionalProperties": false,
+        "required": ["name"],
+        "properties": {"name": {"type": "string", "minLength": 1}}
+      }
+    }
+  }
+}`
+
+func newTestValidator(t *testing.T, opts ...ValidatorOption) *Validator {
+	doc, err := Load([]byte(testSpec))
+	assert.NoError(t, err)
+	validator, err := NewValidator(doc, opts...)
+	assert.NoError(t, err)
+	return validator
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := Load([]byte(`{invalid`))
+		assert.Error(t, err)
+	})
+
+	t.Run("No paths", func(t *testing.T) {
+		_, err := Load([]byte(`{"openapi":"3.0.3"}`))
+		assert.EqualError(t, err, "invalid OpenAPI document: no paths defined")
+	})
+
+	t.Run("Unresolved reference", func(t *testing.T) {
+		_, err := Load([]byte(`{"paths":{"/a":{"post":{"requestBody":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}`))
+		assert.EqualError(t, err, "invalid OpenAPI document: unresolved reference #/components/schemas/Missing")
+	})
+
+	t.Run("Invalid pattern", func(t *testing.T) {
+		_, err := Load([]byte(`{"paths":{"/a":{"get":{"parameters":[{"name":"q","in":"query","schema":{"type":"string","pattern":"[a-"}}]}}}}`))
+		assert.ErrorContains(t, err, "invalid OpenAPI document: invalid pattern [a-")
+	})
+
+	t.Run("Compiles patterns", func(t *testing.T) {
+		doc, err := Load([]byte(`{"paths":{"/a":{"get":{"parameters":[{"name":"q","in":"query","schema":{"$ref":"#/components/schemas/Code"}}]}}},"components":{"schemas":{"Code":{"type":"string","pattern":"^[A-Z]{3}$"}}}}`))
+		assert.NoError(t, err)
+		schema := doc.Paths["/a"].Get.Parameters[0].Schema.target()
+		assert.NotNil(t, schema.pattern)
+		assert.Empty(t, schema.Validate("ABC", "q"))
+		assert.Len(t, schema.Validate("abc", "q"), 1)
+	})
+}
+
+func TestNewValidator(t *testing.T) {
+	_, err := NewValidator(nil)
+	assert.Error(t, err)
+
+	doc, _ := Load([]byte(testSpec))
+	_, err = NewValidator(doc, WithMaxBodyBytes(0))
+	assert.Error(t, err)
+
+	validator, err := NewValidator(doc, WithMaxBodyBytes(10))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), validator.MaxBodyBytes())
+}
+
+func TestValidator_Validate(t *testing.T) {
+	validator := newTestValidator(t, WithMaxBodyBytes(64))
+
+	tests := []struct {
+		name           string
+		request        Request
+		expectedErr    error
+		expectedStatus int
+		expectedFields []FieldError
+	}{
+		{
+			name:    "Valid body",
+			request: Request{Method: http.MethodPost, Path: "/items", Body: []byte(`{"name":"a"}`)},
+		},
+		{
+			name:    "Unknown route is not validated",
+			request: Request{Method: http.MethodPost, Path: "/unknown", Body: []byte(`garbage`)},
+		},
+		{
+			name:    "Undeclared method is not validated",
+			request: Request{Method: http.MethodDelete, Path: "/items/abc", Body: []byte(`garbage`)},
+		},
+		{
+			name:    "Static route wins over templated route",
+			request: Request{Method: http.MethodGet, Path: "/items/search"},
+		},
+		{
+			name:           "Missing body",
+			request:        Request{Method: http.MethodPost, Path: "/items"},
+			expectedErr:    adapter.ErrHttpValidation,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []FieldError{{Field: "body", Message: "is required"}},
+		},
+		{
+			name:           "Invalid JSON",
+			request:        Request{Method: http.MethodPost, Path: "/items", Body: []byte(`{"name":`)},
+			expectedErr:    adapter.ErrHttpInvalidJSON,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Trailing data after JSON",
+			request:        Request{Method: http.MethodPost, Path: "/items", Body: []byte(`{"name":"a"} {}`)},
+			expectedErr:    adapter.ErrHttpInvalidJSON,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Body too large",
+			request:        Request{Method: http.MethodPost, Path: "/items", Body: []byte(`{"name":"` + strings.Repeat("a", 64) + `"}`)},
+			expectedErr:    adapter.ErrHttpBodyTooLarge,
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "Unsupported media type",
+			request:        Request{Method: http.MethodPost, Path: "/items", ContentType: "text/plain", Body: []byte(`name=a`)},
+			expectedErr:    adapter.ErrHttpUnsupportedMediaType,
+			expectedStatus: http.StatusUnsupportedMediaType,
+		},
+		{
+			name:    "Content type with charset",
+			request: Request{Method: http.MethodPost, Path: "/items", ContentType: "application/json; charset=utf-8", Body: []byte(`{"name":"a"}`)},
+		},
+		{
+			name:           "Body schema violations",
+			request:        Request{Method: http.MethodPost, Path: "/items", Body: []byte(`{"name":"","extra":true}`)},
+			expectedErr:    adapter.ErrHttpValidation,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []FieldError{
+				{Field: "extra", Message: "is not allowed"},
+				{Field: "name", Message: "must be at least 1 characters long"},
+			},
+		},
+		{
+			name:           "Path parameter from concrete path",
+			request:        Request{Method: http.MethodPut, Path: "/items/a", Body: []byte(`{"name":"a"}`)},
+			expectedErr:    adapter.ErrHttpValidation,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []FieldError{{Field: "path.id", Message: "must be at least 2 characters long"}},
+		},
+		{
+			name: "Path parameter from resource",
+			request: Request{
+				Method:         http.MethodPut,
+				Path:           "/stage/items/a",
+				Resource:       "/items/{id}",
+				PathParameters: map[string]string{"id": "ab"},
+				Body:           []byte(`{"name":"a"}`),
+			},
+		},
+		{
+			name:           "Query parameter violation",
+			request:        Request{Method: http.MethodGet, Path: "/items", Query: map[string][]string{"limit": {"0"}}},
+			expectedErr:    adapter.ErrHttpValidation,
+			expectedStatus: http.StatusBadRequest,
+			expectedFields: []FieldError{{Field: "query.limit", Message: "must be greater than or equal to 1"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.Validate(tt.request)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			assert.ErrorIs(t, err, tt.expectedErr)
+			var validationErr *ValidationError
+			assert.True(t, errors.As(err, &validationErr))
+			assert.Equal(t, tt.expectedStatus, validationErr.Status)
+			assert.Equal(t, tt.expectedFields, validationErr.Details)
+		})
+	}
+}
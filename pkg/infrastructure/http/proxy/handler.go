@@ -0,0 +1,33 @@
+package proxy
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+func NewHandler(handler http.Handler) lambda.RequestHandler {
+	return func(ctx context.Context, request lambda.Request) (lambda.Response, error) {
+		req, err := NewRequest(ctx, request)
+		if err != nil {
+			return lambda.Response{}, err
+		}
+
+		w := NewResponseWriter()
+		handler.ServeHTTP(w, req)
+		return w.Response(), nil
+	}
+}
+
+func NewAPIGatewayProxyHandler(handler http.Handler) lambda.Handler {
+	return lambda.NewAPIGatewayProxyHandler(NewHandler(handler))
+}
+
+func NewAPIGatewayV2HTTPHandler(handler http.Handler) lambda.APIGatewayV2HTTPHandler {
+	return lambda.NewAPIGatewayV2HTTPHandler(NewHandler(handler))
+}
+
+func NewALBTargetGroupHandler(handler http.Handler) lambda.ALBTargetGroupHandler {
+	return lambda.NewALBTargetGroupHandler(NewHandler(handler))
+}
@@ -0,0 +1,135 @@
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRouter() *mux.Router {
+	r := mux.NewRouter()
+	r.HandleFunc("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"` + mux.Vars(r)["id"] + `","tag":"` + r.URL.Query().Get("tag") + `","body":"` + string(body) + `","host":"` + r.Host + `"}`))
+	}).Methods(http.MethodPut)
+	r.HandleFunc("/image", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte{0x89, 0x50, 0x4e, 0x47})
+	})
+	r.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return r
+}
+
+func TestNewAPIGatewayProxyHandler(t *testing.T) {
+	handler := NewAPIGatewayProxyHandler(newTestRouter())
+
+	tests := []struct {
+		name            string
+		request         events.APIGatewayProxyRequest
+		expectedStatus  int
+		expectedBody    string
+		expectedBase64  bool
+		expectedHeaders map[string]string
+		expectedMulti   map[string][]string
+	}{
+		{
+			name: "Routes through gorilla mux with base64 request body",
+			request: events.APIGatewayProxyRequest{
+				HTTPMethod:                      http.MethodPut,
+				Path:                            "/products/42",
+				Headers:                         map[string]string{"Host": "api.example.com"},
+				MultiValueQueryStringParameters: map[string][]string{"tag": {"new"}},
+				Body:                            base64.StdEncoding.EncodeToString([]byte("payload")),
+				IsBase64Encoded:                 true,
+			},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    `{"id":"42","tag":"new","body":"payload","host":"api.example.com"}`,
+			expectedHeaders: map[string]string{"Content-Type": "application/json"},
+			expectedMulti:   map[string][]string{"Set-Cookie": {"a=1", "b=2"}},
+		},
+		{
+			name:            "Binary responses are base64 encoded",
+			request:         events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/image"},
+			expectedStatus:  http.StatusOK,
+			expectedBody:    base64.StdEncoding.EncodeToString([]byte{0x89, 0x50, 0x4e, 0x47}),
+			expectedBase64:  true,
+			expectedHeaders: map[string]string{"Content-Type": "image/png"},
+		},
+		{
+			name:           "Status without body",
+			request:        events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/empty"},
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "Router not found",
+			request:         events.APIGatewayProxyRequest{HTTPMethod: http.MethodGet, Path: "/missing"},
+			expectedStatus:  http.StatusNotFound,
+			expectedBody:    "404 page not found\n",
+			expectedHeaders: map[string]string{"Content-Type": "text/plain; charset=utf-8", "X-Content-Type-Options": "nosniff"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := handler(context.Background(), tt.request)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			assert.Equal(t, tt.expectedBody, resp.Body)
+			assert.Equal(t, tt.expectedBase64, resp.IsBase64Encoded)
+			assert.Equal(t, tt.expectedHeaders, resp.Headers)
+			assert.Equal(t, tt.expectedMulti, resp.MultiValueHeaders)
+		})
+	}
+
+	t.Run("Invalid base64 body", func(t *testing.T) {
+		_, err := handler(context.Background(), events.APIGatewayProxyRequest{HTTPMethod: http.MethodPut, Path: "/products/1", Body: "%%%", IsBase64Encoded: true})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewAPIGatewayV2HTTPHandler(t *testing.T) {
+	handler := NewAPIGatewayV2HTTPHandler(newTestRouter())
+
+	event := events.APIGatewayV2HTTPRequest{
+		RouteKey:       "$default",
+		RawPath:        "/products/7",
+		RawQueryString: "tag=sale",
+		Headers:        map[string]string{"host": "api.example.com"},
+		Body:           "payload",
+	}
+	event.RequestContext.HTTP.Method = http.MethodPut
+	event.RequestContext.HTTP.SourceIP = "10.0.0.1"
+
+	resp, err := handler(context.Background(), event)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `{"id":"7","tag":"sale","body":"payload","host":"api.example.com"}`, resp.Body)
+	assert.Equal(t, []string{"a=1", "b=2"}, resp.Cookies)
+	assert.Equal(t, map[string]string{"Content-Type": "application/json"}, resp.Headers)
+}
+
+func TestNewALBTargetGroupHandler(t *testing.T) {
+	handler := NewALBTargetGroupHandler(newTestRouter())
+
+	resp, err := handler(context.Background(), events.ALBTargetGroupRequest{
+		HTTPMethod:        http.MethodGet,
+		Path:              "/image",
+		MultiValueHeaders: map[string][]string{"host": {"alb.example.com"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "200 OK", resp.StatusDescription)
+	assert.True(t, resp.IsBase64Encoded)
+	assert.Equal(t, []string{"image/png"}, resp.MultiValueHeaders["Content-Type"])
+}
@@ -0,0 +1,52 @@
+package proxy
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+func NewRequest(ctx context.Context, request lambda.Request) (*http.Request, error) {
+	body, err := request.DecodedBody()
+	if err != nil {
+		return nil, err
+	}
+
+	path := request.Path
+	if path == "" {
+		path = "/"
+	}
+	target := &url.URL{Path: path, RawQuery: url.Values(request.QueryParameters).Encode()}
+
+	req, err := http.NewRequestWithContext(ctx, request.Method, target.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range request.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	req.Host = req.Header.Get("Host")
+	req.URL.Host = req.Host
+	req.URL.Scheme = req.Header.Get("X-Forwarded-Proto")
+	req.RequestURI = target.RequestURI()
+	req.ContentLength = int64(len(body))
+	if len(body) > 0 && req.Header.Get("Content-Length") == "" {
+		req.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+	if request.SourceIP != "" {
+		req.RemoteAddr = net.JoinHostPort(request.SourceIP, "0")
+	}
+	if request.RequestID != "" && req.Header.Get("X-Request-Id") == "" {
+		req.Header.Set("X-Request-Id", request.RequestID)
+	}
+
+	return req, nil
+}
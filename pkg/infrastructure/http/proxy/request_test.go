@@ -0,0 +1,49 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+func TestNewRequest(t *testing.T) {
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+
+	req, err := NewRequest(ctx, lambda.Request{
+		Method:          http.MethodPost,
+		Path:            "/products",
+		QueryParameters: map[string][]string{"tag": {"a", "b"}},
+		Headers: http.Header{
+			"Host":              {"api.example.com"},
+			"X-Forwarded-Proto": {"https"},
+			"Accept":            {"text/html", "application/json"},
+		},
+		Body:      "payload",
+		RequestID: "req-1",
+		SourceIP:  "10.0.0.1",
+	})
+	assert.NoError(t, err)
+
+	body, _ := io.ReadAll(req.Body)
+	assert.Equal(t, "payload", string(body))
+	assert.Equal(t, int64(7), req.ContentLength)
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "/products?tag=a&tag=b", req.RequestURI)
+	assert.Equal(t, "https://api.example.com/products?tag=a&tag=b", req.URL.String())
+	assert.Equal(t, "api.example.com", req.Host)
+	assert.Equal(t, []string{"text/html", "application/json"}, req.Header.Values("Accept"))
+	assert.Equal(t, "req-1", req.Header.Get("X-Request-Id"))
+	assert.Equal(t, "10.0.0.1:0", req.RemoteAddr)
+	assert.Equal(t, "value", req.Context().Value(struct{}{}))
+}
+
+func TestNewRequest_DefaultsToRootPath(t *testing.T) {
+	req, err := NewRequest(context.Background(), lambda.Request{Method: http.MethodGet})
+	assert.NoError(t, err)
+	assert.Equal(t, "/", req.URL.Path)
+}
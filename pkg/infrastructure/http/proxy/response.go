@@ -0,0 +1,104 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/base64"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/http/lambda"
+)
+
+type ResponseWriter struct {
+	headers     http.Header
+	body        bytes.Buffer
+	statusCode  int
+	wroteHeader bool
+}
+
+func NewResponseWriter() *ResponseWriter {
+	return &ResponseWriter{headers: make(http.Header)}
+}
+
+func (w *ResponseWriter) Header() http.Header {
+	return w.headers
+}
+
+func (w *ResponseWriter) Write(data []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(data)
+}
+
+func (w *ResponseWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		return
+	}
+	w.statusCode = statusCode
+	w.wroteHeader = true
+}
+
+func (w *ResponseWriter) Flush() {}
+
+func (w *ResponseWriter) Response() lambda.Response {
+	statusCode := w.statusCode
+	if !w.wroteHeader {
+		statusCode = http.StatusOK
+	}
+
+	body := w.body.Bytes()
+	headers := w.headers.Clone()
+	if headers.Get("Content-Type") == "" && len(body) > 0 {
+		headers.Set("Content-Type", http.DetectContentType(body))
+	}
+
+	if isBinary(headers.Get("Content-Type"), body) {
+		return lambda.Response{
+			StatusCode:      statusCode,
+			Headers:         headers,
+			Body:            base64.StdEncoding.EncodeToString(body),
+			IsBase64Encoded: true,
+		}
+	}
+
+	return lambda.Response{
+		StatusCode: statusCode,
+		Headers:    headers,
+		Body:       string(body),
+	}
+}
+
+func isBinary(contentType string, body []byte) bool {
+	if len(body) == 0 {
+		return false
+	}
+	if !utf8.Valid(body) {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	if strings.HasPrefix(mediaType, "text/") {
+		return false
+	}
+	switch {
+	case mediaType == "application/json",
+		mediaType == "application/xml",
+		mediaType == "application/javascript",
+		mediaType == "application/x-www-form-urlencoded",
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return false
+	}
+	return strings.HasPrefix(mediaType, "image/") ||
+		strings.HasPrefix(mediaType, "audio/") ||
+		strings.HasPrefix(mediaType, "video/") ||
+		mediaType == "application/octet-stream" ||
+		mediaType == "application/pdf" ||
+		mediaType == "application/zip" ||
+		mediaType == "application/gzip"
+}
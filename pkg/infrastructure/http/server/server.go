@@ -0,0 +1,158 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+var ErrDrainTimeout = errors.New("shutdown drain deadline exceeded")
+
+// Server runs an http.Server until its context is cancelled or the process
+// receives SIGTERM or SIGINT, then stops accepting connections, lets
+// in-flight requests drain and closes the registered resources.
+type Server struct {
+	server          *http.Server
+	maxBodyBytes    int64
+	shutdownTimeout time.Duration
+	signals         []os.Signal
+	closers         []io.Closer
+	logger          application.Logger
+}
+
+type ServerOption func(*Server)
+
+func WithReadTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) { s.server.ReadTimeout = timeout }
+}
+
+func WithReadHeaderTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) { s.server.ReadHeaderTimeout = timeout }
+}
+
+func WithWriteTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) { s.server.WriteTimeout = timeout }
+}
+
+func WithIdleTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) { s.server.IdleTimeout = timeout }
+}
+
+func WithMaxHeaderBytes(size int) ServerOption {
+	return func(s *Server) { s.server.MaxHeaderBytes = size }
+}
+
+// WithMaxBodyBytes rejects request bodies larger than size; reads past the
+// limit fail and the handler sees an *http.MaxBytesError.
+func WithMaxBodyBytes(size int64) ServerOption {
+	return func(s *Server) { s.maxBodyBytes = size }
+}
+
+// WithShutdownTimeout bounds how long in-flight requests may drain before
+// their connections are closed.
+func WithShutdownTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) { s.shutdownTimeout = timeout }
+}
+
+// WithSignals replaces the signals that trigger a graceful shutdown.
+func WithSignals(signals ...os.Signal) ServerOption {
+	return func(s *Server) { s.signals = signals }
+}
+
+// WithCloser registers a resource, such as the application factory, to be
+// closed after the server has drained. Closers run in reverse order.
+func WithCloser(closer io.Closer) ServerOption {
+	return func(s *Server) { s.closers = append(s.closers, closer) }
+}
+
+func WithLogger(logger application.Logger) ServerOption {
+	return func(s *Server) { s.logger = logger }
+}
+
+func NewServer(addr string, handler http.Handler, opts ...ServerOption) *Server {
+	s := &Server{
+		server:          &http.Server{Addr: addr, Handler: handler},
+		shutdownTimeout: 20 * time.Second,
+		signals:         []os.Signal{syscall.SIGTERM, os.Interrupt},
+		logger:          application.NopLogger(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.maxBodyBytes > 0 {
+		s.server.Handler = http.MaxBytesHandler(handler, s.maxBodyBytes)
+	}
+	return s
+}
+
+// Run listens on the configured address and serves until shutdown. The
+// registered closers run even when the listener cannot be opened.
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return errors.Join(err, s.close())
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve is like Run on an existing listener.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, s.signals...)
+	defer stop()
+
+	served := make(chan error, 1)
+	go func() { served <- s.server.Serve(listener) }()
+	s.logger.Info("HTTP server listening", "addr", listener.Addr().String())
+
+	var err error
+	select {
+	case err = <-served:
+	case <-ctx.Done():
+		stop()
+		err = s.shutdown(ctx)
+		if serveErr := <-served; !errors.Is(serveErr, http.ErrServerClosed) {
+			err = errors.Join(err, serveErr)
+		}
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		err = nil
+	}
+	return errors.Join(err, s.close())
+}
+
+func (s *Server) shutdown(ctx context.Context) error {
+	s.logger.Info("Draining HTTP server", "timeout", s.shutdownTimeout.String())
+
+	drainCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.shutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(drainCtx); err != nil {
+		closeErr := s.server.Close()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("%w after %s", ErrDrainTimeout, s.shutdownTimeout)
+		}
+		s.logger.Error("HTTP server did not drain", err)
+		return errors.Join(err, closeErr)
+	}
+	s.logger.Info("HTTP server drained")
+	return nil
+}
+
+func (s *Server) close() error {
+	var errs []error
+	for i := len(s.closers) - 1; i >= 0; i-- {
+		if err := s.closers[i].Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
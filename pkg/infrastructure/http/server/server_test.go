@@ -0,0 +1,171 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type closerFunc func() error
+
+func (f closerFunc) Close() error { return f() }
+
+func listen(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	return listener
+}
+
+// blockingHandler signals when a request arrives and holds it until release
+// is closed.
+func blockingHandler(started chan<- struct{}, release <-chan struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+		w.Write([]byte("done"))
+	})
+}
+
+func TestServer_DrainsInFlightRequests(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	var order []string
+	s := NewServer("", blockingHandler(started, release),
+		WithShutdownTimeout(time.Second),
+		WithCloser(closerFunc(func() error { order = append(order, "first"); return nil })),
+		WithCloser(closerFunc(func() error { order = append(order, "second"); return nil })),
+	)
+
+	listener := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Serve(ctx, listener) }()
+
+	response := make(chan string, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String())
+		if err != nil {
+			response <- err.Error()
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		response <- string(body)
+	}()
+
+	<-started
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+
+	_, err := net.DialTimeout("tcp", listener.Addr().String(), 100*time.Millisecond)
+	assert.Error(t, err, "the listener should be closed while draining")
+
+	close(release)
+	assert.Equal(t, "done", <-response)
+	assert.NoError(t, <-stopped)
+	assert.Equal(t, []string{"second", "first"}, order)
+}
+
+func TestServer_DrainTimeout(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	closed := false
+	s := NewServer("", blockingHandler(started, release),
+		WithShutdownTimeout(50*time.Millisecond),
+		WithCloser(closerFunc(func() error { closed = true; return nil })),
+	)
+
+	listener := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Serve(ctx, listener) }()
+	go http.Get("http://" + listener.Addr().String())
+
+	<-started
+	cancel()
+	assert.ErrorIs(t, <-stopped, ErrDrainTimeout)
+	assert.True(t, closed)
+}
+
+func TestServer_ShutsDownOnSignal(t *testing.T) {
+	s := NewServer("", http.NotFoundHandler(), WithSignals(syscall.SIGUSR1))
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Serve(context.Background(), listen(t)) }()
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, syscall.Kill(syscall.Getpid(), syscall.SIGUSR1))
+	select {
+	case err := <-stopped:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("server did not stop on signal")
+	}
+}
+
+func TestServer_LimitsBodySize(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.ReadAll(r.Body); err != nil {
+			var maxBytesErr *http.MaxBytesError
+			assert.True(t, errors.As(err, &maxBytesErr))
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	s := NewServer("", handler, WithMaxBodyBytes(8))
+
+	listener := listen(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- s.Serve(ctx, listener) }()
+
+	for body, status := range map[string]int{"small": http.StatusNoContent, "way too large": http.StatusRequestEntityTooLarge} {
+		resp, err := http.Post("http://"+listener.Addr().String(), "text/plain", strings.NewReader(body))
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, status, resp.StatusCode, body)
+	}
+
+	cancel()
+	assert.NoError(t, <-stopped)
+}
+
+func TestServer_RunClosesResourcesWhenListenFails(t *testing.T) {
+	closed := false
+	s := NewServer("256.0.0.1:http", http.NotFoundHandler(), WithCloser(closerFunc(func() error {
+		closed = true
+		return errors.New("close failed")
+	})))
+
+	err := s.Run(context.Background())
+	assert.ErrorContains(t, err, "close failed")
+	assert.True(t, closed)
+}
+
+func TestNewServer_Options(t *testing.T) {
+	s := NewServer(":8080", http.NotFoundHandler(),
+		WithReadTimeout(time.Second),
+		WithReadHeaderTimeout(2*time.Second),
+		WithWriteTimeout(3*time.Second),
+		WithIdleTimeout(4*time.Second),
+		WithMaxHeaderBytes(512),
+		WithShutdownTimeout(5*time.Second),
+	)
+
+	assert.Equal(t, ":8080", s.server.Addr)
+	assert.Equal(t, time.Second, s.server.ReadTimeout)
+	assert.Equal(t, 2*time.Second, s.server.ReadHeaderTimeout)
+	assert.Equal(t, 3*time.Second, s.server.WriteTimeout)
+	assert.Equal(t, 4*time.Second, s.server.IdleTimeout)
+	assert.Equal(t, 512, s.server.MaxHeaderBytes)
+	assert.Equal(t, 5*time.Second, s.shutdownTimeout)
+}
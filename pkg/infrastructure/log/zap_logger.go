@@ -2,27 +2,100 @@ package log
 
 import (
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
 )
 
+const (
+	EncodingJSON    = "json"
+	EncodingConsole = "console"
+)
+
 type ZapLogger struct {
 	logger *zap.SugaredLogger
 }
 
-func NewZapLogger() (application.Logger, error) {
-	logger, err := zap.NewProduction()
+type ZapOption func(*zapOptions) error
+
+type zapOptions struct {
+	config zap.Config
+}
+
+// WithLevel sets the minimum level logged: debug, info, warn or error.
+func WithLevel(level string) ZapOption {
+	return func(o *zapOptions) error {
+		parsed, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return err
+		}
+		o.config.Level = zap.NewAtomicLevelAt(parsed)
+		return nil
+	}
+}
+
+// WithEncoding selects json or console output.
+func WithEncoding(encoding string) ZapOption {
+	return func(o *zapOptions) error {
+		o.config.Encoding = encoding
+		if encoding == EncodingConsole {
+			o.config.EncoderConfig = zap.NewDevelopmentEncoderConfig()
+		}
+		return nil
+	}
+}
+
+// WithSampling toggles zap's production sampling, which drops repeated
+// entries past the first hundred per second.
+func WithSampling(enabled bool) ZapOption {
+	return func(o *zapOptions) error {
+		if !enabled {
+			o.config.Sampling = nil
+		} else if o.config.Sampling == nil {
+			o.config.Sampling = zap.NewProductionConfig().Sampling
+		}
+		return nil
+	}
+}
+
+// NewZapLogger builds a logger from zap's production configuration: JSON at
+// info level with sampling, unless opts say otherwise.
+func NewZapLogger(opts ...ZapOption) (application.Logger, error) {
+	o := &zapOptions{config: zap.NewProductionConfig()}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+
+	logger, err := o.config.Build(zap.AddCallerSkip(1))
 	if err != nil {
 		return nil, err
 	}
-	sugar := logger.WithOptions(zap.AddCallerSkip(1)).Sugar()
-	return &ZapLogger{logger: sugar}, nil
+	return &ZapLogger{logger: logger.Sugar()}, nil
+}
+
+// NewZapLoggerFrom adapts an existing zap logger.
+func NewZapLoggerFrom(logger *zap.Logger) application.Logger {
+	return &ZapLogger{logger: logger.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+func (z *ZapLogger) Debug(msg string, keysAndValues ...interface{}) {
+	z.logger.Debugw(msg, keysAndValues...)
 }
 
 func (z *ZapLogger) Info(msg string, keysAndValues ...interface{}) {
 	z.logger.Infow(msg, keysAndValues...)
 }
 
+func (z *ZapLogger) Warn(msg string, keysAndValues ...interface{}) {
+	z.logger.Warnw(msg, keysAndValues...)
+}
+
 func (z *ZapLogger) Error(msg string, err error, keysAndValues ...interface{}) {
 	z.logger.Errorw(msg, append(keysAndValues, "error", err)...)
 }
+
+func (z *ZapLogger) With(keysAndValues ...interface{}) application.Logger {
+	return &ZapLogger{logger: z.logger.With(keysAndValues...)}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNewZapLogger(t *testing.T) {
@@ -14,19 +16,69 @@ func TestNewZapLogger(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestZapLogger_Info(t *testing.T) {
-	logger := &ZapLogger{
-		logger: zap.NewExample().Sugar(),
+func TestNewZapLogger_Options(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []ZapOption
+		wantDebug bool
+		wantInfo  bool
+		wantErr   bool
+	}{
+		{name: "defaults to info", wantInfo: true},
+		{name: "debug level", opts: []ZapOption{WithLevel("debug")}, wantDebug: true, wantInfo: true},
+		{name: "warn level", opts: []ZapOption{WithLevel("warn")}},
+		{name: "console encoding without sampling", opts: []ZapOption{WithEncoding(EncodingConsole), WithSampling(false)}, wantInfo: true},
+		{name: "sampling re-enabled", opts: []ZapOption{WithSampling(false), WithSampling(true)}, wantInfo: true},
+		{name: "unknown level", opts: []ZapOption{WithLevel("verbose")}, wantErr: true},
+		{name: "unknown encoding", opts: []ZapOption{WithEncoding("xml")}, wantErr: true},
 	}
 
-	logger.Info("This is an info message", "key1", "value1", "key2", "value2")
-}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, err := NewZapLogger(tt.opts...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
 
-func TestZapLogger_Error(t *testing.T) {
-	logger := &ZapLogger{
-		logger: zap.NewExample().Sugar(),
+			core := logger.(*ZapLogger).logger.Desugar().Core()
+			assert.Equal(t, tt.wantDebug, core.Enabled(zapcore.DebugLevel))
+			assert.Equal(t, tt.wantInfo, core.Enabled(zapcore.InfoLevel))
+			assert.True(t, core.Enabled(zapcore.ErrorLevel))
+		})
 	}
-	err := errors.New("some error")
+}
+
+func TestZapLogger_Levels(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewZapLoggerFrom(zap.New(core))
+
+	logger.Debug("debug message", "key", "value")
+	logger.Info("info message")
+	logger.Warn("warn message")
+	logger.Error("error message", errors.New("some error"))
+
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 4)
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "value", entries[0].ContextMap()["key"])
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+	assert.Equal(t, zapcore.WarnLevel, entries[2].Level)
+	assert.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+	assert.Equal(t, "some error", entries[3].ContextMap()["error"])
+}
+
+func TestZapLogger_With(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := NewZapLoggerFrom(zap.New(core))
+
+	child := logger.With("request_id", "abc")
+	child.Info("child message", "key", "value")
+	logger.Info("parent message")
 
-	logger.Error("This is an error message", err, "key1", "value1", "key2", "value2")
+	entries := logs.AllUntimed()
+	assert.Len(t, entries, 2)
+	assert.Equal(t, map[string]interface{}{"request_id": "abc", "key": "value"}, entries[0].ContextMap())
+	assert.Empty(t, entries[1].ContextMap())
 }
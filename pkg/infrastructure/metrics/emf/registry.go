@@ -0,0 +1,215 @@
+package emf
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// maxValues is the most values CloudWatch accepts for one metric in one
+// document; longer histograms are split across documents.
+const maxValues = 100
+
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+// Registry implements application.Metrics by aggregating observations in
+// memory and writing them, on Flush, as CloudWatch Embedded Metric Format
+// documents: one JSON line per metric and label set, with the labels as
+// dimensions. In Lambda, CloudWatch Logs extracts the metrics from stdout.
+type Registry struct {
+	w         io.Writer
+	namespace string
+	now       func() time.Time
+	families  map[string]*family
+	mu        sync.Mutex
+}
+
+type Option func(*Registry)
+
+// WithClock replaces time.Now as the source of document timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(r *Registry) {
+		r.now = now
+	}
+}
+
+type family struct {
+	name       string
+	kind       string
+	labelNames []string
+	series     map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	values      []float64
+}
+
+func NewRegistry(w io.Writer, namespace string, opts ...Option) *Registry {
+	r := &Registry{w: w, namespace: namespace, now: time.Now, families: make(map[string]*family)}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+func (r *Registry) Counter(name, help string, labelNames ...string) application.Counter {
+	return &instrument{registry: r, family: r.family(name, kindCounter, labelNames)}
+}
+
+// Histogram ignores buckets: CloudWatch computes percentiles from the raw
+// values.
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) application.Histogram {
+	return &instrument{registry: r, family: r.family(name, kindHistogram, labelNames)}
+}
+
+func (r *Registry) Gauge(name, help string, labelNames ...string) application.Gauge {
+	return &instrument{registry: r, family: r.family(name, kindGauge, labelNames)}
+}
+
+func (r *Registry) family(name, kind string, labelNames []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if f, exists := r.families[name]; exists {
+		if f.kind != kind || strings.Join(f.labelNames, ",") != strings.Join(labelNames, ",") {
+			panic(fmt.Sprintf("emf: %s is already registered as a %s with labels %v", name, f.kind, f.labelNames))
+		}
+		return f
+	}
+
+	f := &family{name: name, kind: kind, labelNames: labelNames, series: make(map[string]*series)}
+	r.families[name] = f
+	return f
+}
+
+// Flush writes the observations recorded since the last flush and resets
+// counters and histograms. Gauges keep their value and are written again.
+func (r *Registry) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := bufio.NewWriter(r.w)
+	encoder := json.NewEncoder(out)
+	timestamp := r.now().UnixMilli()
+	for _, name := range names {
+		f := r.families[name]
+		keys := make([]string, 0, len(f.series))
+		for key := range f.series {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s := f.series[key]
+			for _, value := range f.values(s) {
+				if err := encoder.Encode(r.document(f, s, timestamp, value)); err != nil {
+					return err
+				}
+			}
+			if f.kind == kindGauge {
+				continue
+			}
+			delete(f.series, key)
+		}
+	}
+	return out.Flush()
+}
+
+// values returns the metric value of each document written for s.
+func (f *family) values(s *series) []interface{} {
+	if f.kind != kindHistogram {
+		return []interface{}{s.value}
+	}
+	var chunks []interface{}
+	for start := 0; start < len(s.values); start += maxValues {
+		chunks = append(chunks, s.values[start:min(start+maxValues, len(s.values))])
+	}
+	return chunks
+}
+
+func (r *Registry) document(f *family, s *series, timestamp int64, value interface{}) map[string]interface{} {
+	document := map[string]interface{}{
+		"_aws": map[string]interface{}{
+			"Timestamp": timestamp,
+			"CloudWatchMetrics": []interface{}{map[string]interface{}{
+				"Namespace":  r.namespace,
+				"Dimensions": [][]string{append([]string{}, f.labelNames...)},
+				"Metrics":    []interface{}{map[string]string{"Name": f.name, "Unit": f.unit()}},
+			}},
+		},
+		f.name: value,
+	}
+	for i, labelName := range f.labelNames {
+		document[labelName] = s.labelValues[i]
+	}
+	return document
+}
+
+func (f *family) unit() string {
+	switch {
+	case f.kind == kindCounter:
+		return "Count"
+	case strings.HasSuffix(f.name, "_seconds"):
+		return "Seconds"
+	case strings.HasSuffix(f.name, "_bytes"):
+		return "Bytes"
+	}
+	return "None"
+}
+
+type instrument struct {
+	registry *Registry
+	family   *family
+}
+
+func (i *instrument) Inc(labelValues ...string) {
+	i.Add(1, labelValues...)
+}
+
+func (i *instrument) Add(delta float64, labelValues ...string) {
+	i.update(labelValues, func(s *series) { s.value += delta })
+}
+
+func (i *instrument) Set(value float64, labelValues ...string) {
+	i.update(labelValues, func(s *series) { s.value = value })
+}
+
+func (i *instrument) Observe(value float64, labelValues ...string) {
+	i.update(labelValues, func(s *series) { s.values = append(s.values, value) })
+}
+
+func (i *instrument) update(labelValues []string, fn func(s *series)) {
+	if len(labelValues) != len(i.family.labelNames) {
+		panic(fmt.Sprintf("emf: %s expects labels %v, got %d values", i.family.name, i.family.labelNames, len(labelValues)))
+	}
+
+	i.registry.mu.Lock()
+	defer i.registry.mu.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	s, exists := i.family.series[key]
+	if !exists {
+		s = &series{labelValues: append([]string{}, labelValues...)}
+		i.family.series[key] = s
+	}
+	fn(s)
+}
@@ -0,0 +1,104 @@
+package emf
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixedClock() time.Time {
+	return time.UnixMilli(1700000000000)
+}
+
+func decode(t *testing.T, out *bytes.Buffer) []map[string]interface{} {
+	var documents []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var document map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &document))
+		documents = append(documents, document)
+	}
+	return documents
+}
+
+func TestRegistry_Flush(t *testing.T) {
+	var out bytes.Buffer
+	registry := NewRegistry(&out, "Catalog", WithClock(fixedClock))
+
+	requests := registry.Counter("http_requests_total", "Requests.", "route", "status")
+	requests.Inc("/products", "200")
+	requests.Inc("/products", "200")
+	registry.Histogram("http_request_duration_seconds", "Latency.", nil, "route").Observe(0.25, "/products")
+
+	require.NoError(t, registry.Flush())
+
+	documents := decode(t, &out)
+	require.Len(t, documents, 2)
+
+	expected := `{
+		"_aws": {
+			"Timestamp": 1700000000000,
+			"CloudWatchMetrics": [{
+				"Namespace": "Catalog",
+				"Dimensions": [["route"]],
+				"Metrics": [{"Name": "http_request_duration_seconds", "Unit": "Seconds"}]
+			}]
+		},
+		"route": "/products",
+		"http_request_duration_seconds": [0.25]
+	}`
+	actual, _ := json.Marshal(documents[0])
+	assert.JSONEq(t, expected, string(actual))
+
+	assert.Equal(t, "/products", documents[1]["route"])
+	assert.Equal(t, "200", documents[1]["status"])
+	assert.Equal(t, float64(2), documents[1]["http_requests_total"])
+}
+
+func TestRegistry_FlushResetsCountersAndKeepsGauges(t *testing.T) {
+	var out bytes.Buffer
+	registry := NewRegistry(&out, "Catalog", WithClock(fixedClock))
+
+	registry.Counter("requests_total", "Requests.").Inc()
+	registry.Gauge("in_flight", "In flight.").Set(3)
+	require.NoError(t, registry.Flush())
+	require.Len(t, decode(t, &out), 2)
+
+	out.Reset()
+	require.NoError(t, registry.Flush())
+
+	documents := decode(t, &out)
+	require.Len(t, documents, 1)
+	assert.Equal(t, float64(3), documents[0]["in_flight"])
+}
+
+func TestRegistry_SplitsLongHistograms(t *testing.T) {
+	var out bytes.Buffer
+	registry := NewRegistry(&out, "Catalog")
+
+	latency := registry.Histogram("latency_seconds", "Latency.", nil)
+	for i := 0; i < 150; i++ {
+		latency.Observe(float64(i))
+	}
+	require.NoError(t, registry.Flush())
+
+	documents := decode(t, &out)
+	require.Len(t, documents, 2)
+	assert.Len(t, documents[0]["latency_seconds"], 100)
+	assert.Len(t, documents[1]["latency_seconds"], 50)
+}
+
+func TestRegistry_Panics(t *testing.T) {
+	registry := NewRegistry(&bytes.Buffer{}, "Catalog")
+	counter := registry.Counter("requests_total", "Requests.", "route")
+
+	assert.Panics(t, func() { registry.Histogram("requests_total", "Requests.", nil, "route") })
+	assert.Panics(t, func() { counter.Inc("/products", "200") })
+}
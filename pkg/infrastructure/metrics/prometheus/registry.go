@@ -0,0 +1,235 @@
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// ContentType is the media type of the text exposition format.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+const (
+	kindCounter   = "counter"
+	kindGauge     = "gauge"
+	kindHistogram = "histogram"
+)
+
+// Registry implements application.Metrics in memory and writes its
+// instruments in the Prometheus text exposition format.
+type Registry struct {
+	families map[string]*family
+	mu       sync.Mutex
+}
+
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	buckets    []float64
+	series     map[string]*series
+}
+
+type series struct {
+	labelValues []string
+	value       float64
+	counts      []uint64
+	count       uint64
+}
+
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+func (r *Registry) Counter(name, help string, labelNames ...string) application.Counter {
+	return &instrument{registry: r, family: r.family(name, help, kindCounter, nil, labelNames)}
+}
+
+func (r *Registry) Histogram(name, help string, buckets []float64, labelNames ...string) application.Histogram {
+	if buckets == nil {
+		buckets = application.DefaultLatencyBuckets
+	}
+	return &instrument{registry: r, family: r.family(name, help, kindHistogram, buckets, labelNames)}
+}
+
+func (r *Registry) Gauge(name, help string, labelNames ...string) application.Gauge {
+	return &instrument{registry: r, family: r.family(name, help, kindGauge, nil, labelNames)}
+}
+
+// family returns the family registered as name, creating it on first use.
+// Reusing a name for another kind of instrument or other labels is a
+// programming error and panics, as it would corrupt the exposition.
+func (r *Registry) family(name, help, kind string, buckets []float64, labelNames []string) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if f, exists := r.families[name]; exists {
+		if f.kind != kind || strings.Join(f.labelNames, ",") != strings.Join(labelNames, ",") {
+			panic(fmt.Sprintf("prometheus: %s is already registered as a %s with labels %v", name, f.kind, f.labelNames))
+		}
+		return f
+	}
+
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+	f := &family{name: name, help: help, kind: kind, labelNames: labelNames, buckets: buckets, series: make(map[string]*series)}
+	r.families[name] = f
+	return f
+}
+
+// Handler serves the registry to Prometheus scrapers.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.WriteTo(w)
+	})
+}
+
+// WriteTo writes every family sorted by name, and its series sorted by
+// label values.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	out := &countingWriter{w: bufio.NewWriter(w)}
+	for _, name := range names {
+		r.families[name].write(out)
+	}
+	if out.err == nil {
+		out.err = out.w.Flush()
+	}
+	return out.n, out.err
+}
+
+func (f *family) write(out *countingWriter) {
+	out.printf("# HELP %s %s\n", f.name, helpEscaper.Replace(f.help))
+	out.printf("# TYPE %s %s\n", f.name, f.kind)
+
+	keys := make([]string, 0, len(f.series))
+	for key := range f.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.series[key]
+		if f.kind != kindHistogram {
+			out.printf("%s%s %s\n", f.name, f.labels(s.labelValues, ""), formatFloat(s.value))
+			continue
+		}
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += s.counts[i]
+			out.printf("%s_bucket%s %d\n", f.name, f.labels(s.labelValues, formatFloat(bound)), cumulative)
+		}
+		out.printf("%s_bucket%s %d\n", f.name, f.labels(s.labelValues, "+Inf"), s.count)
+		out.printf("%s_sum%s %s\n", f.name, f.labels(s.labelValues, ""), formatFloat(s.value))
+		out.printf("%s_count%s %d\n", f.name, f.labels(s.labelValues, ""), s.count)
+	}
+}
+
+func (f *family) labels(values []string, le string) string {
+	pairs := make([]string, 0, len(values)+1)
+	for i, value := range values {
+		pairs = append(pairs, f.labelNames[i]+`="`+labelEscaper.Replace(value)+`"`)
+	}
+	if le != "" {
+		pairs = append(pairs, `le="`+le+`"`)
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+type instrument struct {
+	registry *Registry
+	family   *family
+}
+
+func (i *instrument) Inc(labelValues ...string) {
+	i.Add(1, labelValues...)
+}
+
+func (i *instrument) Add(delta float64, labelValues ...string) {
+	if i.family.kind == kindCounter && delta < 0 {
+		panic(fmt.Sprintf("prometheus: counter %s cannot decrease", i.family.name))
+	}
+	i.update(labelValues, func(s *series) { s.value += delta })
+}
+
+func (i *instrument) Set(value float64, labelValues ...string) {
+	i.update(labelValues, func(s *series) { s.value = value })
+}
+
+func (i *instrument) Observe(value float64, labelValues ...string) {
+	i.update(labelValues, func(s *series) {
+		if index := sort.SearchFloat64s(i.family.buckets, value); index < len(s.counts) {
+			s.counts[index]++
+		}
+		s.value += value
+		s.count++
+	})
+}
+
+func (i *instrument) update(labelValues []string, fn func(s *series)) {
+	if len(labelValues) != len(i.family.labelNames) {
+		panic(fmt.Sprintf("prometheus: %s expects labels %v, got %d values", i.family.name, i.family.labelNames, len(labelValues)))
+	}
+
+	i.registry.mu.Lock()
+	defer i.registry.mu.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+	s, exists := i.family.series[key]
+	if !exists {
+		s = &series{labelValues: append([]string{}, labelValues...), counts: make([]uint64, len(i.family.buckets))}
+		i.family.series[key] = s
+	}
+	fn(s)
+}
+
+type countingWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) printf(format string, args ...interface{}) {
+	if c.err != nil {
+		return
+	}
+	n, err := fmt.Fprintf(c.w, format, args...)
+	c.n += int64(n)
+	c.err = err
+}
+
+func formatFloat(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+)
@@ -0,0 +1,123 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_WriteTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		record   func(r *Registry)
+		expected string
+	}{
+		{
+			name: "Counter",
+			record: func(r *Registry) {
+				requests := r.Counter("requests_total", "Requests served.", "method", "status")
+				requests.Inc("GET", "200")
+				requests.Add(2, "GET", "200")
+				requests.Inc("POST", "201")
+			},
+			expected: `# HELP requests_total Requests served.
+# TYPE requests_total counter
+requests_total{method="GET",status="200"} 3
+requests_total{method="POST",status="201"} 1
+`,
+		},
+		{
+			name: "Gauge without labels",
+			record: func(r *Registry) {
+				inFlight := r.Gauge("in_flight", "Requests in flight.")
+				inFlight.Add(3)
+				inFlight.Add(-1)
+				r.Gauge("temperature", "Set directly.").Set(21.5)
+			},
+			expected: `# HELP in_flight Requests in flight.
+# TYPE in_flight gauge
+in_flight 2
+# HELP temperature Set directly.
+# TYPE temperature gauge
+temperature 21.5
+`,
+		},
+		{
+			name: "Histogram",
+			record: func(r *Registry) {
+				latency := r.Histogram("latency_seconds", "Latency.", []float64{0.5, 0.1}, "route")
+				latency.Observe(0.05, "/products")
+				latency.Observe(0.1, "/products")
+				latency.Observe(0.3, "/products")
+				latency.Observe(2, "/products")
+			},
+			expected: `# HELP latency_seconds Latency.
+# TYPE latency_seconds histogram
+latency_seconds_bucket{route="/products",le="0.1"} 2
+latency_seconds_bucket{route="/products",le="0.5"} 3
+latency_seconds_bucket{route="/products",le="+Inf"} 4
+latency_seconds_sum{route="/products"} 2.45
+latency_seconds_count{route="/products"} 4
+`,
+		},
+		{
+			name: "Escaping",
+			record: func(r *Registry) {
+				r.Counter("errors_total", "Errors\nby \\ message.", "message").Inc("say \"hi\"\n\\")
+			},
+			expected: `# HELP errors_total Errors\nby \\ message.
+# TYPE errors_total counter
+errors_total{message="say \"hi\"\n\\"} 1
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRegistry()
+			tt.record(registry)
+
+			var out strings.Builder
+			n, err := registry.WriteTo(&out)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, out.String())
+			assert.Equal(t, int64(out.Len()), n)
+		})
+	}
+}
+
+func TestRegistry_SameNameReturnsSameFamily(t *testing.T) {
+	registry := NewRegistry()
+	registry.Counter("requests_total", "Requests.", "method").Inc("GET")
+	registry.Counter("requests_total", "Requests.", "method").Inc("GET")
+
+	var out strings.Builder
+	registry.WriteTo(&out)
+	assert.Contains(t, out.String(), `requests_total{method="GET"} 2`)
+}
+
+func TestRegistry_Panics(t *testing.T) {
+	registry := NewRegistry()
+	counter := registry.Counter("requests_total", "Requests.", "method")
+
+	assert.Panics(t, func() { registry.Gauge("requests_total", "Requests.", "method") })
+	assert.Panics(t, func() { registry.Counter("requests_total", "Requests.", "route") })
+	assert.Panics(t, func() { counter.Inc() })
+	assert.Panics(t, func() { counter.Add(-1, "GET") })
+}
+
+func TestRegistry_Handler(t *testing.T) {
+	registry := NewRegistry()
+	registry.Counter("requests_total", "Requests.").Inc()
+
+	rec := httptest.NewRecorder()
+	registry.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "requests_total 1\n")
+}
@@ -0,0 +1,151 @@
+package resilience
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit open")
+
+type State int
+
+const (
+	StateClosed State = iota
+	StateHalfOpen
+	StateOpen
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateHalfOpen:
+		return "half_open"
+	case StateOpen:
+		return "open"
+	}
+	return fmt.Sprintf("State(%d)", int(s))
+}
+
+// Breaker is a consecutive-failure circuit breaker. After threshold
+// failures in a row it opens and rejects every call for the cooldown, then
+// lets a single probe through: the circuit closes if the probe succeeds and
+// opens again if it fails.
+type Breaker struct {
+	threshold     int
+	cooldown      time.Duration
+	now           func() time.Time
+	onStateChange func(from, to State)
+
+	mu       sync.Mutex
+	state    State
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+type BreakerOption func(*Breaker)
+
+// WithBreakerClock replaces time.Now, mostly for tests.
+func WithBreakerClock(now func() time.Time) BreakerOption {
+	return func(b *Breaker) {
+		b.now = now
+	}
+}
+
+// OnStateChange is called, with the breaker locked, on every transition.
+func OnStateChange(fn func(from, to State)) BreakerOption {
+	return func(b *Breaker) {
+		b.onStateChange = fn
+	}
+}
+
+func NewBreaker(threshold int, cooldown time.Duration, opts ...BreakerOption) *Breaker {
+	b := &Breaker{threshold: threshold, cooldown: cooldown, now: time.Now, onStateChange: func(State, State) {}}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+func (b *Breaker) State() State {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow reports with ErrCircuitOpen that a call must not be made. A nil
+// error obliges the caller to Record the outcome of the call.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.transition(StateHalfOpen)
+		b.probing = true
+		return nil
+	case StateHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Record reports the outcome of a call that Allow let through.
+func (b *Breaker) Record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+		if failed {
+			b.open()
+		} else {
+			b.failures = 0
+			b.transition(StateClosed)
+		}
+		return
+	}
+
+	if !failed {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == StateClosed && b.failures >= b.threshold {
+		b.open()
+	}
+}
+
+// Release ends a call that Allow let through without counting it either
+// way, such as one cancelled by its caller.
+func (b *Breaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateHalfOpen {
+		b.probing = false
+	}
+}
+
+func (b *Breaker) open() {
+	b.openedAt = b.now()
+	b.transition(StateOpen)
+}
+
+func (b *Breaker) transition(to State) {
+	if b.state == to {
+		return
+	}
+	from := b.state
+	b.state = to
+	b.onStateChange(from, to)
+}
@@ -0,0 +1,56 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	now := time.Unix(0, 0)
+	var transitions []string
+	breaker := NewBreaker(2, time.Minute,
+		WithBreakerClock(func() time.Time { return now }),
+		OnStateChange(func(from, to State) { transitions = append(transitions, from.String()+"->"+to.String()) }),
+	)
+
+	call := func(failed bool) error {
+		if err := breaker.Allow(); err != nil {
+			return err
+		}
+		breaker.Record(failed)
+		return nil
+	}
+
+	assert.NoError(t, call(true))
+	assert.NoError(t, call(false), "a success resets the failure count")
+	assert.NoError(t, call(true))
+	assert.Equal(t, StateClosed, breaker.State())
+	assert.NoError(t, call(true))
+	assert.Equal(t, StateOpen, breaker.State())
+
+	assert.ErrorIs(t, call(false), ErrCircuitOpen)
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Allow(), "the cooldown is over, so one probe goes through")
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	assert.ErrorIs(t, breaker.Allow(), ErrCircuitOpen, "only one probe at a time")
+	breaker.Record(true)
+	assert.Equal(t, StateOpen, breaker.State())
+
+	now = now.Add(time.Minute)
+	assert.NoError(t, breaker.Allow())
+	breaker.Release()
+	assert.Equal(t, StateHalfOpen, breaker.State())
+	assert.NoError(t, call(false))
+	assert.Equal(t, StateClosed, breaker.State())
+
+	assert.Equal(t, []string{
+		"closed->open",
+		"open->half_open",
+		"half_open->open",
+		"open->half_open",
+		"half_open->closed",
+	}, transitions)
+}
@@ -0,0 +1,213 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+var ErrTimeout = errors.New("operation timed out")
+
+// Policy runs operations with a timeout per attempt, retries the attempts
+// that failed with a transient error after a jittered exponential backoff,
+// and, given a Breaker, stops calling a backend that keeps failing.
+// Timeouts are transient; which other errors are is up to the classifier
+// given with WithTransient.
+type Policy struct {
+	attempts       int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	timeout        time.Duration
+	timeouts       map[string]time.Duration
+	breaker        *Breaker
+	transient      func(err error) bool
+	random         func() float64
+	sleep          func(ctx context.Context, d time.Duration) error
+
+	onRetry    func(operation string, err error)
+	onTimeout  func(operation string)
+	onRejected func(operation string)
+}
+
+type Option func(*Policy)
+
+// WithMaxAttempts bounds the attempts of an operation, the first included;
+// 1 disables retries.
+func WithMaxAttempts(attempts int) Option {
+	return func(p *Policy) {
+		p.attempts = attempts
+	}
+}
+
+// WithBackoff sets the delay before the first retry, doubled on every
+// retry up to max. Each delay is drawn at random between zero and that
+// bound, so clients that failed together do not retry together.
+func WithBackoff(initial, max time.Duration) Option {
+	return func(p *Policy) {
+		p.initialBackoff = initial
+		p.maxBackoff = max
+	}
+}
+
+// WithTimeout bounds every attempt of the operations without a timeout of
+// their own; 0 leaves them unbounded.
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Policy) {
+		p.timeout = timeout
+	}
+}
+
+// WithOperationTimeout bounds every attempt of operation.
+func WithOperationTimeout(operation string, timeout time.Duration) Option {
+	return func(p *Policy) {
+		p.timeouts[operation] = timeout
+	}
+}
+
+func WithBreaker(breaker *Breaker) Option {
+	return func(p *Policy) {
+		p.breaker = breaker
+	}
+}
+
+// WithTransient sets the classifier of the errors worth retrying.
+func WithTransient(transient func(err error) bool) Option {
+	return func(p *Policy) {
+		p.transient = transient
+	}
+}
+
+// WithRandom replaces the source of the backoff jitter, mostly for tests.
+func WithRandom(random func() float64) Option {
+	return func(p *Policy) {
+		p.random = random
+	}
+}
+
+// OnRetry is called before every retry with the error that caused it.
+func OnRetry(fn func(operation string, err error)) Option {
+	return func(p *Policy) {
+		p.onRetry = fn
+	}
+}
+
+// OnTimeout is called for every attempt that timed out.
+func OnTimeout(fn func(operation string)) Option {
+	return func(p *Policy) {
+		p.onTimeout = fn
+	}
+}
+
+// OnRejected is called for every call the open circuit rejected.
+func OnRejected(fn func(operation string)) Option {
+	return func(p *Policy) {
+		p.onRejected = fn
+	}
+}
+
+func NewPolicy(opts ...Option) *Policy {
+	p := &Policy{
+		attempts:       3,
+		initialBackoff: 50 * time.Millisecond,
+		maxBackoff:     time.Second,
+		timeouts:       make(map[string]time.Duration),
+		transient:      func(error) bool { return false },
+		random:         rand.Float64,
+		sleep:          sleep,
+		onRetry:        func(string, error) {},
+		onTimeout:      func(string) {},
+		onRejected:     func(string) {},
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// IsTransient reports whether err is worth retrying.
+func (p *Policy) IsTransient(err error) bool {
+	return err != nil && (errors.Is(err, ErrTimeout) || p.transient(err))
+}
+
+// Do runs fn under the policy and returns the error of its last attempt,
+// or ErrCircuitOpen when the breaker rejected the call. It gives up early
+// when ctx is done or its deadline does not leave room for the next retry.
+func (p *Policy) Do(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		if p.breaker != nil {
+			if err := p.breaker.Allow(); err != nil {
+				p.onRejected(operation)
+				return err
+			}
+		}
+
+		err := p.attempt(ctx, operation, fn)
+		transient := p.IsTransient(err)
+		if p.breaker != nil {
+			if ctx.Err() != nil {
+				p.breaker.Release()
+			} else {
+				p.breaker.Record(transient)
+			}
+		}
+		if !transient || attempt >= p.attempts || ctx.Err() != nil {
+			return err
+		}
+
+		delay := p.backoff(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		p.onRetry(operation, err)
+		if err := p.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func (p *Policy) attempt(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	timeout, ok := p.timeouts[operation]
+	if !ok {
+		timeout = p.timeout
+	}
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	err := fn(attemptCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(attemptCtx.Err(), context.DeadlineExceeded) {
+		p.onTimeout(operation)
+		return fmt.Errorf("%w: %s after %s: %w", ErrTimeout, operation, timeout, err)
+	}
+	return err
+}
+
+// backoff returns the delay before retrying after attempt failed.
+func (p *Policy) backoff(attempt int) time.Duration {
+	bound := p.initialBackoff
+	for i := 1; i < attempt && bound < p.maxBackoff; i++ {
+		bound *= 2
+	}
+	if bound > p.maxBackoff {
+		bound = p.maxBackoff
+	}
+	return time.Duration(p.random() * float64(bound))
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,150 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errThrottled = errors.New("throttled")
+
+func isThrottled(err error) bool {
+	return errors.Is(err, errThrottled)
+}
+
+func TestPolicy_Do(t *testing.T) {
+	errPermanent := errors.New("permanent")
+
+	tests := []struct {
+		name      string
+		results   []error
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "Success", results: []error{nil}, wantCalls: 1},
+		{name: "Retries transient errors", results: []error{errThrottled, errThrottled, nil}, wantCalls: 3},
+		{name: "Gives up after the last attempt", results: []error{errThrottled, errThrottled, errThrottled}, wantErr: errThrottled, wantCalls: 3},
+		{name: "Does not retry other errors", results: []error{errPermanent}, wantErr: errPermanent, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var retries []string
+			policy := NewPolicy(
+				WithTransient(isThrottled),
+				WithBackoff(0, 0),
+				OnRetry(func(operation string, err error) { retries = append(retries, operation) }),
+			)
+
+			calls := 0
+			err := policy.Do(context.Background(), "find", func(ctx context.Context) error {
+				calls++
+				return tt.results[calls-1]
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, calls)
+			assert.Len(t, retries, tt.wantCalls-1)
+		})
+	}
+}
+
+func TestPolicy_Timeouts(t *testing.T) {
+	var timeouts []string
+	policy := NewPolicy(
+		WithMaxAttempts(2),
+		WithBackoff(0, 0),
+		WithTimeout(time.Millisecond),
+		WithOperationTimeout("find_all", time.Hour),
+		OnTimeout(func(operation string) { timeouts = append(timeouts, operation) }),
+	)
+
+	calls := 0
+	err := policy.Do(context.Background(), "find", func(ctx context.Context) error {
+		calls++
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, policy.IsTransient(err))
+	assert.Equal(t, 2, calls, "timeouts are retried")
+	assert.Equal(t, []string{"find", "find"}, timeouts)
+
+	err = policy.Do(context.Background(), "find_all", func(ctx context.Context) error {
+		deadline, ok := ctx.Deadline()
+		assert.True(t, ok)
+		assert.Greater(t, time.Until(deadline), time.Minute)
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestPolicy_StopsWhenTheCallerIsDone(t *testing.T) {
+	policy := NewPolicy(WithTransient(isThrottled), WithBackoff(time.Hour, time.Hour), WithRandom(func() float64 { return 1 }))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	calls := 0
+	err := policy.Do(ctx, "find", func(ctx context.Context) error {
+		calls++
+		return errThrottled
+	})
+	assert.ErrorIs(t, err, errThrottled)
+	assert.Equal(t, 1, calls, "the backoff does not fit before the deadline")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = NewPolicy(WithTransient(isThrottled)).Do(ctx, "find", func(ctx context.Context) error {
+		calls++
+		return errThrottled
+	})
+	assert.ErrorIs(t, err, errThrottled)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPolicy_Breaker(t *testing.T) {
+	breaker := NewBreaker(2, time.Minute)
+	rejected := 0
+	policy := NewPolicy(
+		WithTransient(isThrottled),
+		WithMaxAttempts(1),
+		WithBreaker(breaker),
+		OnRejected(func(string) { rejected++ }),
+	)
+	fail := func(ctx context.Context) error { return errThrottled }
+
+	assert.ErrorIs(t, policy.Do(context.Background(), "find", fail), errThrottled)
+	errInvalid := errors.New("invalid request")
+	assert.ErrorIs(t, policy.Do(context.Background(), "find", func(ctx context.Context) error { return errInvalid }), errInvalid)
+	assert.ErrorIs(t, policy.Do(context.Background(), "find", fail), errThrottled)
+	assert.Equal(t, StateClosed, breaker.State(), "an answer other than a transient error shows the backend is up")
+	assert.ErrorIs(t, policy.Do(context.Background(), "find", fail), errThrottled)
+	assert.Equal(t, StateOpen, breaker.State())
+
+	called := false
+	err := policy.Do(context.Background(), "save", func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.False(t, called)
+	assert.Equal(t, 1, rejected)
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	policy := NewPolicy(WithBackoff(100*time.Millisecond, time.Second), WithRandom(func() float64 { return 1 }))
+
+	var delays []time.Duration
+	for attempt := 1; attempt <= 6; attempt++ {
+		delays = append(delays, policy.backoff(attempt))
+	}
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond, 800 * time.Millisecond, time.Second, time.Second}, delays)
+
+	policy = NewPolicy(WithBackoff(100*time.Millisecond, time.Second), WithRandom(func() float64 { return 0.5 }))
+	assert.Equal(t, 200*time.Millisecond, policy.backoff(3), "jitter draws below the bound")
+}
@@ -0,0 +1,141 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	defaultQueueSize    = 2048
+	defaultBatchSize    = 512
+	defaultBatchTimeout = 5 * time.Second
+	closeTimeout        = 5 * time.Second
+)
+
+// batcher exports the traces handed to it from a background goroutine, in
+// batches of up to batchSize spans or every batchTimeout, so ending a
+// request span never waits for the tracing backend. Traces that do not fit
+// in the queue are dropped and reported with the next export.
+type batcher struct {
+	export       func([]SpanData)
+	report       func(dropped int64)
+	queue        chan []SpanData
+	flushes      chan chan struct{}
+	stop         chan struct{}
+	stopped      chan struct{}
+	stopOnce     sync.Once
+	dropped      atomic.Int64
+	batchSize    int
+	batchTimeout time.Duration
+}
+
+func newBatcher(export func([]SpanData), report func(dropped int64), queueSize, batchSize int, batchTimeout time.Duration) *batcher {
+	b := &batcher{
+		export:       export,
+		report:       report,
+		queue:        make(chan []SpanData, queueSize),
+		flushes:      make(chan chan struct{}),
+		stop:         make(chan struct{}),
+		stopped:      make(chan struct{}),
+		batchSize:    batchSize,
+		batchTimeout: batchTimeout,
+	}
+	go b.run()
+	return b
+}
+
+func (b *batcher) enqueue(spans []SpanData) {
+	select {
+	case <-b.stop:
+		b.dropped.Add(int64(len(spans)))
+		return
+	default:
+	}
+	select {
+	case b.queue <- spans:
+	default:
+		b.dropped.Add(int64(len(spans)))
+	}
+}
+
+func (b *batcher) run() {
+	defer close(b.stopped)
+	ticker := time.NewTicker(b.batchTimeout)
+	defer ticker.Stop()
+
+	var batch []SpanData
+	flush := func() {
+		if len(batch) > 0 {
+			b.export(batch)
+			batch = nil
+		}
+		if dropped := b.dropped.Swap(0); dropped > 0 {
+			b.report(dropped)
+		}
+	}
+	add := func(spans []SpanData) {
+		batch = append(batch, spans...)
+		if len(batch) >= b.batchSize {
+			flush()
+		}
+	}
+	drain := func() {
+		for {
+			select {
+			case spans := <-b.queue:
+				add(spans)
+			default:
+				flush()
+				return
+			}
+		}
+	}
+
+	for {
+		select {
+		case spans := <-b.queue:
+			add(spans)
+		case <-ticker.C:
+			flush()
+		case done := <-b.flushes:
+			drain()
+			close(done)
+		case <-b.stop:
+			drain()
+			return
+		}
+	}
+}
+
+// forceFlush exports the traces queued so far, waiting for the export until
+// ctx is done.
+func (b *batcher) forceFlush(ctx context.Context) error {
+	done := make(chan struct{})
+	select {
+	case b.flushes <- done:
+	case <-b.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// shutdown exports the queued traces and stops the goroutine; the traces
+// ended afterwards are dropped.
+func (b *batcher) shutdown(ctx context.Context) error {
+	b.stopOnce.Do(func() { close(b.stop) })
+	select {
+	case <-b.stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
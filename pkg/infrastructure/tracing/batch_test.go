@@ -0,0 +1,107 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingExporter holds every export until release is closed.
+type blockingExporter struct {
+	recordingExporter
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingExporter() *blockingExporter {
+	return &blockingExporter{started: make(chan struct{}, 10), release: make(chan struct{})}
+}
+
+func (e *blockingExporter) Export(ctx context.Context, spans []SpanData) error {
+	e.started <- struct{}{}
+	<-e.release
+	return e.recordingExporter.Export(ctx, spans)
+}
+
+func (e *blockingExporter) names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var names []string
+	for _, export := range e.exports {
+		for _, span := range export {
+			names = append(names, span.Name)
+		}
+	}
+	return names
+}
+
+func endTrace(tracer *Tracer, name string) {
+	_, span := tracer.Start(context.Background(), name)
+	span.End()
+}
+
+func TestTracer_ExportsInTheBackground(t *testing.T) {
+	exporter := newBlockingExporter()
+	tracer := NewTracer(exporter, WithBatch(1, time.Hour))
+	defer tracer.Shutdown(context.Background())
+
+	endTrace(tracer, "root")
+	<-exporter.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, tracer.ForceFlush(ctx), context.DeadlineExceeded, "the export is still running")
+
+	close(exporter.release)
+	require.NoError(t, tracer.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"root"}, exporter.names())
+}
+
+func TestTracer_BatchesTraces(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, WithBatch(2, time.Hour))
+	defer tracer.Shutdown(context.Background())
+
+	endTrace(tracer, "first")
+	endTrace(tracer, "second")
+	endTrace(tracer, "third")
+	require.NoError(t, tracer.ForceFlush(context.Background()))
+
+	exporter.mu.Lock()
+	defer exporter.mu.Unlock()
+	require.Len(t, exporter.exports, 2)
+	assert.Len(t, exporter.exports[0], 2)
+	assert.Equal(t, "third", exporter.exports[1][0].Name)
+}
+
+func TestTracer_DropsTracesWhenTheQueueIsFull(t *testing.T) {
+	exporter := newBlockingExporter()
+	tracer := NewTracer(exporter, WithQueueSize(1), WithBatch(1, time.Hour))
+	defer tracer.Shutdown(context.Background())
+
+	endTrace(tracer, "exporting")
+	<-exporter.started
+	endTrace(tracer, "queued")
+	endTrace(tracer, "dropped")
+
+	close(exporter.release)
+	require.NoError(t, tracer.ForceFlush(context.Background()))
+	assert.Equal(t, []string{"exporting", "queued"}, exporter.names())
+}
+
+func TestTracer_Shutdown(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, WithBatch(512, time.Hour))
+
+	endTrace(tracer, "before")
+	require.NoError(t, tracer.Close())
+	endTrace(tracer, "after")
+
+	assert.NoError(t, tracer.ForceFlush(context.Background()))
+	assert.NoError(t, tracer.Shutdown(context.Background()))
+	require.Len(t, exporter.exports, 1)
+	assert.Equal(t, "before", exporter.exports[0][0].Name)
+}
@@ -0,0 +1,99 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSpans(t *testing.T) []SpanData {
+	parent, err := ParseTraceparent(validTraceparent)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	return []SpanData{{
+		SpanContext:   SpanContext{TraceID: parent.TraceID, SpanID: SpanID{1, 2, 3, 4, 5, 6, 7, 8}, Sampled: true},
+		ParentSpanID:  parent.SpanID,
+		Name:          "GET /products/{id}",
+		Kind:          SpanKindServer,
+		Service:       "catalog",
+		Start:         start,
+		End:           start.Add(1500 * time.Microsecond),
+		Attributes:    []Attribute{{Key: "http.route", Value: "/products/{id}"}, {Key: "http.response.status_code", Value: int64(500)}},
+		Status:        StatusError,
+		StatusMessage: "boom",
+	}}
+}
+
+func TestStdoutExporter_Export(t *testing.T) {
+	var out bytes.Buffer
+
+	require.NoError(t, NewStdoutExporter(&out).Export(context.Background(), testSpans(t)))
+
+	assert.JSONEq(t, `{
+		"trace_id": "4bf92f3577b34da6a3ce929d0e0e4736",
+		"span_id": "0102030405060708",
+		"parent_span_id": "00f067aa0ba902b7",
+		"name": "GET /products/{id}",
+		"kind": "server",
+		"service": "catalog",
+		"start": "2024-01-02T03:04:05Z",
+		"duration_ms": 1.5,
+		"attributes": {"http.route": "/products/{id}", "http.response.status_code": 500},
+		"error": "boom"
+	}`, out.String())
+}
+
+func TestOTLPExporter_Export(t *testing.T) {
+	var received []byte
+	var headers http.Header
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer collector.Close()
+
+	exporter := NewOTLPExporter(collector.URL+"/v1/traces", WithHeaders(map[string]string{"X-Api-Key": "secret"}))
+	require.NoError(t, exporter.Export(context.Background(), testSpans(t)))
+
+	assert.Equal(t, "application/json", headers.Get("Content-Type"))
+	assert.Equal(t, "secret", headers.Get("X-Api-Key"))
+	assert.JSONEq(t, `{"resourceSpans": [{
+		"resource": {"attributes": [{"key": "service.name", "value": {"stringValue": "catalog"}}]},
+		"scopeSpans": [{
+			"scope": {"name": "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"},
+			"spans": [{
+				"traceId": "4bf92f3577b34da6a3ce929d0e0e4736",
+				"spanId": "0102030405060708",
+				"parentSpanId": "00f067aa0ba902b7",
+				"name": "GET /products/{id}",
+				"kind": 2,
+				"startTimeUnixNano": "1704164645000000000",
+				"endTimeUnixNano": "1704164645001500000",
+				"attributes": [
+					{"key": "http.route", "value": {"stringValue": "/products/{id}"}},
+					{"key": "http.response.status_code", "value": {"intValue": "500"}}
+				],
+				"status": {"code": 2, "message": "boom"}
+			}]
+		}]
+	}]}`, string(received))
+}
+
+func TestOTLPExporter_Rejected(t *testing.T) {
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer collector.Close()
+
+	err := NewOTLPExporter(collector.URL).Export(context.Background(), testSpans(t))
+
+	assert.ErrorIs(t, err, ErrExportRejected)
+}
@@ -0,0 +1,30 @@
+package tracing
+
+import (
+	"context"
+	"sync"
+)
+
+// InMemoryExporter keeps the exported spans, for tests to assert on.
+type InMemoryExporter struct {
+	spans []SpanData
+	mu    sync.Mutex
+}
+
+func NewInMemoryExporter() *InMemoryExporter {
+	return &InMemoryExporter{}
+}
+
+func (e *InMemoryExporter) Export(ctx context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, spans...)
+	return nil
+}
+
+// Spans returns the exported spans in the order they ended.
+func (e *InMemoryExporter) Spans() []SpanData {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]SpanData{}, e.spans...)
+}
@@ -0,0 +1,188 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var ErrExportRejected = errors.New("OTLP collector rejected the spans")
+
+// OTLPExporter sends spans to an OpenTelemetry collector with the OTLP/HTTP
+// protocol, JSON encoded, which every collector accepts on port 4318.
+type OTLPExporter struct {
+	endpoint string
+	client   *http.Client
+	headers  map[string]string
+}
+
+type OTLPOption func(*OTLPExporter)
+
+// WithHTTPClient replaces the default client, which gives up after five
+// seconds.
+func WithHTTPClient(client *http.Client) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.client = client
+	}
+}
+
+// WithHeaders adds headers to every export, such as the API key of a
+// hosted backend.
+func WithHeaders(headers map[string]string) OTLPOption {
+	return func(e *OTLPExporter) {
+		e.headers = headers
+	}
+}
+
+// NewOTLPExporter exports to the traces URL of a collector, such as
+// http://localhost:4318/v1/traces.
+func NewOTLPExporter(endpoint string, opts ...OTLPOption) *OTLPExporter {
+	e := &OTLPExporter{endpoint: endpoint, client: &http.Client{Timeout: 5 * time.Second}}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+func (e *OTLPExporter) Export(ctx context.Context, spans []SpanData) error {
+	if len(spans) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(otlpRequest(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range e.headers {
+		req.Header.Set(name, value)
+	}
+
+	res, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode/100 != 2 {
+		return fmt.Errorf("%w: %s", ErrExportRejected, res.Status)
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP JSON encoding of an
+// ExportTraceServiceRequest the tracer fills in.
+
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+// scopeName is the instrumentation scope of every span.
+const scopeName = "github.com/mateusmacedo/go-sls-marketplace/pkg/infrastructure/tracing"
+
+func otlpRequest(spans []SpanData) otlpTraceRequest {
+	var request otlpTraceRequest
+	byService := map[string]int{}
+	for _, span := range spans {
+		index, exists := byService[span.Service]
+		if !exists {
+			index = len(request.ResourceSpans)
+			byService[span.Service] = index
+			request.ResourceSpans = append(request.ResourceSpans, otlpResourceSpans{
+				Resource:   otlpResource{Attributes: []otlpKeyValue{otlpAttribute("service.name", span.Service)}},
+				ScopeSpans: []otlpScopeSpans{{Scope: otlpScope{Name: scopeName}}},
+			})
+		}
+		scope := &request.ResourceSpans[index].ScopeSpans[0]
+		scope.Spans = append(scope.Spans, otlpSpanFrom(span))
+	}
+	return request
+}
+
+func otlpSpanFrom(span SpanData) otlpSpan {
+	converted := otlpSpan{
+		TraceID:           span.TraceID.String(),
+		SpanID:            span.SpanID.String(),
+		Name:              span.Name,
+		Kind:              span.Kind,
+		StartTimeUnixNano: strconv.FormatInt(span.Start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.End.UnixNano(), 10),
+		Status:            otlpStatus{Code: span.Status, Message: span.StatusMessage},
+	}
+	if span.ParentSpanID.IsValid() {
+		converted.ParentSpanID = span.ParentSpanID.String()
+	}
+	for _, attribute := range span.Attributes {
+		converted.Attributes = append(converted.Attributes, otlpAttribute(attribute.Key, attribute.Value))
+	}
+	return converted
+}
+
+// otlpAttribute encodes value as an AnyValue. Integers are strings, as
+// the JSON encoding of 64-bit integers requires.
+func otlpAttribute(key string, value interface{}) otlpKeyValue {
+	var encoded map[string]interface{}
+	switch v := value.(type) {
+	case bool:
+		encoded = map[string]interface{}{"boolValue": v}
+	case int64:
+		encoded = map[string]interface{}{"intValue": strconv.FormatInt(v, 10)}
+	case float64:
+		encoded = map[string]interface{}{"doubleValue": v}
+	default:
+		encoded = map[string]interface{}{"stringValue": fmt.Sprint(v)}
+	}
+	return otlpKeyValue{Key: key, Value: encoded}
+}
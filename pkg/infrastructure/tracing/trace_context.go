@@ -0,0 +1,132 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TraceparentHeader is the W3C Trace Context header that carries the trace
+// and the parent span of a request across services.
+const TraceparentHeader = "traceparent"
+
+var ErrInvalidTraceparent = errors.New("invalid traceparent")
+
+type TraceID [16]byte
+
+func (id TraceID) IsValid() bool {
+	return id != TraceID{}
+}
+
+func (id TraceID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+type SpanID [8]byte
+
+func (id SpanID) IsValid() bool {
+	return id != SpanID{}
+}
+
+func (id SpanID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+// SpanContext identifies a span within its trace. Remote is set on the
+// contexts extracted from an incoming request.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+	Remote  bool
+}
+
+func (sc SpanContext) IsValid() bool {
+	return sc.TraceID.IsValid() && sc.SpanID.IsValid()
+}
+
+// Traceparent formats sc as the value of the traceparent header.
+func (sc SpanContext) Traceparent() string {
+	flags := "00"
+	if sc.Sampled {
+		flags = "01"
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-" + flags
+}
+
+// ParseTraceparent parses a version 00 traceparent header. Later versions
+// are parsed by their first four fields, as the specification requires.
+func ParseTraceparent(value string) (SpanContext, error) {
+	parts := strings.Split(strings.TrimSpace(value), "-")
+	if len(parts) < 4 || len(parts[0]) != 2 || parts[0] == "ff" || (parts[0] == "00" && len(parts) != 4) {
+		return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, value)
+	}
+
+	var sc SpanContext
+	var flags [1]byte
+	for _, field := range []struct {
+		value string
+		into  []byte
+	}{
+		{parts[0], make([]byte, 1)},
+		{parts[1], sc.TraceID[:]},
+		{parts[2], sc.SpanID[:]},
+		{parts[3], flags[:]},
+	} {
+		if len(field.value) != hex.EncodedLen(len(field.into)) || strings.ToLower(field.value) != field.value {
+			return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, value)
+		}
+		if _, err := hex.Decode(field.into, []byte(field.value)); err != nil {
+			return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, value)
+		}
+	}
+	if !sc.IsValid() {
+		return SpanContext{}, fmt.Errorf("%w: %q", ErrInvalidTraceparent, value)
+	}
+
+	sc.Sampled = flags[0]&0x01 == 0x01
+	sc.Remote = true
+	return sc, nil
+}
+
+type remoteKey struct{}
+
+type spanKey struct{}
+
+// Extract returns ctx carrying the remote parent described by traceparent,
+// so that the next span started is part of the caller's trace. An empty or
+// malformed traceparent leaves ctx as is and the next span starts a trace.
+func Extract(ctx context.Context, traceparent string) context.Context {
+	if traceparent == "" {
+		return ctx
+	}
+	sc, err := ParseTraceparent(traceparent)
+	if err != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, remoteKey{}, sc)
+}
+
+// SpanContextFromContext returns the span context of the active span in
+// ctx or, when no span was started yet, the remote parent extracted into it.
+func SpanContextFromContext(ctx context.Context) SpanContext {
+	if s, ok := ctx.Value(spanKey{}).(*span); ok {
+		return s.data.SpanContext
+	}
+	if sc, ok := ctx.Value(remoteKey{}).(SpanContext); ok {
+		return sc
+	}
+	return SpanContext{}
+}
+
+// Traceparent returns the traceparent header that propagates the active
+// span in ctx to a downstream call, or "" without one.
+func Traceparent(ctx context.Context) string {
+	sc := SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return ""
+	}
+	return sc.Traceparent()
+}
@@ -0,0 +1,65 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestParseTraceparent(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expectedErr error
+		sampled     bool
+	}{
+		{name: "Sampled", value: validTraceparent, sampled: true},
+		{name: "Not sampled", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"},
+		{name: "Future version with more fields", value: "cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-what-the-future-holds", sampled: true},
+		{name: "Empty", value: "", expectedErr: ErrInvalidTraceparent},
+		{name: "Version ff", value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", expectedErr: ErrInvalidTraceparent},
+		{name: "Version 00 with more fields", value: validTraceparent + "-extra", expectedErr: ErrInvalidTraceparent},
+		{name: "Short trace ID", value: "00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01", expectedErr: ErrInvalidTraceparent},
+		{name: "Uppercase", value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01", expectedErr: ErrInvalidTraceparent},
+		{name: "Not hex", value: "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01", expectedErr: ErrInvalidTraceparent},
+		{name: "Zero trace ID", value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", expectedErr: ErrInvalidTraceparent},
+		{name: "Zero span ID", value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", expectedErr: ErrInvalidTraceparent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc, err := ParseTraceparent(tt.value)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID.String())
+			assert.Equal(t, "00f067aa0ba902b7", sc.SpanID.String())
+			assert.Equal(t, tt.sampled, sc.Sampled)
+			assert.True(t, sc.Remote)
+		})
+	}
+}
+
+func TestSpanContext_Traceparent(t *testing.T) {
+	sc, err := ParseTraceparent(validTraceparent)
+	require.NoError(t, err)
+	assert.Equal(t, validTraceparent, sc.Traceparent())
+
+	sc.Sampled = false
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", sc.Traceparent())
+}
+
+func TestExtract(t *testing.T) {
+	ctx := Extract(context.Background(), validTraceparent)
+	assert.Equal(t, validTraceparent, Traceparent(ctx))
+
+	assert.Equal(t, "", Traceparent(Extract(context.Background(), "garbage")))
+	assert.Equal(t, "", Traceparent(context.Background()))
+}
@@ -0,0 +1,330 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mateusmacedo/go-sls-marketplace/pkg/application"
+)
+
+// SpanKind follows the OTLP numbering.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+	SpanKindServer   SpanKind = 2
+)
+
+// StatusCode follows the OTLP numbering.
+type StatusCode int
+
+const (
+	StatusUnset StatusCode = 0
+	StatusError StatusCode = 2
+)
+
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// SpanData is an ended span, as handed to the Exporter.
+type SpanData struct {
+	SpanContext
+	ParentSpanID  SpanID
+	Name          string
+	Kind          SpanKind
+	Service       string
+	Start         time.Time
+	End           time.Time
+	Attributes    []Attribute
+	Status        StatusCode
+	StatusMessage string
+}
+
+// Attribute returns the value of the attribute key of span, or nil.
+func (span SpanData) Attribute(key string) interface{} {
+	for _, attribute := range span.Attributes {
+		if attribute.Key == key {
+			return attribute.Value
+		}
+	}
+	return nil
+}
+
+// Exporter sends ended spans to a tracing backend.
+type Exporter interface {
+	Export(ctx context.Context, spans []SpanData) error
+}
+
+// Tracer implements application.Tracer. The first span started in a
+// process for a request, the one without a local parent, is a server span;
+// the spans started under it are buffered and handed to the exporter with
+// it once it ends. Unless WithSyncExport is set, the traces are exported in
+// batches from a background goroutine, which ForceFlush and Shutdown wait
+// for. A remote parent that was not sampled is followed: its spans
+// propagate but are never exported.
+type Tracer struct {
+	exporter     Exporter
+	service      string
+	now          func() time.Time
+	logger       application.Logger
+	sync         bool
+	queueSize    int
+	batchSize    int
+	batchTimeout time.Duration
+	batcher      *batcher
+}
+
+type Option func(*Tracer)
+
+// WithServiceName sets the service.name resource of the spans.
+func WithServiceName(service string) Option {
+	return func(t *Tracer) {
+		t.service = service
+	}
+}
+
+// WithClock replaces time.Now as the source of span timestamps.
+func WithClock(now func() time.Time) Option {
+	return func(t *Tracer) {
+		t.now = now
+	}
+}
+
+// WithLogger sets the logger that reports failed exports.
+func WithLogger(logger application.Logger) Option {
+	return func(t *Tracer) {
+		t.logger = logger
+	}
+}
+
+// WithSyncExport exports every trace from the goroutine that ends its root
+// span, mostly for tests and short-lived commands.
+func WithSyncExport() Option {
+	return func(t *Tracer) {
+		t.sync = true
+	}
+}
+
+// WithQueueSize sets how many ended traces may wait for export before new
+// ones are dropped.
+func WithQueueSize(size int) Option {
+	return func(t *Tracer) {
+		t.queueSize = size
+	}
+}
+
+// WithBatch sets the most spans exported at once and how long an ended
+// trace may wait for a batch to fill.
+func WithBatch(size int, timeout time.Duration) Option {
+	return func(t *Tracer) {
+		t.batchSize = size
+		t.batchTimeout = timeout
+	}
+}
+
+func NewTracer(exporter Exporter, opts ...Option) *Tracer {
+	t := &Tracer{
+		exporter:     exporter,
+		now:          time.Now,
+		logger:       application.NopLogger(),
+		queueSize:    defaultQueueSize,
+		batchSize:    defaultBatchSize,
+		batchTimeout: defaultBatchTimeout,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.queueSize <= 0 {
+		t.queueSize = defaultQueueSize
+	}
+	if t.batchSize <= 0 {
+		t.batchSize = defaultBatchSize
+	}
+	if t.batchTimeout <= 0 {
+		t.batchTimeout = defaultBatchTimeout
+	}
+	if !t.sync {
+		t.batcher = newBatcher(t.exportNow, func(dropped int64) {
+			t.logger.Warn("Dropped spans, the export queue was full", "spans", dropped)
+		}, t.queueSize, t.batchSize, t.batchTimeout)
+	}
+	return t
+}
+
+// ForceFlush exports the traces ended so far, waiting until ctx is done.
+func (t *Tracer) ForceFlush(ctx context.Context) error {
+	if t.batcher == nil {
+		return nil
+	}
+	return t.batcher.forceFlush(ctx)
+}
+
+// Shutdown exports the traces ended so far and stops exporting, waiting
+// until ctx is done.
+func (t *Tracer) Shutdown(ctx context.Context) error {
+	if t.batcher == nil {
+		return nil
+	}
+	return t.batcher.shutdown(ctx)
+}
+
+// Close shuts the tracer down, giving the last export up to
+// closeTimeout, so it can be closed with the server's other resources.
+func (t *Tracer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), closeTimeout)
+	defer cancel()
+	return t.Shutdown(ctx)
+}
+
+func (t *Tracer) Start(ctx context.Context, name string, kv ...interface{}) (context.Context, application.Span) {
+	s := &span{tracer: t, data: SpanData{Name: name, Kind: SpanKindInternal, Service: t.service}}
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.data.TraceID = parent.data.TraceID
+		s.data.ParentSpanID = parent.data.SpanID
+		s.data.Sampled = parent.data.Sampled
+		s.trace = parent.trace
+	} else {
+		if remote, ok := ctx.Value(remoteKey{}).(SpanContext); ok && remote.IsValid() {
+			s.data.TraceID = remote.TraceID
+			s.data.ParentSpanID = remote.SpanID
+			s.data.Sampled = remote.Sampled
+		} else {
+			s.data.TraceID = newTraceID()
+			s.data.Sampled = true
+		}
+		s.data.Kind = SpanKindServer
+		s.trace = &localTrace{}
+		s.root = true
+	}
+	s.data.SpanID = newSpanID()
+	s.data.Start = t.now()
+	s.SetAttributes(kv...)
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func (t *Tracer) export(spans []SpanData) {
+	if t.batcher != nil {
+		t.batcher.enqueue(spans)
+		return
+	}
+	t.exportNow(spans)
+}
+
+func (t *Tracer) exportNow(spans []SpanData) {
+	if err := t.exporter.Export(context.Background(), spans); err != nil {
+		t.logger.Error("Exporting spans failed", err, "spans", len(spans))
+	}
+}
+
+// localTrace buffers the ended spans of a trace in this process until its
+// root span ends.
+type localTrace struct {
+	spans    []SpanData
+	exported bool
+	mu       sync.Mutex
+}
+
+type span struct {
+	tracer *Tracer
+	trace  *localTrace
+	root   bool
+	data   SpanData
+	ended  bool
+	mu     sync.Mutex
+}
+
+func (s *span) SetAttributes(kv ...interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := 0; i+1 < len(kv); i += 2 {
+		s.data.Attributes = append(s.data.Attributes, Attribute{Key: fmt.Sprint(kv[i]), Value: attributeValue(kv[i+1])})
+	}
+}
+
+func (s *span) RecordError(err error) {
+	if err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data.Status = StatusError
+	s.data.StatusMessage = err.Error()
+}
+
+func (s *span) End() {
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.data.End = s.tracer.now()
+	data := s.data
+	s.mu.Unlock()
+
+	if !data.Sampled {
+		return
+	}
+
+	s.trace.mu.Lock()
+	if s.trace.exported {
+		// A span outliving its root, such as one ended by a goroutine the
+		// request left behind, is exported on its own.
+		s.trace.mu.Unlock()
+		s.tracer.export([]SpanData{data})
+		return
+	}
+	s.trace.spans = append(s.trace.spans, data)
+	if !s.root {
+		s.trace.mu.Unlock()
+		return
+	}
+	spans := s.trace.spans
+	s.trace.spans = nil
+	s.trace.exported = true
+	s.trace.mu.Unlock()
+
+	s.tracer.export(spans)
+}
+
+// attributeValue keeps the types OTLP has a value for and formats the rest.
+func attributeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string, bool, int64, float64:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+	return fmt.Sprint(value)
+}
+
+func newTraceID() TraceID {
+	var id TraceID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
+
+func newSpanID() SpanID {
+	var id SpanID
+	for !id.IsValid() {
+		rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,142 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingExporter struct {
+	exports [][]SpanData
+	err     error
+	mu      sync.Mutex
+}
+
+func (e *recordingExporter) Export(ctx context.Context, spans []SpanData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.exports = append(e.exports, spans)
+	return e.err
+}
+
+func TestTracer_ExportsTraceWhenRootEnds(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, WithServiceName("catalog"), WithSyncExport())
+
+	ctx, root := tracer.Start(context.Background(), "GET /products/{id}", "http.route", "/products/{id}")
+	childCtx, child := tracer.Start(ctx, "GetProductUseCase.Execute", "product.id", "1")
+	_, grandchild := tracer.Start(childCtx, "ProductFindRepository.Find")
+	grandchild.RecordError(errors.New("boom"))
+	grandchild.End()
+	child.RecordError(nil)
+	child.End()
+
+	assert.Empty(t, exporter.exports, "nothing is exported before the root ends")
+
+	root.End()
+	root.End()
+
+	require.Len(t, exporter.exports, 1)
+	spans := exporter.exports[0]
+	require.Len(t, spans, 3)
+
+	assert.Equal(t, "ProductFindRepository.Find", spans[0].Name)
+	assert.Equal(t, "GetProductUseCase.Execute", spans[1].Name)
+	assert.Equal(t, "GET /products/{id}", spans[2].Name)
+
+	for _, span := range spans {
+		assert.Equal(t, spans[2].TraceID, span.TraceID)
+		assert.Equal(t, "catalog", span.Service)
+		assert.True(t, span.Sampled)
+	}
+	assert.Equal(t, spans[1].SpanID, spans[0].ParentSpanID)
+	assert.Equal(t, spans[2].SpanID, spans[1].ParentSpanID)
+	assert.False(t, spans[2].ParentSpanID.IsValid())
+
+	assert.Equal(t, SpanKindServer, spans[2].Kind)
+	assert.Equal(t, SpanKindInternal, spans[1].Kind)
+	assert.Equal(t, StatusError, spans[0].Status)
+	assert.Equal(t, "boom", spans[0].StatusMessage)
+	assert.Equal(t, StatusUnset, spans[1].Status)
+	assert.Equal(t, []Attribute{{Key: "product.id", Value: "1"}}, spans[1].Attributes)
+}
+
+func TestTracer_ContinuesRemoteParent(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, WithSyncExport())
+
+	ctx := Extract(context.Background(), validTraceparent)
+	ctx, span := tracer.Start(ctx, "GET /products")
+	assert.Regexp(t, "^00-4bf92f3577b34da6a3ce929d0e0e4736-[0-9a-f]{16}-01$", Traceparent(ctx))
+	span.End()
+
+	require.Len(t, exporter.exports, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", exporter.exports[0][0].TraceID.String())
+	assert.Equal(t, "00f067aa0ba902b7", exporter.exports[0][0].ParentSpanID.String())
+}
+
+func TestTracer_DoesNotExportUnsampledTraces(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, WithSyncExport())
+
+	ctx := Extract(context.Background(), "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00")
+	ctx, root := tracer.Start(ctx, "GET /products")
+	_, child := tracer.Start(ctx, "GetAllProductsUseCase.Execute")
+	child.End()
+	root.End()
+
+	assert.Empty(t, exporter.exports)
+	assert.Regexp(t, "-00$", Traceparent(ctx), "the sampling decision propagates")
+}
+
+func TestTracer_ExportsSpansEndingAfterRoot(t *testing.T) {
+	exporter := &recordingExporter{}
+	tracer := NewTracer(exporter, WithSyncExport())
+
+	ctx, root := tracer.Start(context.Background(), "root")
+	_, late := tracer.Start(ctx, "late")
+	root.End()
+	late.End()
+
+	require.Len(t, exporter.exports, 2)
+	assert.Equal(t, "late", exporter.exports[1][0].Name)
+}
+
+func TestTracer_Timestamps(t *testing.T) {
+	exporter := &recordingExporter{}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tracer := NewTracer(exporter, WithSyncExport(), WithClock(func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}))
+
+	_, span := tracer.Start(context.Background(), "root")
+	span.End()
+
+	require.Len(t, exporter.exports, 1)
+	assert.Equal(t, time.Second, exporter.exports[0][0].End.Sub(exporter.exports[0][0].Start))
+}
+
+func TestAttributeValue(t *testing.T) {
+	tests := []struct {
+		value    interface{}
+		expected interface{}
+	}{
+		{value: "text", expected: "text"},
+		{value: true, expected: true},
+		{value: 3, expected: int64(3)},
+		{value: float32(1.5), expected: float64(1.5)},
+		{value: errors.New("boom"), expected: "boom"},
+		{value: time.Second, expected: "1s"},
+		{value: []string{"a"}, expected: "[a]"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, attributeValue(tt.value))
+	}
+}